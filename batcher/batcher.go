@@ -0,0 +1,205 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// ErrResultCount is returned to executions when a batch func returns a different number of results than it was given
+// items.
+var ErrResultCount = errors.New("batch returned a different number of results than items")
+
+// ItemErrors can be returned by a BatchFunc to provide a separate error for each item in a batch, where a nil error
+// indicates that the item succeeded. ItemErrors must have the same length as the batch's items, else the ItemErrors
+// will be returned to every execution in the batch.
+type ItemErrors []error
+
+func (e ItemErrors) Error() string {
+	failed := 0
+	for _, err := range e {
+		if err != nil {
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d batch items failed", failed, len(e))
+}
+
+// BatchFunc performs a batch of items, returning a result for each item in the same order as the items. If an error is
+// returned, it's provided to every execution in the batch, unless it's ItemErrors. A panic is recovered and provided to
+// every execution in the batch as a *failsafe.PanicError.
+type BatchFunc[I any, R any] func(ctx context.Context, items []I) ([]R, error)
+
+// Batcher is a policy that collects executions that share a batch key into batches, performs each batch with a single
+// call to a BatchFunc, and provides each execution with the result for its item. A batch is performed when it reaches
+// the max batch size or when the max delay has elapsed since its first item was added, whichever comes first.
+//
+// An execution's func is not called when it's handled by a Batcher, since the BatchFunc performs its item instead.
+// Policies that should apply to batches, such as a RetryPolicy or Timeout, can be configured via
+// BatcherBuilder.WithBatchExecutor. Policies composed outside of a Batcher apply to each execution, so that retrying an
+// execution adds its item to a new batch.
+//
+// Executions that are waiting on a batch can be canceled without affecting the batch.
+//
+// R is the execution result type. This type is concurrency safe.
+type Batcher[R any] interface {
+	failsafe.Policy[R]
+
+	// Pending returns the number of items that are waiting for their batch to be performed.
+	Pending() int
+}
+
+// BatchDoneEvent indicates that a batch is done.
+type BatchDoneEvent struct {
+	// Key is the key that the batch's executions shared.
+	Key string
+
+	// Size is the number of items in the batch.
+	Size int
+
+	// ElapsedTime is the time it took to perform the batch.
+	ElapsedTime time.Duration
+
+	// Error is the error that the batch returned, if any.
+	Error error
+}
+
+// BatcherBuilder builds Batcher instances.
+//
+// I is the batch item type and R is the execution result type. This type is not concurrency safe.
+type BatcherBuilder[I any, R any] interface {
+	failsafe.NamedPolicyBuilder[BatcherBuilder[I, R]]
+
+	// WithMaxBatchSize configures the max number of items in a batch. Defaults to 100.
+	WithMaxBatchSize(maxBatchSize int) BatcherBuilder[I, R]
+
+	// WithMaxDelay configures the max time that a batch will wait for more items after its first item is added. Defaults
+	// to 10 milliseconds.
+	WithMaxDelay(maxDelay time.Duration) BatcherBuilder[I, R]
+
+	// WithKeyFunc configures a keyFunc that is called with each execution's Context to determine which batch the execution
+	// belongs to. By default, all executions share a single batch key.
+	WithKeyFunc(keyFunc func(ctx context.Context) string) BatcherBuilder[I, R]
+
+	// WithBatchExecutor configures an executor that batches are performed with, such as to retry or time out batches.
+	WithBatchExecutor(executor failsafe.Executor[[]R]) BatcherBuilder[I, R]
+
+	// OnBatchDone registers the listener to be called when a batch is done, before its executions are provided with results.
+	OnBatchDone(listener func(event BatchDoneEvent)) BatcherBuilder[I, R]
+
+	// Build returns a new Batcher using the builder's configuration.
+	Build() Batcher[R]
+}
+
+type config[I any, R any] struct {
+	name          string
+	itemFunc      func(ctx context.Context) I
+	batchFn       BatchFunc[I, R]
+	maxBatchSize  int
+	maxDelay      time.Duration
+	keyFunc       func(ctx context.Context) string
+	batchExecutor failsafe.Executor[[]R]
+	onBatchDone   func(BatchDoneEvent)
+}
+
+var _ BatcherBuilder[any, any] = &config[any, any]{}
+
+type batcher[I any, R any] struct {
+	*config[I, R]
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	batches map[string]*batch[I, R]
+	// Guarded by mtx
+	pending int
+}
+
+// With returns a new Batcher that performs batches of items via the batchFn. The itemFunc is called with each
+// execution's Context to get the execution's item.
+func With[I any, R any](itemFunc func(ctx context.Context) I, batchFn BatchFunc[I, R]) Batcher[R] {
+	return Builder[I, R](itemFunc, batchFn).Build()
+}
+
+// Builder returns a BatcherBuilder for batch item type I and execution result type R that builds Batchers which perform
+// batches of items via the batchFn. The itemFunc is called with each execution's Context to get the execution's item.
+func Builder[I any, R any](itemFunc func(ctx context.Context) I, batchFn BatchFunc[I, R]) BatcherBuilder[I, R] {
+	return &config[I, R]{
+		itemFunc:     itemFunc,
+		batchFn:      batchFn,
+		maxBatchSize: 100,
+		maxDelay:     10 * time.Millisecond,
+	}
+}
+
+func (c *config[I, R]) WithMaxBatchSize(maxBatchSize int) BatcherBuilder[I, R] {
+	c.maxBatchSize = maxBatchSize
+	return c
+}
+
+func (c *config[I, R]) WithMaxDelay(maxDelay time.Duration) BatcherBuilder[I, R] {
+	c.maxDelay = maxDelay
+	return c
+}
+
+func (c *config[I, R]) WithKeyFunc(keyFunc func(ctx context.Context) string) BatcherBuilder[I, R] {
+	c.keyFunc = keyFunc
+	return c
+}
+
+func (c *config[I, R]) WithBatchExecutor(executor failsafe.Executor[[]R]) BatcherBuilder[I, R] {
+	c.batchExecutor = executor
+	return c
+}
+
+func (c *config[I, R]) OnBatchDone(listener func(event BatchDoneEvent)) BatcherBuilder[I, R] {
+	c.onBatchDone = listener
+	return c
+}
+
+func (c *config[I, R]) WithName(name string) BatcherBuilder[I, R] {
+	c.name = name
+	return c
+}
+
+func (c *config[I, R]) Build() Batcher[R] {
+	cCopy := *c
+	return &batcher[I, R]{
+		config:  &cCopy,
+		batches: make(map[string]*batch[I, R]),
+	}
+}
+
+func (b *batcher[I, R]) Pending() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.pending
+}
+
+func (b *batcher[I, R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.BatcherKind
+}
+
+func (b *batcher[I, R]) PolicyName() string {
+	return b.name
+}
+
+func (b *batcher[I, R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"maxBatchSize": b.maxBatchSize,
+		"maxDelay":     b.maxDelay,
+	}
+}
+
+func (b *batcher[I, R]) ToExecutor(_ R) any {
+	e := &executor[I, R]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		batcher:      b,
+	}
+	e.Executor = e
+	return e
+}
@@ -0,0 +1,141 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// executor is a policy.Executor that handles executions according to a Batcher.
+type executor[I any, R any] struct {
+	*policy.BaseExecutor[R]
+	*batcher[I, R]
+}
+
+var _ policy.Executor[any] = &executor[any, any]{}
+
+// batch is a batch of items that is pending or being performed.
+type batch[I any, R any] struct {
+	// The context that the batch is performed with, which is derived from the first item's context without its cancellation
+	ctx   context.Context
+	timer *time.Timer
+	done  chan struct{}
+	// Guarded by the batcher's mtx until the batch is performed
+	items []I
+	// Set before done is closed
+	results []R
+	err     error
+}
+
+func (e *executor[I, R]) Apply(_ func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+		ctx := exec.Context()
+		var key string
+		if e.keyFunc != nil {
+			key = e.keyFunc(ctx)
+		}
+		item := e.itemFunc(ctx)
+
+		e.mtx.Lock()
+		b, ok := e.batches[key]
+		if !ok {
+			b = &batch[I, R]{
+				ctx:  context.WithoutCancel(ctx),
+				done: make(chan struct{}),
+			}
+			e.batches[key] = b
+			b.timer = time.AfterFunc(e.maxDelay, func() {
+				e.perform(key, b)
+			})
+		}
+		index := len(b.items)
+		b.items = append(b.items, item)
+		e.pending++
+		full := len(b.items) >= e.maxBatchSize
+		if full {
+			delete(e.batches, key)
+		}
+		e.mtx.Unlock()
+		if full && b.timer.Stop() {
+			go e.perform(key, b)
+		}
+
+		select {
+		case <-b.done:
+			return b.resultFor(index)
+		case <-exec.Canceled():
+			if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled && cancelResult != nil {
+				return cancelResult
+			}
+			return &common.PolicyResult[R]{Error: ctx.Err(), Done: true}
+		}
+	}
+}
+
+// perform removes the batch so that no more items are added to it, then performs it.
+func (e *executor[I, R]) perform(key string, b *batch[I, R]) {
+	e.mtx.Lock()
+	if e.batches[key] == b {
+		delete(e.batches, key)
+	}
+	items := b.items
+	e.pending -= len(items)
+	e.mtx.Unlock()
+
+	// Always release the batch's executions, even if a listener panics
+	defer close(b.done)
+	start := time.Now()
+	b.results, b.err = e.performItems(b.ctx, items)
+	if b.err == nil && len(b.results) != len(items) {
+		b.err = ErrResultCount
+	}
+	if e.onBatchDone != nil {
+		e.onBatchDone(BatchDoneEvent{
+			Key:         key,
+			Size:        len(items),
+			ElapsedTime: time.Since(start),
+			Error:       b.err,
+		})
+	}
+}
+
+// performItems performs the items via the batchFn, recovering a panic as a *failsafe.PanicError that fails all of the
+// items.
+func (e *executor[I, R]) performItems(ctx context.Context, items []I) (results []R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			results, err = nil, &failsafe.PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	if e.batchExecutor == nil {
+		return e.batchFn(ctx, items)
+	}
+	return e.batchExecutor.WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[[]R]) ([]R, error) {
+		return e.batchFn(exec.Context(), items)
+	})
+}
+
+func (b *batch[I, R]) resultFor(index int) *common.PolicyResult[R] {
+	err := b.err
+	var itemErrs ItemErrors
+	if errors.As(err, &itemErrs) && len(itemErrs) == len(b.items) {
+		err = itemErrs[index]
+	}
+	var result R
+	if index < len(b.results) {
+		result = b.results[index]
+	}
+	return &common.PolicyResult[R]{
+		Result:     result,
+		Error:      err,
+		Done:       true,
+		Success:    err == nil,
+		SuccessAll: err == nil,
+	}
+}
@@ -0,0 +1,2 @@
+// Package batcher provides a Batcher policy.
+package batcher
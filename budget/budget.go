@@ -0,0 +1,129 @@
+package budget
+
+import (
+	"errors"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// ErrExceeded is returned when an execution does not have enough of its deadline budget remaining, or when it exceeds
+// its trimmed deadline.
+var ErrExceeded = errors.New("deadline budget exceeded")
+
+// Budget is a Policy that trims an execution's context deadline by a reserve, leaving time for outer policies, such as
+// fallbacks and retries, to handle a failure before the deadline. Executions whose trimmed deadline has already passed,
+// or that have less than a minimum remaining, fail fast with ErrExceeded, and executions that exceed their trimmed
+// deadline are canceled with ErrExceeded. Budgets can be composed at multiple layers, each reserving time for the
+// policies outside of it:
+//
+//	failsafe.NewExecutor[R](fallback, budget.With[R](50*time.Millisecond), retryPolicy, budget.With[R](20*time.Millisecond))
+//
+// Executions whose context has no deadline are not affected by a Budget.
+//
+// R is the execution result type. This type is concurrency safe.
+type Budget[R any] interface {
+	failsafe.Policy[R]
+}
+
+// BudgetBuilder builds Budget instances.
+//
+// R is the execution result type. This type is not concurrency safe.
+type BudgetBuilder[R any] interface {
+	failsafe.NamedPolicyBuilder[BudgetBuilder[R]]
+
+	// WithMinRemaining configures the minimum time that must remain before the trimmed deadline for an execution to be
+	// attempted, else the execution fails fast with ErrExceeded. By default, an execution is attempted if any time remains.
+	WithMinRemaining(minRemaining time.Duration) BudgetBuilder[R]
+
+	// WithClock configures the clock that is used to time executions against the remaining budget, such as a
+	// failsafe.FakeClock when testing. The remaining budget is computed from the context's deadline with the system
+	// clock, since context deadlines are measured with it. By default, failsafe.SystemClock is used.
+	WithClock(clock failsafe.TimerClock) BudgetBuilder[R]
+
+	// OnBudgetExceeded registers the listener to be called when an execution fails fast or exceeds its trimmed deadline.
+	OnBudgetExceeded(listener func(event failsafe.ExecutionDoneEvent[R])) BudgetBuilder[R]
+
+	// Build returns a new Budget using the builder's configuration.
+	Build() Budget[R]
+}
+
+type config[R any] struct {
+	name             string
+	reserve          time.Duration
+	minRemaining     time.Duration
+	clock            failsafe.TimerClock
+	onBudgetExceeded func(failsafe.ExecutionDoneEvent[R])
+}
+
+var _ BudgetBuilder[any] = &config[any]{}
+
+type budget[R any] struct {
+	*config[R]
+}
+
+// With returns a new Budget for execution result type R that trims the execution's context deadline by the reserve.
+func With[R any](reserve time.Duration) Budget[R] {
+	return Builder[R](reserve).Build()
+}
+
+// Builder returns a BudgetBuilder for execution result type R which builds Budgets that trim the execution's context
+// deadline by the reserve.
+func Builder[R any](reserve time.Duration) BudgetBuilder[R] {
+	return &config[R]{
+		reserve: reserve,
+		clock:   failsafe.SystemClock(),
+	}
+}
+
+func (c *config[R]) WithMinRemaining(minRemaining time.Duration) BudgetBuilder[R] {
+	c.minRemaining = minRemaining
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.TimerClock) BudgetBuilder[R] {
+	c.clock = clock
+	return c
+}
+
+func (c *config[R]) OnBudgetExceeded(listener func(event failsafe.ExecutionDoneEvent[R])) BudgetBuilder[R] {
+	c.onBudgetExceeded = listener
+	return c
+}
+
+func (c *config[R]) WithName(name string) BudgetBuilder[R] {
+	c.name = name
+	return c
+}
+
+func (c *config[R]) Build() Budget[R] {
+	bCopy := *c
+	return &budget[R]{
+		config: &bCopy,
+	}
+}
+
+func (b *budget[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.BudgetKind
+}
+
+func (b *budget[R]) PolicyName() string {
+	return b.name
+}
+
+func (b *budget[R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"reserve":      b.reserve,
+		"minRemaining": b.minRemaining,
+	}
+}
+
+func (b *budget[R]) ToExecutor(_ R) any {
+	be := &executor[R]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		budget:       b,
+	}
+	be.Executor = be
+	return be
+}
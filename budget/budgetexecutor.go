@@ -0,0 +1,64 @@
+package budget
+
+import (
+	"errors"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal"
+	"github.com/failsafe-go/failsafe-go/internal/timelimit"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// executor is a policy.Executor that handles failures according to a Budget.
+type executor[R any] struct {
+	*policy.BaseExecutor[R]
+	*budget[R]
+}
+
+var _ policy.Executor[any] = &executor[any]{}
+
+func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+		deadline, ok := exec.Context().Deadline()
+		if !ok {
+			return innerFn(exec)
+		}
+
+		// Fail fast if there is not enough budget remaining. Context deadlines are measured with the system clock.
+		trimmedDeadline := deadline.Add(-e.reserve)
+		remaining := time.Until(trimmedDeadline)
+		if remaining <= e.minRemaining {
+			e.exceeded(execInternal, remaining)
+			return internal.FailureResult[R](ErrExceeded)
+		}
+
+		// Race the innerFn against the remaining budget, as measured by the clock
+		execInternal, result, _ := timelimit.Apply(execInternal, e.clock, trimmedDeadline, remaining, innerFn,
+			func(policy.ExecutionInternal[R]) *common.PolicyResult[R] {
+				return internal.FailureResult[R](ErrExceeded)
+			},
+			func(exec policy.ExecutionInternal[R], _ *common.PolicyResult[R]) {
+				e.exceeded(exec, 0)
+			})
+		return e.PostExecute(execInternal, result)
+	}
+}
+
+func (e *executor[R]) exceeded(exec policy.ExecutionInternal[R], remaining time.Duration) {
+	if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+		logger.Debug("budget exceeded", "policy", failsafe.BudgetKind, "attempts", exec.Attempts(), "remaining", remaining)
+	}
+	if e.onBudgetExceeded != nil {
+		e.onBudgetExceeded(failsafe.ExecutionDoneEvent[R]{
+			ExecutionInfo: exec,
+			Error:         ErrExceeded,
+		})
+	}
+}
+
+func (e *executor[R]) IsFailure(_ R, err error) bool {
+	return err != nil && errors.Is(err, ErrExceeded)
+}
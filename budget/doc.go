@@ -0,0 +1,2 @@
+// Package budget provides a Budget policy.
+package budget
@@ -0,0 +1,160 @@
+package failsafe
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// BulkOption configures how Map, ForEach, and ForEachChan perform executions.
+type BulkOption func(*bulkConfig)
+
+type bulkConfig struct {
+	concurrency int
+}
+
+// WithConcurrency configures the max number of items that are executed concurrently. Defaults to 1, which executes
+// items one at a time, in order.
+func WithConcurrency(concurrency int) BulkOption {
+	return func(c *bulkConfig) {
+		c.concurrency = max(concurrency, 1)
+	}
+}
+
+func newBulkConfig(options []BulkOption) *bulkConfig {
+	config := &bulkConfig{concurrency: 1}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// BulkError is returned by Map, ForEach, and ForEachChan when the executions for one or more items fail.
+type BulkError struct {
+	// Errors contains the error for each item, in the order of the items, and is nil for items whose execution succeeded.
+	Errors []error
+}
+
+func (e *BulkError) Error() string {
+	failed := e.Unwrap()
+	return fmt.Sprintf("%d of %d executions failed: %v", len(failed), len(e.Errors), failed[0])
+}
+
+// Unwrap returns the errors for the items whose execution failed.
+func (e *BulkError) Unwrap() []error {
+	var failed []error
+	for _, err := range e.Errors {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return failed
+}
+
+// newBulkError returns a BulkError for the errs, or nil if the errs are all nil.
+func newBulkError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return &BulkError{Errors: errs}
+		}
+	}
+	return nil
+}
+
+// Map calls the fn for each of the items via the executor, so that the executor's policies are applied separately to
+// each item, and returns the results in the order of the items. If the executions for any items fail, a *BulkError is
+// returned along with the results, which are the zero value for failed items. Example usage:
+//
+//	responses, err := failsafe.Map(urls, fetch, executor, failsafe.WithConcurrency(10))
+//
+// T is the item type and R is the execution result type.
+func Map[T any, R any](items []T, fn func(item T) (R, error), executor Executor[R], options ...BulkOption) ([]R, error) {
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	runBounded(len(items), newBulkConfig(options).concurrency, func(i int) {
+		results[i], errs[i] = executor.Get(func() (R, error) {
+			return fn(items[i])
+		})
+	})
+	return results, newBulkError(errs)
+}
+
+// ForEach calls the fn for each of the items via the executor, so that the executor's policies are applied separately
+// to each item. If the executions for any items fail, a *BulkError is returned.
+//
+// T is the item type.
+func ForEach[T any](items []T, fn func(item T) error, executor Executor[any], options ...BulkOption) error {
+	errs := make([]error, len(items))
+	runBounded(len(items), newBulkConfig(options).concurrency, func(i int) {
+		errs[i] = executor.Run(func() error {
+			return fn(items[i])
+		})
+	})
+	return newBulkError(errs)
+}
+
+// ForEachChan calls the fn for each of the items that are received from the channel via the executor, until the channel
+// is closed, so that the executor's policies are applied separately to each item. If the executions for any items
+// fail, a *BulkError is returned, whose errors are in the order that items were received.
+//
+// T is the item type.
+func ForEachChan[T any](items <-chan T, fn func(item T) error, executor Executor[any], options ...BulkOption) error {
+	// Guards receiving items, so that they're indexed in the order they're received
+	var receiveMtx sync.Mutex
+	// Guards errs, and is never held while receiving, so that recording errors doesn't wait on the channel
+	var mtx sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	for i := 0; i < newBulkConfig(options).concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				receiveMtx.Lock()
+				item, ok := <-items
+				if !ok {
+					receiveMtx.Unlock()
+					return
+				}
+				mtx.Lock()
+				index := len(errs)
+				errs = append(errs, nil)
+				mtx.Unlock()
+				receiveMtx.Unlock()
+
+				err := executor.Run(func() error {
+					return fn(item)
+				})
+				mtx.Lock()
+				errs[index] = err
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return newBulkError(errs)
+}
+
+// runBounded calls the fn for each index up to n, from up to concurrency goroutines at a time, and waits for them to
+// complete.
+func runBounded(n int, concurrency int, fn func(i int)) {
+	if concurrency == 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < n; i = int(next.Add(1) - 1) {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
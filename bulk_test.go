@@ -0,0 +1,103 @@
+package failsafe_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+// Asserts that Map applies policies to each item, and returns results in the order of the items.
+func TestMap(t *testing.T) {
+	// Given
+	executor := failsafe.NewExecutor[string](retrypolicy.WithDefaults[string]())
+	var mtx sync.Mutex
+	attempts := make(map[int]int)
+	fn := func(item int) (string, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		attempts[item]++
+		// Fail the first attempt for even items, and all attempts for item 3
+		if item == 3 || (item%2 == 0 && attempts[item] == 1) {
+			return "", errors.New("failed " + strconv.Itoa(item))
+		}
+		return strconv.Itoa(item), nil
+	}
+
+	// When
+	results, err := failsafe.Map([]int{0, 1, 2, 3, 4}, fn, executor, failsafe.WithConcurrency(3))
+
+	// Then
+	assert.Equal(t, []string{"0", "1", "2", "", "4"}, results)
+	var bulkErr *failsafe.BulkError
+	assert.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, []error{nil, nil, nil, bulkErr.Errors[3], nil}, bulkErr.Errors)
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	assert.Equal(t, map[int]int{0: 2, 1: 1, 2: 2, 3: 3, 4: 2}, attempts)
+}
+
+// Asserts that ForEach does not exceed the configured concurrency.
+func TestForEachWithConcurrency(t *testing.T) {
+	// Given
+	executor := failsafe.NewExecutor[any]()
+	var running, maxRunning atomic.Int32
+	fn := func(item int) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			observed := maxRunning.Load()
+			if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+
+	// When
+	err := failsafe.ForEach(make([]int, 10), fn, executor, failsafe.WithConcurrency(3))
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), maxRunning.Load())
+}
+
+// Asserts that ForEachChan executes items until the channel is closed, and indexes errors in the order items are
+// received.
+func TestForEachChan(t *testing.T) {
+	// Given
+	executor := failsafe.NewExecutor[any]()
+	items := make(chan int)
+	go func() {
+		for i := 0; i < 5; i++ {
+			items <- i
+		}
+		close(items)
+	}()
+	var executed atomic.Int32
+	fn := func(item int) error {
+		executed.Add(1)
+		if item == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	}
+
+	// When
+	err := failsafe.ForEachChan(items, fn, executor, failsafe.WithConcurrency(2))
+
+	// Then
+	assert.Equal(t, int32(5), executed.Load())
+	var bulkErr *failsafe.BulkError
+	assert.ErrorAs(t, err, &bulkErr)
+	assert.Len(t, bulkErr.Errors, 5)
+	assert.Error(t, bulkErr.Errors[1])
+	assert.Len(t, bulkErr.Unwrap(), 1)
+}
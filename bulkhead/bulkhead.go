@@ -3,17 +3,53 @@ package bulkhead
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
-// ErrFull is returned when an execution is attempted against a Bulkhead that is full.
+// ErrFull is a convenience error sentinel that can be used to build policies that handle FullError, such as via
+// HandleErrors(bulkhead.ErrFull). It can also be used with Errors.Is to determine whether an error is a
+// bulkhead.FullError.
 var ErrFull = errors.New("bulkhead full")
 
+// FullError is returned when an execution is attempted against a Bulkhead that is full. This type can be used with
+// HandleErrorTypes(bulkhead.FullError{}), or with errors.As to determine which of several Bulkheads rejected an
+// execution.
+type FullError struct {
+	// Bulkhead is the Bulkhead that rejected the execution, which can be compared with a Bulkhead instance.
+	Bulkhead any
+	// Name is the name of the Bulkhead, else an empty string if it's not named.
+	Name string
+	// MaxConcurrency is the Bulkhead's max concurrency.
+	MaxConcurrency uint
+	// MaxWaitTime is the time that was waited for a permit.
+	MaxWaitTime time.Duration
+}
+
+func (e FullError) Error() string {
+	return fmt.Sprintf("bulkhead full. %smax concurrency: %d, max wait time: %v", internal.ErrorName(e.Name), e.MaxConcurrency, e.MaxWaitTime)
+}
+
+func (e FullError) Is(err error) bool {
+	if err == ErrFull {
+		return true
+	}
+	return err == e
+}
+
+// ErrDraining is returned when an execution is attempted against, or is waiting on, a Bulkhead that is draining.
+var ErrDraining = errors.New("bulkhead draining")
+
 // Bulkhead is a policy restricts concurrent executions as a way of preventing system overload.
 //
+// Unlike a RateLimiter, a Bulkhead only tracks its permits in memory, and has no backing store that can become
+// unavailable, so it has no option to fail open.
+//
 // R is the execution result type. This type is concurrency safe.
 type Bulkhead[R any] interface {
 	failsafe.Policy[R]
@@ -40,26 +76,53 @@ type Bulkhead[R any] interface {
 	// waiting. Returns true if the permit was acquired, else false. Callers should call ReleasePermit to release a
 	// successfully acquired permit back to the Bulkhead.
 	TryAcquirePermit() bool
+
+	// Drain stops the Bulkhead from admitting new executions, fails any waiting executions with ErrDraining, and waits
+	// until inflight executions have released their permits or the ctx is done. Returns the ctx error if inflight
+	// executions did not complete in time. Drain progress is reported to any OnDrainProgress listener. Once drained, a
+	// Bulkhead does not admit executions again.
+	//
+	// ctx may be nil.
+	Drain(ctx context.Context) error
+}
+
+// DrainEvent indicates a Bulkhead's drain progress.
+type DrainEvent struct {
+	// Inflight is the number of executions that are still inflight.
+	Inflight int
 }
 
 // BulkheadBuilder builds Bulkhead instances.
 //
 // R is the execution result type. This type is not concurrency safe.
 type BulkheadBuilder[R any] interface {
+	failsafe.NamedPolicyBuilder[BulkheadBuilder[R]]
+
 	// WithMaxWaitTime configures the maxWaitTime to wait for permits to be available.
 	WithMaxWaitTime(maxWaitTime time.Duration) BulkheadBuilder[R]
 
 	// OnFull registers the listener to be called when the bulkhead is full.
 	OnFull(listener func(event failsafe.ExecutionEvent[R])) BulkheadBuilder[R]
 
+	// OnDrainProgress registers the listener to be called each time an inflight execution completes and releases its
+	// permit while draining.
+	OnDrainProgress(listener func(event DrainEvent)) BulkheadBuilder[R]
+
+	// WithClock configures the clock that is used to time waiting for permits, such as a failsafe.FakeClock when testing.
+	// By default, failsafe.SystemClock is used.
+	WithClock(clock failsafe.TimerClock) BulkheadBuilder[R]
+
 	// Build returns a new Bulkhead using the builder's configuration.
 	Build() Bulkhead[R]
 }
 
 type config[R any] struct {
+	name           string
 	maxConcurrency uint
 	maxWaitTime    time.Duration
 	onFull         func(failsafe.ExecutionEvent[R])
+	onDrain        func(DrainEvent)
+	clock          failsafe.TimerClock
 }
 
 func (c *config[R]) WithMaxWaitTime(maxWaitTime time.Duration) BulkheadBuilder[R] {
@@ -72,10 +135,27 @@ func (c *config[R]) OnFull(listener func(event failsafe.ExecutionEvent[R])) Bulk
 	return c
 }
 
+func (c *config[R]) OnDrainProgress(listener func(event DrainEvent)) BulkheadBuilder[R] {
+	c.onDrain = listener
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.TimerClock) BulkheadBuilder[R] {
+	c.clock = clock
+	return c
+}
+
+func (c *config[R]) WithName(name string) BulkheadBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() Bulkhead[R] {
 	return &bulkhead[R]{
 		config:    c, // TODO copy base fields
 		semaphore: make(chan struct{}, c.maxConcurrency),
+		draining:  make(chan struct{}),
+		drained:   make(chan struct{}),
 	}
 }
 
@@ -90,23 +170,36 @@ func With[R any](maxConcurrency uint) Bulkhead[R] {
 func Builder[R any](maxConcurrency uint) BulkheadBuilder[R] {
 	return &config[R]{
 		maxConcurrency: maxConcurrency,
+		clock:          failsafe.SystemClock(),
 	}
 }
 
 type bulkhead[R any] struct {
 	*config[R]
 	semaphore chan struct{}
+
+	// Closed when draining starts
+	draining  chan struct{}
+	drainOnce sync.Once
+	// Closed when draining completes
+	drained     chan struct{}
+	drainedOnce sync.Once
 }
 
 func (b *bulkhead[R]) AcquirePermit(ctx context.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if b.isDraining() {
+		return ErrDraining
+	}
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
+	case <-b.draining:
+		return ErrDraining
 	case b.semaphore <- struct{}{}:
-		return nil
+		return b.checkDraining()
 	}
 }
 
@@ -116,34 +209,54 @@ func (b *bulkhead[R]) AcquirePermitWithMaxWait(ctx context.Context, maxWaitTime
 	}
 
 	// Initial attempt, in case permit is immediately available or context is done, so we don't race with a timer
+	if b.isDraining() {
+		return ErrDraining
+	}
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	case b.semaphore <- struct{}{}:
-		return nil
+		return b.checkDraining()
 	default:
 		if maxWaitTime == 0 {
-			return ErrFull
+			return b.fullError(maxWaitTime)
 		}
 	}
 
 	// Second attempt with timer
-	timer := time.NewTimer(maxWaitTime)
+	timedOut := make(chan struct{})
+	timer := b.clock.AfterFunc(maxWaitTime, func() {
+		close(timedOut)
+	})
 	defer timer.Stop()
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
+	case <-b.draining:
+		return ErrDraining
 	case b.semaphore <- struct{}{}:
-		return nil
-	case <-timer.C:
-		return ErrFull
+		return b.checkDraining()
+	case <-timedOut:
+		return b.fullError(maxWaitTime)
+	}
+}
+
+func (b *bulkhead[R]) fullError(maxWaitTime time.Duration) FullError {
+	return FullError{
+		Bulkhead:       b,
+		Name:           b.name,
+		MaxConcurrency: b.maxConcurrency,
+		MaxWaitTime:    maxWaitTime,
 	}
 }
 
 func (b *bulkhead[R]) TryAcquirePermit() bool {
+	if b.isDraining() {
+		return false
+	}
 	select {
 	case b.semaphore <- struct{}{}:
-		return true
+		return b.checkDraining() == nil
 	default:
 		return false
 	}
@@ -151,6 +264,80 @@ func (b *bulkhead[R]) TryAcquirePermit() bool {
 
 func (b *bulkhead[R]) ReleasePermit() {
 	<-b.semaphore
+	if b.isDraining() {
+		inflight := len(b.semaphore)
+		if b.onDrain != nil {
+			b.onDrain(DrainEvent{Inflight: inflight})
+		}
+		b.checkDrained(inflight)
+	}
+}
+
+func (b *bulkhead[R]) Drain(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	b.drainOnce.Do(func() {
+		close(b.draining)
+		b.checkDrained(len(b.semaphore))
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.drained:
+		return nil
+	}
+}
+
+func (b *bulkhead[R]) isDraining() bool {
+	select {
+	case <-b.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkDraining releases a permit that was acquired concurrently with draining starting, returning ErrDraining if so.
+// Drain progress is not reported for the permit, since no execution was admitted with it.
+func (b *bulkhead[R]) checkDraining() error {
+	if b.isDraining() {
+		<-b.semaphore
+		b.checkDrained(len(b.semaphore))
+		return ErrDraining
+	}
+	return nil
+}
+
+// checkDrained marks the bulkhead as drained once no executions are inflight.
+func (b *bulkhead[R]) checkDrained(inflight int) {
+	if inflight == 0 {
+		b.drainedOnce.Do(func() {
+			close(b.drained)
+		})
+	}
+}
+
+func (b *bulkhead[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.BulkheadKind
+}
+
+func (b *bulkhead[R]) PolicyName() string {
+	return b.name
+}
+
+func (b *bulkhead[R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"maxConcurrency": b.maxConcurrency,
+		"maxWaitTime":    b.maxWaitTime,
+	}
+}
+
+func (b *bulkhead[R]) PolicyMetrics() []failsafe.Metric {
+	return []failsafe.Metric{
+		{Name: "maxConcurrency", Kind: failsafe.GaugeMetric, Value: float64(b.maxConcurrency)},
+		{Name: "permitsInUse", Kind: failsafe.GaugeMetric, Value: float64(len(b.semaphore))},
+	}
 }
 
 func (b *bulkhead[R]) ToExecutor(_ R) any {
@@ -1,11 +1,13 @@
 package bulkhead
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
 )
 
@@ -31,7 +33,10 @@ func TestAcquirePermitWithMaxWaitTime(t *testing.T) {
 
 	assert.Nil(t, bulkhead.AcquirePermitWithMaxWait(nil, 100*time.Millisecond)) // waits 0
 	err := bulkhead.AcquirePermitWithMaxWait(nil, 100*time.Millisecond)         // waits 100
-	assert.ErrorIs(t, ErrFull, err)
+	assert.ErrorIs(t, err, ErrFull)
+	var fullErr FullError
+	assert.ErrorAs(t, err, &fullErr)
+	assert.Equal(t, FullError{Bulkhead: bulkhead, MaxConcurrency: 1, MaxWaitTime: 100 * time.Millisecond}, fullErr)
 }
 
 func TestTryAcquirePermitAndReleasePermit(t *testing.T) {
@@ -51,3 +56,54 @@ func TestTryAcquirePermitAndReleasePermit(t *testing.T) {
 	assert.True(t, bulkhead.TryAcquirePermit())
 	assert.False(t, bulkhead.TryAcquirePermit())
 }
+
+func TestDrain(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	events := make(chan DrainEvent, 1)
+	bulkhead := Builder[any](1).
+		WithClock(clock).
+		OnDrainProgress(func(e DrainEvent) {
+			events <- e
+		}).
+		Build()
+	assert.True(t, bulkhead.TryAcquirePermit())
+
+	// Waiting executions should fail when draining starts
+	waitErr := make(chan error)
+	go func() {
+		waitErr <- bulkhead.AcquirePermitWithMaxWait(nil, time.Hour)
+	}()
+	assert.Eventually(t, func() bool {
+		return clock.PendingTimers() == 1
+	}, time.Second, time.Millisecond)
+	drainErr := make(chan error)
+	go func() {
+		drainErr <- bulkhead.Drain(nil)
+	}()
+	assert.ErrorIs(t, <-waitErr, ErrDraining)
+
+	// Draining should complete once inflight executions release their permits
+	select {
+	case <-drainErr:
+		assert.Fail(t, "drained with an inflight execution")
+	default:
+	}
+	bulkhead.ReleasePermit()
+	assert.Nil(t, <-drainErr)
+	assert.Equal(t, DrainEvent{Inflight: 0}, <-events)
+	assert.Empty(t, events)
+
+	// New executions should not be admitted
+	assert.False(t, bulkhead.TryAcquirePermit())
+	assert.ErrorIs(t, bulkhead.AcquirePermitWithMaxWait(nil, 0), ErrDraining)
+}
+
+func TestDrainWithContextDone(t *testing.T) {
+	bulkhead := With[any](1)
+	assert.True(t, bulkhead.TryAcquirePermit())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bulkhead.Drain(ctx), context.DeadlineExceeded)
+}
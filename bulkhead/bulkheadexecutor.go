@@ -18,7 +18,21 @@ type executor[R any] struct {
 var _ policy.Executor[any] = &executor[any]{}
 
 func (e *executor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
-	if err := e.AcquirePermitWithMaxWait(exec.Context(), e.maxWaitTime); err != nil {
+	if err := exec.Context().Err(); err != nil {
+		return internal.FailureResult[R](err)
+	}
+
+	// Avoid reading the time when a permit is immediately available
+	if e.TryAcquirePermit() {
+		return nil
+	}
+	waitStart := exec.ElapsedTime()
+	err := e.AcquirePermitWithMaxWait(exec.Context(), e.maxWaitTime)
+	exec.RecordWaitTime(exec.ElapsedTime() - waitStart)
+	if err != nil {
+		if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+			logger.Debug("bulkhead rejected execution", "policy", failsafe.BulkheadKind, "attempts", exec.Attempts(), "error", err)
+		}
 		if e.onFull != nil && errors.Is(err, ErrFull) {
 			e.onFull(failsafe.ExecutionEvent[R]{
 				ExecutionAttempt: exec,
@@ -29,6 +43,20 @@ func (e *executor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.Polic
 	return nil
 }
 
+func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+		if result := e.PreExecute(execInternal); result != nil {
+			return result
+		}
+
+		releasePermit := execInternal.TrackResource(failsafe.PermitResource)
+		result := innerFn(exec)
+		releasePermit()
+		return e.PostExecute(execInternal, result)
+	}
+}
+
 func (e *executor[R]) PostExecute(_ policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
 	e.bulkhead.ReleasePermit()
 	return result
@@ -0,0 +1,56 @@
+package bulkhead
+
+import (
+	"context"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/keyed"
+)
+
+// KeyedBulkhead is a Policy that maintains a separate Bulkhead for each key, such as a tenant, host, or API key, and
+// limits the concurrency of each execution via the Bulkhead for the execution's key. Bulkheads are created as needed,
+// and the least recently used Bulkheads that have no executions in progress are evicted when there are more than the
+// configured max keys. An evicted key gets a new Bulkhead when it's used again.
+//
+// R is the execution result type. This type is concurrency safe.
+type KeyedBulkhead[R any] interface {
+	failsafe.Policy[R]
+
+	// Get returns the Bulkhead for the key, creating it if needed.
+	Get(key string) Bulkhead[R]
+
+	// Keys returns the number of keys that currently have a Bulkhead.
+	Keys() int
+}
+
+// Keyed returns a new KeyedBulkhead that limits the concurrency of executions via a separate Bulkhead for each key
+// returned by the keyFunc, which is given the execution's context. Bulkheads are created via the builder, and up to
+// maxKeys Bulkheads are kept, evicting the least recently used that are idle. If maxKeys is 0, the number of Bulkheads
+// is unbounded.
+func Keyed[R any](keyFunc func(ctx context.Context) string, builder BulkheadBuilder[R], maxKeys uint) KeyedBulkhead[R] {
+	return &keyedBulkhead[R]{
+		keyFunc:   keyFunc,
+		bulkheads: keyed.New[Bulkhead[R]](int(maxKeys), builder.Build),
+	}
+}
+
+type keyedBulkhead[R any] struct {
+	keyFunc   func(ctx context.Context) string
+	bulkheads *keyed.Policies[Bulkhead[R]]
+}
+
+func (k *keyedBulkhead[R]) Get(key string) Bulkhead[R] {
+	return k.bulkheads.Get(key)
+}
+
+func (k *keyedBulkhead[R]) Keys() int {
+	return k.bulkheads.Len()
+}
+
+func (k *keyedBulkhead[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.BulkheadKind
+}
+
+func (k *keyedBulkhead[R]) ToExecutor(_ R) any {
+	return keyed.NewExecutor[R](k.keyFunc, k.bulkheads)
+}
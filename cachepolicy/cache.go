@@ -1,7 +1,11 @@
 package cachepolicy
 
 import (
+	"sync"
+	"time"
+
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
@@ -35,6 +39,8 @@ type CachePolicy[R any] interface {
 //
 // R is the execution result type. This type is not concurrency safe.
 type CachePolicyBuilder[R any] interface {
+	failsafe.NamedPolicyBuilder[CachePolicyBuilder[R]]
+
 	// WithKey builds caches that store successful execution results in a cache with the key. This key can be overridden by
 	// providing a CacheKey in a Context used with an execution.
 	WithKey(key string) CachePolicyBuilder[R]
@@ -43,6 +49,25 @@ type CachePolicyBuilder[R any] interface {
 	// results will be cached.
 	CacheIf(predicate func(R, error) bool) CachePolicyBuilder[R]
 
+	// WithSingleFlight configures concurrent executions that miss the cache for the same key to wait for a single
+	// execution to load the value, and to share its result, rather than each execution loading the value. Waiting
+	// executions return early if they're canceled.
+	WithSingleFlight() CachePolicyBuilder[R]
+
+	// WithEarlyExpiration configures cached values to expire after the ttl, and to be refreshed early, before they expire,
+	// with a probability that increases as expiration nears, according to the XFetch algorithm. Values that took longer to
+	// load are refreshed earlier, and a larger beta favors earlier refreshes, where 1 is a typical beta and 0 disables
+	// early refreshes. While a value is refreshed, which is done by one execution at a time, other executions use the
+	// cached value, even if it has expired. As such, the Cache should hold values longer than the ttl.
+	//
+	// The expiration of up to 10,000 recently used keys is tracked in memory. Values for other keys are not
+	// refreshed until they're evicted from the Cache.
+	WithEarlyExpiration(ttl time.Duration, beta float64) CachePolicyBuilder[R]
+
+	// WithClock configures the clock that is used to expire cached values when early expiration is configured, such as a
+	// failsafe.FakeClock when testing. By default, failsafe.SystemClock is used.
+	WithClock(clock failsafe.Clock) CachePolicyBuilder[R]
+
 	// OnCacheHit registers the listener to be called when the cachePolicy entry is hit during an execution.
 	OnCacheHit(listener func(event failsafe.ExecutionDoneEvent[R])) CachePolicyBuilder[R]
 
@@ -57,9 +82,14 @@ type CachePolicyBuilder[R any] interface {
 }
 
 type config[R any] struct {
+	name            string
 	cache           Cache[R]
 	key             string
 	cacheConditions []func(result R, err error) bool
+	singleFlight    bool
+	ttl             time.Duration
+	beta            float64
+	clock           failsafe.Clock
 	onHit           func(event failsafe.ExecutionDoneEvent[R])
 	onMiss          func(failsafe.ExecutionEvent[R])
 	onCache         func(failsafe.ExecutionEvent[R])
@@ -67,8 +97,30 @@ type config[R any] struct {
 
 var _ CachePolicyBuilder[any] = &config[any]{}
 
+// maxExpirations is the max number of keys whose expiration is tracked when early expiration is configured.
+const maxExpirations = 10_000
+
 type cachePolicy[R any] struct {
 	*config[R]
+	// Tracks when cached values expire, else nil if early expiration is not configured
+	expirations Cache[expiration]
+
+	mtx sync.Mutex
+	// Guarded by mtx. Loads that are in progress, by cache key.
+	flights map[string]*flight[R]
+}
+
+// expiration describes when a cached value expires, and how long it took to load.
+type expiration struct {
+	expiresAt time.Time
+	loadTime  time.Duration
+}
+
+// flight is a load of a cached value that is in progress.
+type flight[R any] struct {
+	done chan struct{}
+	// Set before done is closed. Nil if the load did not complete, such as when it panicked or was canceled.
+	result *common.PolicyResult[R]
 }
 
 // With returns a new CachePolicy. The resulting CachePolicy will only be used with executions that provide a Context
@@ -81,6 +133,7 @@ func With[R any](cache Cache[R]) CachePolicy[R] {
 func Builder[R any](cache Cache[R]) CachePolicyBuilder[R] {
 	return &config[R]{
 		cache: cache,
+		clock: failsafe.SystemClock(),
 	}
 }
 
@@ -89,6 +142,22 @@ func (c *config[R]) CacheIf(predicate func(R, error) bool) CachePolicyBuilder[R]
 	return c
 }
 
+func (c *config[R]) WithSingleFlight() CachePolicyBuilder[R] {
+	c.singleFlight = true
+	return c
+}
+
+func (c *config[R]) WithEarlyExpiration(ttl time.Duration, beta float64) CachePolicyBuilder[R] {
+	c.ttl = ttl
+	c.beta = beta
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.Clock) CachePolicyBuilder[R] {
+	c.clock = clock
+	return c
+}
+
 func (c *config[R]) WithKey(key string) CachePolicyBuilder[R] {
 	c.key = key
 	return c
@@ -109,9 +178,36 @@ func (c *config[R]) OnResultCached(listener func(event failsafe.ExecutionEvent[R
 	return c
 }
 
+func (c *config[R]) WithName(name string) CachePolicyBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() CachePolicy[R] {
-	return &cachePolicy[R]{
-		config: c, // TODO copy base fields
+	cp := &cachePolicy[R]{
+		config:  c, // TODO copy base fields
+		flights: make(map[string]*flight[R]),
+	}
+	if c.ttl > 0 {
+		cp.expirations = NewMemoryCache[expiration](maxExpirations, 0)
+	}
+	return cp
+}
+
+func (c *cachePolicy[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.CacheKind
+}
+
+func (c *cachePolicy[R]) PolicyName() string {
+	return c.name
+}
+
+func (c *cachePolicy[R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"key":          c.key,
+		"singleFlight": c.singleFlight,
+		"ttl":          c.ttl,
+		"beta":         c.beta,
 	}
 }
 
@@ -2,6 +2,9 @@ package cachepolicy
 
 import (
 	"context"
+	"math"
+	"math/rand"
+	"time"
 
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
@@ -17,29 +20,66 @@ type executor[R any] struct {
 
 var _ policy.Executor[any] = &executor[any]{}
 
-func (e *executor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
-	execInternal := exec.(policy.ExecutionInternal[R])
-	if cacheKey := e.getCacheKey(exec.Context()); cacheKey != "" {
+func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	if !e.singleFlight && e.expirations == nil {
+		return e.BaseExecutor.Apply(innerFn)
+	}
+
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+		cacheKey := e.getCacheKey(exec.Context())
+		if cacheKey == "" {
+			e.onCacheMiss(execInternal)
+			return e.PostExecute(execInternal, innerFn(exec))
+		}
+
 		if cacheResult, found := e.cache.Get(cacheKey); found {
-			if e.onHit != nil {
-				e.onHit(failsafe.ExecutionDoneEvent[R]{
-					ExecutionInfo: execInternal,
-					Result:        cacheResult,
-				})
+			if !e.shouldRefresh(cacheKey) {
+				return e.onCacheHit(execInternal, cacheResult)
 			}
-			return &common.PolicyResult[R]{
-				Result:     cacheResult,
-				Done:       true,
-				Success:    true,
-				SuccessAll: true,
+
+			// Refresh the value, unless another execution is already refreshing it, in which case use the cached value
+			f, loading := e.startFlight(cacheKey)
+			if !loading {
+				return e.onCacheHit(execInternal, cacheResult)
+			}
+			return e.load(execInternal, innerFn, cacheKey, f)
+		}
+
+		e.onCacheMiss(execInternal)
+		if !e.singleFlight {
+			return e.load(execInternal, innerFn, cacheKey, nil)
+		}
+
+		// Load the value, or wait for the execution that is loading it, taking over the load if that execution was canceled
+		// or panicked
+		for {
+			f, loading := e.startFlight(cacheKey)
+			if loading {
+				return e.load(execInternal, innerFn, cacheKey, f)
+			}
+			select {
+			case <-f.done:
+				if f.result != nil {
+					// Copy the result, since it's shared by waiting executions
+					resultCopy := *f.result
+					return &resultCopy
+				}
+			case <-exec.Canceled():
+				_, cancelResult := execInternal.IsCanceledWithResult()
+				return cancelResult
 			}
 		}
 	}
-	if e.onMiss != nil {
-		e.onMiss(failsafe.ExecutionEvent[R]{
-			ExecutionAttempt: execInternal,
-		})
+}
+
+func (e *executor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
+	if cacheKey := e.getCacheKey(exec.Context()); cacheKey != "" {
+		if cacheResult, found := e.cache.Get(cacheKey); found {
+			return e.onCacheHit(exec, cacheResult)
+		}
 	}
+	e.onCacheMiss(exec)
 	return nil
 }
 
@@ -50,6 +90,12 @@ func (e *executor[R]) PostExecute(exec policy.ExecutionInternal[R], er *common.P
 	if shouldCache {
 		if cacheKey := e.getCacheKey(exec.Context()); cacheKey != "" {
 			e.cache.Set(cacheKey, er.Result)
+			if e.expirations != nil {
+				e.expirations.Set(cacheKey, expiration{
+					expiresAt: e.clock.Now().Add(e.ttl),
+					loadTime:  exec.ElapsedTime(),
+				})
+			}
 			if e.onCache != nil {
 				e.onCache(failsafe.ExecutionEvent[R]{
 					ExecutionAttempt: exec.CopyWithResult(er),
@@ -68,3 +114,76 @@ func (e *executor[R]) getCacheKey(ctx context.Context) string {
 	}
 	return e.key
 }
+
+// onCacheHit calls the onHit listener and returns a result for the cacheResult.
+func (e *executor[R]) onCacheHit(exec policy.ExecutionInternal[R], cacheResult R) *common.PolicyResult[R] {
+	if e.onHit != nil {
+		e.onHit(failsafe.ExecutionDoneEvent[R]{
+			ExecutionInfo: exec,
+			Result:        cacheResult,
+		})
+	}
+	return &common.PolicyResult[R]{
+		Result:     cacheResult,
+		Done:       true,
+		Success:    true,
+		SuccessAll: true,
+	}
+}
+
+func (e *executor[R]) onCacheMiss(exec policy.ExecutionInternal[R]) {
+	if e.onMiss != nil {
+		e.onMiss(failsafe.ExecutionEvent[R]{
+			ExecutionAttempt: exec,
+		})
+	}
+}
+
+// load performs an execution via the innerFn to load a value for the cacheKey, and completes the flight with the result,
+// if a flight is provided.
+func (e *executor[R]) load(exec policy.ExecutionInternal[R], innerFn func(failsafe.Execution[R]) *common.PolicyResult[R], cacheKey string, f *flight[R]) (result *common.PolicyResult[R]) {
+	if f != nil {
+		// Complete the flight even if the innerFn panics, so that waiting executions don't block
+		defer e.completeFlight(exec, cacheKey, f, &result)
+	}
+	return e.PostExecute(exec, innerFn(exec))
+}
+
+// startFlight returns the flight for the cacheKey, along with whether the caller started it, in which case the caller
+// must load the value.
+func (e *executor[R]) startFlight(cacheKey string) (*flight[R], bool) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if f, ok := e.flights[cacheKey]; ok {
+		return f, false
+	}
+	f := &flight[R]{done: make(chan struct{})}
+	e.flights[cacheKey] = f
+	return f, true
+}
+
+func (e *executor[R]) completeFlight(exec policy.ExecutionInternal[R], cacheKey string, f *flight[R], result **common.PolicyResult[R]) {
+	e.mtx.Lock()
+	delete(e.flights, cacheKey)
+	e.mtx.Unlock()
+	if !exec.IsCanceled() {
+		f.result = *result
+	}
+	close(f.done)
+}
+
+// shouldRefresh returns whether the cached value for the cacheKey should be refreshed, because it has expired, or
+// according to the XFetch algorithm, it should be refreshed early.
+func (e *executor[R]) shouldRefresh(cacheKey string) bool {
+	if e.expirations == nil {
+		return false
+	}
+	exp, found := e.expirations.Get(cacheKey)
+	if !found {
+		return false
+	}
+
+	// Use 1-rand, which is in (0, 1], to avoid log(0)
+	earlyBy := time.Duration(-float64(exp.loadTime) * e.beta * math.Log(1-rand.Float64()))
+	return !e.clock.Now().Add(earlyBy).Before(exp.expiresAt)
+}
@@ -0,0 +1,184 @@
+package cachepolicy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+)
+
+// Codec marshals and unmarshals cached values, so that they can be stored in caches that hold bytes, such as external
+// caches.
+//
+// R is the execution result type.
+type Codec[R any] interface {
+	// Marshal returns the encoded bytes for the value.
+	Marshal(value R) ([]byte, error)
+
+	// Unmarshal returns the value decoded from the data.
+	Unmarshal(data []byte) (R, error)
+}
+
+// JSONCodec returns a Codec that marshals values using encoding/json.
+func JSONCodec[R any]() Codec[R] {
+	return jsonCodec[R]{}
+}
+
+type jsonCodec[R any] struct{}
+
+func (jsonCodec[R]) Marshal(value R) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec[R]) Unmarshal(data []byte) (R, error) {
+	var value R
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// GzipCodec returns a Codec that gzip compresses the data marshalled by the codec.
+func GzipCodec[R any](codec Codec[R]) Codec[R] {
+	return gzipCodec[R]{codec: codec}
+}
+
+type gzipCodec[R any] struct {
+	codec Codec[R]
+}
+
+func (c gzipCodec[R]) Marshal(value R) ([]byte, error) {
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c gzipCodec[R]) Unmarshal(data []byte) (R, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return *new(R), err
+	}
+	defer reader.Close()
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return *new(R), err
+	}
+	return c.codec.Unmarshal(decompressed)
+}
+
+// SerializedEvent indicates a value was marshalled for a SerializingCache.
+type SerializedEvent struct {
+	// Key is the cache key for the value.
+	Key string
+	// Size is the size of the marshalled value in bytes, else 0 if marshalling failed.
+	Size int
+	// Skipped indicates whether the value was not cached, because its size exceeded the max size or marshalling failed.
+	Skipped bool
+	// Error is the error returned by the Codec, if any.
+	Error error
+}
+
+// SerializingCacheBuilder builds Caches that marshal values using a Codec before storing them in a byte Cache, such as
+// an external cache.
+//
+// R is the execution result type. This type is not concurrency safe.
+type SerializingCacheBuilder[R any] interface {
+	// WithMaxSize configures the max size, in bytes, of marshalled values that will be cached. Values that are larger
+	// than the maxSize are not cached. By default, values of any size are cached.
+	WithMaxSize(maxSize int) SerializingCacheBuilder[R]
+
+	// OnSerialized registers the listener to be called when a value is marshalled for caching, which can be used to
+	// record serialized sizes.
+	OnSerialized(listener func(event SerializedEvent)) SerializingCacheBuilder[R]
+
+	// OnUnmarshalError registers the listener to be called when a cached value cannot be unmarshalled. These values are
+	// treated as cache misses.
+	OnUnmarshalError(listener func(key string, err error)) SerializingCacheBuilder[R]
+
+	// Build returns a new Cache using the builder's configuration.
+	Build() Cache[R]
+}
+
+type serializingConfig[R any] struct {
+	cache            Cache[[]byte]
+	codec            Codec[R]
+	maxSize          int
+	onSerialized     func(SerializedEvent)
+	onUnmarshalError func(string, error)
+}
+
+var _ SerializingCacheBuilder[any] = &serializingConfig[any]{}
+
+// SerializingCache returns a SerializingCacheBuilder for execution result type R, which builds Caches that store values
+// in the cache after marshalling them with the codec.
+func SerializingCache[R any](cache Cache[[]byte], codec Codec[R]) SerializingCacheBuilder[R] {
+	return &serializingConfig[R]{
+		cache: cache,
+		codec: codec,
+	}
+}
+
+func (c *serializingConfig[R]) WithMaxSize(maxSize int) SerializingCacheBuilder[R] {
+	c.maxSize = maxSize
+	return c
+}
+
+func (c *serializingConfig[R]) OnSerialized(listener func(event SerializedEvent)) SerializingCacheBuilder[R] {
+	c.onSerialized = listener
+	return c
+}
+
+func (c *serializingConfig[R]) OnUnmarshalError(listener func(key string, err error)) SerializingCacheBuilder[R] {
+	c.onUnmarshalError = listener
+	return c
+}
+
+func (c *serializingConfig[R]) Build() Cache[R] {
+	scCopy := *c
+	return &serializingCache[R]{
+		serializingConfig: &scCopy,
+	}
+}
+
+type serializingCache[R any] struct {
+	*serializingConfig[R]
+}
+
+func (c *serializingCache[R]) Get(key string) (R, bool) {
+	data, found := c.cache.Get(key)
+	if !found {
+		return *new(R), false
+	}
+	value, err := c.codec.Unmarshal(data)
+	if err != nil {
+		if c.onUnmarshalError != nil {
+			c.onUnmarshalError(key, err)
+		}
+		return *new(R), false
+	}
+	return value, true
+}
+
+func (c *serializingCache[R]) Set(key string, value R) {
+	data, err := c.codec.Marshal(value)
+	skipped := err != nil || (c.maxSize > 0 && len(data) > c.maxSize)
+	if !skipped {
+		c.cache.Set(key, data)
+	}
+	if c.onSerialized != nil {
+		c.onSerialized(SerializedEvent{
+			Key:     key,
+			Size:    len(data),
+			Skipped: skipped,
+			Error:   err,
+		})
+	}
+}
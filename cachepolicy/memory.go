@@ -0,0 +1,82 @@
+package cachepolicy
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// NewMemoryCache returns a Cache that holds up to maxEntries values in memory, evicting the least recently used values
+// when full, and expiring values once they've been cached for the ttl. If maxEntries is 0, the number of values is
+// unbounded. If the ttl is 0, values do not expire.
+//
+// R is the execution result type. This type is concurrency safe.
+func NewMemoryCache[R any](maxEntries int, ttl time.Duration) Cache[R] {
+	return &memoryCache[R]{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+type memoryCache[R any] struct {
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	entries map[string]*list.Element
+	// Guarded by mtx. Ordered from most to least recently used.
+	lru *list.List
+}
+
+type memoryEntry[R any] struct {
+	key       string
+	value     R
+	expiresAt time.Time // The zero time if the entry does not expire
+}
+
+func (c *memoryCache[R]) Get(key string) (R, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return *new(R), false
+	}
+	entry := elem.Value.(*memoryEntry[R])
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return *new(R), false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *memoryCache[R]) Set(key string, value R) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = c.now().Add(c.ttl)
+	}
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry[R])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&memoryEntry[R]{key: key, value: value, expiresAt: expiresAt})
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Requires external locking.
+func (c *memoryCache[R]) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*memoryEntry[R]).key)
+}
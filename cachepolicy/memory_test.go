@@ -0,0 +1,53 @@
+package cachepolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Asserts that the least recently used values are evicted when the cache is full.
+func TestMemoryCacheEviction(t *testing.T) {
+	// Given
+	cache := NewMemoryCache[string](2, 0)
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+
+	// When
+	cache.Get("a")
+	cache.Set("c", "3")
+
+	// Then
+	_, found := cache.Get("b")
+	assert.False(t, found)
+	value, found := cache.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, "1", value)
+	value, found = cache.Get("c")
+	assert.True(t, found)
+	assert.Equal(t, "3", value)
+}
+
+// Asserts that values expire after the ttl, and that setting a value resets its expiration.
+func TestMemoryCacheExpiration(t *testing.T) {
+	// Given
+	cache := NewMemoryCache[string](0, time.Minute).(*memoryCache[string])
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+
+	// When
+	now = now.Add(30 * time.Second)
+	cache.Set("b", "3")
+	now = now.Add(30 * time.Second)
+
+	// Then
+	_, found := cache.Get("a")
+	assert.False(t, found)
+	assert.Len(t, cache.entries, 1)
+	value, found := cache.Get("b")
+	assert.True(t, found)
+	assert.Equal(t, "3", value)
+}
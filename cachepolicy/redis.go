@@ -0,0 +1,123 @@
+package cachepolicy
+
+import (
+	"context"
+	"time"
+)
+
+// RedisClient is the subset of a Redis client that is used by a RedisCache. Clients such as go-redis can be adapted to
+// this interface with a few lines of code, which avoids a dependency on any particular client.
+//
+// Implementations must be concurrency safe.
+type RedisClient interface {
+	// Get returns the value for the key along with a flag indicating if it's present. A missing key should be reported as
+	// not found rather than as an error.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value for the key, which expires after the ttl. A ttl of 0 indicates the value does not expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// RedisCacheBuilder builds Caches that store values in Redis via a RedisClient. Since Redis stores bytes, values of
+// other types can be cached by wrapping the resulting Cache in a SerializingCache with a Codec:
+//
+//	redisCache := cachepolicy.RedisCache(client).WithTTL(time.Minute).Build()
+//	cache := cachepolicy.SerializingCache(redisCache, cachepolicy.JSONCodec[*Response]()).Build()
+//
+// This type is not concurrency safe.
+type RedisCacheBuilder interface {
+	// WithTTL configures the ttl after which cached values expire. By default, values do not expire.
+	WithTTL(ttl time.Duration) RedisCacheBuilder
+
+	// WithKeyPrefix configures a prefix that is added to cache keys before they're stored in Redis, which can be used to
+	// namespace keys. By default, no prefix is added.
+	WithKeyPrefix(prefix string) RedisCacheBuilder
+
+	// WithTimeout configures a timeout for each Redis call. By default, calls do not time out other than via the
+	// RedisClient.
+	WithTimeout(timeout time.Duration) RedisCacheBuilder
+
+	// OnError registers the listener to be called when the RedisClient returns an error. Get errors are treated as cache
+	// misses, and Set errors result in values not being cached.
+	OnError(listener func(key string, err error)) RedisCacheBuilder
+
+	// Build returns a new Cache using the builder's configuration.
+	Build() Cache[[]byte]
+}
+
+type redisConfig struct {
+	client    RedisClient
+	ttl       time.Duration
+	keyPrefix string
+	timeout   time.Duration
+	onError   func(string, error)
+}
+
+var _ RedisCacheBuilder = &redisConfig{}
+
+// RedisCache returns a RedisCacheBuilder, which builds Caches that store values in Redis via the client.
+func RedisCache(client RedisClient) RedisCacheBuilder {
+	return &redisConfig{
+		client: client,
+	}
+}
+
+func (c *redisConfig) WithTTL(ttl time.Duration) RedisCacheBuilder {
+	c.ttl = ttl
+	return c
+}
+
+func (c *redisConfig) WithKeyPrefix(prefix string) RedisCacheBuilder {
+	c.keyPrefix = prefix
+	return c
+}
+
+func (c *redisConfig) WithTimeout(timeout time.Duration) RedisCacheBuilder {
+	c.timeout = timeout
+	return c
+}
+
+func (c *redisConfig) OnError(listener func(key string, err error)) RedisCacheBuilder {
+	c.onError = listener
+	return c
+}
+
+func (c *redisConfig) Build() Cache[[]byte] {
+	rcCopy := *c
+	return &redisCache{
+		redisConfig: &rcCopy,
+	}
+}
+
+type redisCache struct {
+	*redisConfig
+}
+
+func (c *redisCache) Get(key string) ([]byte, bool) {
+	ctx, cancel := c.context()
+	defer cancel()
+	value, found, err := c.client.Get(ctx, c.keyPrefix+key)
+	if err != nil {
+		if c.onError != nil {
+			c.onError(key, err)
+		}
+		return nil, false
+	}
+	return value, found
+}
+
+func (c *redisCache) Set(key string, value []byte) {
+	ctx, cancel := c.context()
+	defer cancel()
+	if err := c.client.Set(ctx, c.keyPrefix+key, value, c.ttl); err != nil && c.onError != nil {
+		c.onError(key, err)
+	}
+}
+
+// context returns a context for a Redis call, which has a timeout if one is configured.
+func (c *redisCache) context() (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(context.Background(), c.timeout)
+	}
+	return context.Background(), func() {}
+}
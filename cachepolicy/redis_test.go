@@ -0,0 +1,77 @@
+package cachepolicy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedisClient stores values in a map, recording the ttl of each value.
+type fakeRedisClient struct {
+	values map[string][]byte
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func (c *fakeRedisClient) Get(_ context.Context, key string) ([]byte, bool, error) {
+	if c.err != nil {
+		return nil, false, c.err
+	}
+	value, found := c.values[key]
+	return value, found, nil
+}
+
+func (c *fakeRedisClient) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.values[key] = value
+	c.ttls[key] = ttl
+	return nil
+}
+
+// Asserts that values are stored with the key prefix and ttl, and can be serialized via a SerializingCache.
+func TestRedisCache(t *testing.T) {
+	// Given
+	client := &fakeRedisClient{values: map[string][]byte{}, ttls: map[string]time.Duration{}}
+	redisCache := RedisCache(client).WithKeyPrefix("test:").WithTTL(time.Minute).Build()
+	cache := SerializingCache(redisCache, JSONCodec[int]()).Build()
+
+	// When
+	cache.Set("foo", 42)
+
+	// Then
+	assert.Equal(t, []byte("42"), client.values["test:foo"])
+	assert.Equal(t, time.Minute, client.ttls["test:foo"])
+	value, found := cache.Get("foo")
+	assert.True(t, found)
+	assert.Equal(t, 42, value)
+	_, found = cache.Get("bar")
+	assert.False(t, found)
+}
+
+// Asserts that client errors are reported and treated as cache misses.
+func TestRedisCacheWithErrors(t *testing.T) {
+	// Given
+	unavailable := errors.New("unavailable")
+	client := &fakeRedisClient{err: unavailable}
+	var errs []error
+	cache := RedisCache(client).
+		WithTimeout(time.Second).
+		OnError(func(key string, err error) {
+			assert.Equal(t, "foo", key)
+			errs = append(errs, err)
+		}).
+		Build()
+
+	// When
+	cache.Set("foo", []byte("bar"))
+	_, found := cache.Get("foo")
+
+	// Then
+	assert.False(t, found)
+	assert.Equal(t, []error{unavailable, unavailable}, errs)
+}
@@ -3,16 +3,47 @@ package circuitbreaker
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
-// ErrOpen is returned when an execution is attempted against a circuit breaker that is open.
+// ErrOpen is a convenience error sentinel that can be used to build policies that handle OpenError, such as via
+// HandleErrors(circuitbreaker.ErrOpen). It can also be used with Errors.Is to determine whether an error is a
+// circuitbreaker.OpenError.
 var ErrOpen = errors.New("circuit breaker open")
 
+// OpenError is returned when an execution is attempted against a circuit breaker that is open, or that is half-open and
+// already has the max number of trial executions in progress. This type can be used with
+// HandleErrorTypes(circuitbreaker.OpenError{}), or with errors.As to determine which of several CircuitBreakers rejected
+// an execution.
+type OpenError struct {
+	// CircuitBreaker is the CircuitBreaker that rejected the execution, which can be compared with a CircuitBreaker
+	// instance.
+	CircuitBreaker any
+	// Name is the name of the CircuitBreaker, else an empty string if it's not named.
+	Name string
+	// State is the CircuitBreaker's state when the execution was rejected.
+	State State
+	// RemainingDelay is the remaining delay until the CircuitBreaker is half-opened, when in the OpenState.
+	RemainingDelay time.Duration
+}
+
+func (e OpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open. %sstate: %v, remaining delay: %v", internal.ErrorName(e.Name), e.State, e.RemainingDelay)
+}
+
+func (e OpenError) Is(err error) bool {
+	if err == ErrOpen {
+		return true
+	}
+	return err == e
+}
+
 // State of a CircuitBreaker.
 type State int
 
@@ -58,6 +89,14 @@ A circuit breaker can be count based or time based:
     of the failureThresholdingPeriod. As time progresses, statistics for old time slices are gradually discarded, which
     smoothes the calculation of success and failure rates.
 
+A circuit breaker can also be used standalone, outside of an Executor, such as by a message consumer, by acquiring a
+permit before each execution and recording the execution's outcome after:
+
+	if cb.TryAcquirePermit() {
+		result, err := handle(msg)
+		cb.Record(result, err)
+	}
+
 R is the execution result type. This type is concurrency safe.
 */
 type CircuitBreaker[R any] interface {
@@ -90,6 +129,9 @@ type CircuitBreaker[R any] interface {
 	// Metrics returns metrics for the CircuitBreaker.
 	Metrics() Metrics
 
+	// StateMetrics returns a snapshot of the CircuitBreaker's cumulative permit and state transition counters.
+	StateMetrics() StateMetrics
+
 	// TryAcquirePermit tries to acquire a permit to use the circuit breaker and returns whether a permit was acquired.
 	// Permission will be automatically released when a result or failure is recorded.
 	TryAcquirePermit() bool
@@ -100,6 +142,11 @@ type CircuitBreaker[R any] interface {
 	// RecordError records an error as a success or failure based on the failure handling configuration.
 	RecordError(err error)
 
+	// Record records an execution's result and error as a success or failure based on the failure handling
+	// configuration. This is useful when the CircuitBreaker is used standalone, after acquiring a permit via
+	// TryAcquirePermit.
+	Record(result R, err error)
+
 	// RecordSuccess records an execution success.
 	RecordSuccess()
 
@@ -139,14 +186,89 @@ type Metrics interface {
 	//
 	// The rate is based on the configured success thresholding capacity.
 	SuccessRate() uint
+
+	// FailuresByClass returns the number of failures for each class, as assigned by a failure classifier, recorded in the
+	// current state when in a ClosedState or HalfOpenState. When in OpenState, this returns the failures recorded during
+	// the previous ClosedState. Returns an empty map if no failure classifier is configured.
+	FailuresByClass() map[string]uint
+}
+
+// StateMetrics is a snapshot of a CircuitBreaker's permits and state transitions. The counts are cumulative over the
+// lifetime of the CircuitBreaker, and are not reset when the CircuitBreaker is reset, which makes them suitable for
+// exporting as counters to monitoring systems such as Prometheus. For example, the rate of OpenState to ClosedState
+// transitions can be used to alert on a circuit breaker that is flapping between states.
+type StateMetrics struct {
+	// ClosedPermits is the number of executions that were permitted while closed.
+	ClosedPermits uint64
+	// OpenRejections is the number of executions that were rejected while open.
+	OpenRejections uint64
+	// HalfOpenPermits is the number of executions that were permitted while half-open, which probe whether the circuit
+	// can be closed.
+	HalfOpenPermits uint64
+	// HalfOpenRejections is the number of executions that were rejected while half-open, since the max number of probe
+	// executions were already in progress.
+	HalfOpenRejections uint64
+	// Transitions is the number of transitions between each pair of states.
+	Transitions map[StateTransition]uint64
+}
+
+// StateTransition is a transition from one State to another.
+type StateTransition struct {
+	From State
+	To   State
+}
+
+func (t StateTransition) String() string {
+	return t.From.String() + "->" + t.To.String()
+}
+
+// StateChangeReason is the reason that a CircuitBreaker's state changed.
+type StateChangeReason int
+
+func (r StateChangeReason) String() string {
+	switch r {
+	case ManualReason:
+		return "manual"
+	case FailureThresholdReason:
+		return "failure threshold"
+	case SuccessThresholdReason:
+		return "success threshold"
+	case DelayElapsedReason:
+		return "delay elapsed"
+	default:
+		return "unknown"
+	}
 }
 
+const (
+	// ManualReason indicates the state was changed via Open, HalfOpen, Close, or Reset.
+	ManualReason StateChangeReason = iota
+
+	// FailureThresholdReason indicates the circuit was opened because a failure threshold, including a failure rate or
+	// class failure threshold, was exceeded.
+	FailureThresholdReason
+
+	// SuccessThresholdReason indicates the circuit was closed because the success threshold was met while half-open.
+	SuccessThresholdReason
+
+	// DelayElapsedReason indicates the circuit was half-opened because the delay elapsed while open.
+	DelayElapsedReason
+)
+
 // StateChangedEvent indicates a CircuitBreaker's state has changed.
 type StateChangedEvent struct {
+	// Name is the name of the CircuitBreaker, else an empty string if it's not named.
+	Name     string
 	OldState State
 	NewState State
-	metrics  *eventMetrics
-	context  context.Context
+	// Reason is the reason the state changed.
+	Reason StateChangeReason
+	// LastResult is the result of the execution that caused the state to change, if any, else nil.
+	LastResult any
+	// LastError is the error of the execution that caused the state to change, if any, else nil.
+	LastError error
+	metrics   *eventMetrics
+	context   context.Context
 }
 
 // Metrics returns metrics from the CircuitBreaker old state.
@@ -165,30 +287,37 @@ type circuitBreaker[R any] struct {
 	mtx sync.Mutex
 	// Guarded by mtx
 	state circuitState[R]
+	// Guarded by mtx. The delay for the most recent OpenState, when delay backoff is configured, else 0.
+	lastDelay time.Duration
+	// Guarded by mtx
+	stateMetrics StateMetrics
+	// Guarded by mtx. The times, in unix nanos, that the circuit was opened from ClosedState within the flappingWindow,
+	// when flapping dampening is configured.
+	recentOpens []int64
 }
 
 func (cb *circuitBreaker[R]) TryAcquirePermit() bool {
 	cb.mtx.Lock()
 	defer cb.mtx.Unlock()
-	return cb.tryAcquirePermit()
+	return cb.acquirePermit()
 }
 
 func (cb *circuitBreaker[R]) Open() {
 	cb.mtx.Lock()
 	defer cb.mtx.Unlock()
-	cb.open(nil)
+	cb.open(nil, ManualReason)
 }
 
 func (cb *circuitBreaker[R]) HalfOpen() {
 	cb.mtx.Lock()
 	defer cb.mtx.Unlock()
-	cb.halfOpen()
+	cb.halfOpen(ManualReason)
 }
 
 func (cb *circuitBreaker[R]) Close() {
 	cb.mtx.Lock()
 	defer cb.mtx.Unlock()
-	cb.close()
+	cb.close(ManualReason)
 }
 
 func (cb *circuitBreaker[R]) State() State {
@@ -207,6 +336,17 @@ func (cb *circuitBreaker[R]) Metrics() Metrics {
 	return cb
 }
 
+func (cb *circuitBreaker[R]) StateMetrics() StateMetrics {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	snapshot := cb.stateMetrics
+	snapshot.Transitions = make(map[StateTransition]uint64, len(cb.stateMetrics.Transitions))
+	for transition, count := range cb.stateMetrics.Transitions {
+		snapshot.Transitions[transition] = count
+	}
+	return snapshot
+}
+
 func (cb *circuitBreaker[R]) IsOpen() bool {
 	return cb.State() == OpenState
 }
@@ -249,10 +389,16 @@ func (cb *circuitBreaker[R]) SuccessRate() uint {
 	return cb.state.successRate()
 }
 
+func (cb *circuitBreaker[R]) FailuresByClass() map[string]uint {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	return cb.state.classStats().failureCounts()
+}
+
 func (cb *circuitBreaker[R]) RecordFailure() {
 	cb.mtx.Lock()
 	defer cb.mtx.Unlock()
-	cb.recordFailure(nil)
+	cb.recordFailure(nil, "")
 }
 
 func (cb *circuitBreaker[R]) RecordError(err error) {
@@ -261,6 +407,12 @@ func (cb *circuitBreaker[R]) RecordError(err error) {
 	cb.recordResult(*new(R), err)
 }
 
+func (cb *circuitBreaker[R]) Record(result R, err error) {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	cb.recordResult(result, err)
+}
+
 func (cb *circuitBreaker[R]) RecordResult(result R) {
 	cb.mtx.Lock()
 	defer cb.mtx.Unlock()
@@ -273,6 +425,58 @@ func (cb *circuitBreaker[R]) RecordSuccess() {
 	cb.recordSuccess()
 }
 
+func (cb *circuitBreaker[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.CircuitBreakerKind
+}
+
+func (cb *circuitBreaker[R]) PolicyName() string {
+	return cb.name
+}
+
+func (cb *circuitBreaker[R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"delay":                       cb.Delay,
+		"maxDelay":                    cb.maxDelay,
+		"delayFactor":                 cb.delayFactor,
+		"failureThreshold":            cb.failureThreshold,
+		"failureRateThreshold":        cb.failureRateThreshold,
+		"failureThresholdingCapacity": cb.failureThresholdingCapacity,
+		"failureExecutionThreshold":   cb.failureExecutionThreshold,
+		"failureThresholdingPeriod":   cb.failureThresholdingPeriod,
+		"successThreshold":            cb.successThreshold,
+		"successThresholdingCapacity": cb.successThresholdingCapacity,
+		"flappingWindow":              cb.flappingWindow,
+		"maxFlappingTransitions":      cb.maxFlappingTransitions,
+	}
+}
+
+// PolicyMetrics returns the circuit breaker's state, where 0 is closed, 1 is open, and 2 is half-open, along with the
+// metrics for the current state, and counters for rejections, half-open permits, and transitions to the open state.
+func (cb *circuitBreaker[R]) PolicyMetrics() []failsafe.Metric {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	return []failsafe.Metric{
+		{Name: "state", Kind: failsafe.GaugeMetric, Value: float64(cb.state.state())},
+		{Name: "executions", Kind: failsafe.GaugeMetric, Value: float64(cb.state.executionCount())},
+		{Name: "failures", Kind: failsafe.GaugeMetric, Value: float64(cb.state.failureCount())},
+		{Name: "failureRate", Kind: failsafe.GaugeMetric, Value: float64(cb.state.failureRate())},
+		{Name: "successes", Kind: failsafe.GaugeMetric, Value: float64(cb.state.successCount())},
+		{Name: "successRate", Kind: failsafe.GaugeMetric, Value: float64(cb.state.successRate())},
+		{Name: "openRejections", Kind: failsafe.CounterMetric, Value: float64(cb.stateMetrics.OpenRejections)},
+		{Name: "halfOpenPermits", Kind: failsafe.CounterMetric, Value: float64(cb.stateMetrics.HalfOpenPermits)},
+		{Name: "halfOpenRejections", Kind: failsafe.CounterMetric, Value: float64(cb.stateMetrics.HalfOpenRejections)},
+		{Name: "opens", Kind: failsafe.CounterMetric, Value: float64(cb.opens())},
+	}
+}
+
+// Returns the number of transitions to the OpenState.
+//
+// Requires external locking.
+func (cb *circuitBreaker[R]) opens() uint64 {
+	return cb.stateMetrics.Transitions[StateTransition{ClosedState, OpenState}] +
+		cb.stateMetrics.Transitions[StateTransition{HalfOpenState, OpenState}]
+}
+
 func (cb *circuitBreaker[R]) ToExecutor(_ R) any {
 	cbe := &executor[R]{
 		BaseExecutor: &policy.BaseExecutor[R]{
@@ -287,35 +491,46 @@ func (cb *circuitBreaker[R]) ToExecutor(_ R) any {
 // Transitions to the newState if not already in that state and calls listener after transitioning.
 //
 // Requires external locking.
-func (cb *circuitBreaker[R]) transitionTo(newState State, exec failsafe.Execution[R], listener func(StateChangedEvent)) {
+func (cb *circuitBreaker[R]) transitionTo(newState State, reason StateChangeReason, exec failsafe.Execution[R], listener func(StateChangedEvent)) {
 	transitioned := false
 	currentState := cb.state
 	if currentState.state() != newState {
 		switch newState {
 		case ClosedState:
 			cb.state = newClosedState(cb)
+			cb.lastDelay = 0
 		case OpenState:
 			delay := cb.ComputeDelay(exec)
 			if delay == -1 {
-				delay = cb.Delay
+				delay = cb.computeBackoffDelay(currentState.state() == HalfOpenState)
+			}
+			if currentState.state() == ClosedState && reason != ManualReason && cb.isFlapping() {
+				delay = max(delay, cb.flappingWindow)
 			}
 			cb.state = newOpenState(cb, cb.state, delay)
 		case HalfOpenState:
 			cb.state = newHalfOpenState(cb)
 		}
 		transitioned = true
+		if cb.stateMetrics.Transitions == nil {
+			cb.stateMetrics.Transitions = make(map[StateTransition]uint64)
+		}
+		cb.stateMetrics.Transitions[StateTransition{From: currentState.state(), To: newState}]++
 	}
 
 	if transitioned && (listener != nil || cb.stateChangedListener != nil) {
-		ctx := context.Background()
-		if exec != nil {
-			ctx = exec.Context()
-		}
 		event := StateChangedEvent{
+			Name:     cb.name,
 			OldState: currentState.state(),
 			NewState: newState,
-			metrics:  &eventMetrics{currentState},
-			context:  ctx,
+			Reason:   reason,
+			metrics:  &eventMetrics{currentState, currentState.classStats()},
+			context:  context.Background(),
+		}
+		if exec != nil {
+			event.LastResult = exec.LastResult()
+			event.LastError = exec.LastError()
+			event.context = exec.Context()
 		}
 		if listener != nil {
 			listener(event)
@@ -326,8 +541,46 @@ func (cb *circuitBreaker[R]) transitionTo(newState State, exec failsafe.Executio
 	}
 }
 
+// Returns the delay to use for an OpenState, which is backed off from the last delay when reopening from a HalfOpenState
+// and delay backoff is configured.
+//
+// Requires external locking.
+func (cb *circuitBreaker[R]) computeBackoffDelay(reopening bool) time.Duration {
+	if cb.delayFactor == 0 {
+		return cb.Delay
+	}
+	delay := cb.Delay
+	if reopening && cb.lastDelay > 0 {
+		delay = min(time.Duration(float32(cb.lastDelay)*cb.delayFactor), cb.maxDelay)
+	}
+	cb.lastDelay = delay
+	return delay
+}
+
+// Records an opening of the circuit from ClosedState and returns whether the circuit is flapping, which is when it has
+// been opened more than maxFlappingTransitions times within the flappingWindow. Returns false if flapping dampening is
+// not configured.
+//
+// Requires external locking.
+func (cb *circuitBreaker[R]) isFlapping() bool {
+	if cb.flappingWindow <= 0 {
+		return false
+	}
+	now := cb.clock.CurrentUnixNano()
+	windowStart := now - cb.flappingWindow.Nanoseconds()
+	recentOpens := cb.recentOpens[:0]
+	for _, openTime := range cb.recentOpens {
+		if openTime > windowStart {
+			recentOpens = append(recentOpens, openTime)
+		}
+	}
+	cb.recentOpens = append(recentOpens, now)
+	return uint(len(cb.recentOpens)) > cb.maxFlappingTransitions
+}
+
 type eventMetrics struct {
-	stats stats
+	stats   stats
+	classes *classStats
 }
 
 func (m *eventMetrics) Executions() uint {
@@ -350,33 +603,56 @@ func (m *eventMetrics) SuccessRate() uint {
 	return m.stats.successRate()
 }
 
+func (m *eventMetrics) FailuresByClass() map[string]uint {
+	return m.classes.failureCounts()
+}
+
 // Requires external locking.
 func (cb *circuitBreaker[R]) tryAcquirePermit() bool {
 	return cb.state.tryAcquirePermit()
 }
 
+// Tries to acquire a permit and records the outcome in the stateMetrics, based on the state after trying, since an
+// OpenState may transition to a HalfOpenState when a permit is requested.
+//
+// Requires external locking.
+func (cb *circuitBreaker[R]) acquirePermit() bool {
+	permitted := cb.tryAcquirePermit()
+	switch state := cb.state.state(); {
+	case state == ClosedState && permitted:
+		cb.stateMetrics.ClosedPermits++
+	case state == OpenState:
+		cb.stateMetrics.OpenRejections++
+	case state == HalfOpenState && permitted:
+		cb.stateMetrics.HalfOpenPermits++
+	case state == HalfOpenState:
+		cb.stateMetrics.HalfOpenRejections++
+	}
+	return permitted
+}
+
 // Opens the circuit breaker and considers the execution when computing the delay before the circuit breaker
 // will transition to half open.
 //
 // Requires external locking.
-func (cb *circuitBreaker[R]) open(execution failsafe.Execution[R]) {
-	cb.transitionTo(OpenState, execution, cb.openListener)
+func (cb *circuitBreaker[R]) open(execution failsafe.Execution[R], reason StateChangeReason) {
+	cb.transitionTo(OpenState, reason, execution, cb.openListener)
 }
 
 // Requires external locking.
-func (cb *circuitBreaker[R]) close() {
-	cb.transitionTo(ClosedState, nil, cb.closeListener)
+func (cb *circuitBreaker[R]) close(reason StateChangeReason) {
+	cb.transitionTo(ClosedState, reason, nil, cb.closeListener)
 }
 
 // Requires external locking.
-func (cb *circuitBreaker[R]) halfOpen() {
-	cb.transitionTo(HalfOpenState, nil, cb.halfOpenListener)
+func (cb *circuitBreaker[R]) halfOpen(reason StateChangeReason) {
+	cb.transitionTo(HalfOpenState, reason, nil, cb.halfOpenListener)
 }
 
 // Requires external locking.
 func (cb *circuitBreaker[R]) recordResult(result R, err error) {
 	if cb.IsFailure(result, err) {
-		cb.recordFailure(nil)
+		cb.recordFailure(nil, cb.classify(result, err))
 	} else {
 		cb.recordSuccess()
 	}
@@ -385,16 +661,34 @@ func (cb *circuitBreaker[R]) recordResult(result R, err error) {
 // Requires external locking.
 func (cb *circuitBreaker[R]) recordSuccess() {
 	cb.state.recordSuccess()
+	cb.state.classStats().recordSuccess()
 	cb.state.checkThresholdAndReleasePermit(nil)
 }
 
+// Records a failure for the class, which may be empty if the failure is unclassified. Failures for a class with its own
+// failure threshold are not recorded in the ClosedState's stats.
+//
 // Requires external locking.
-func (cb *circuitBreaker[R]) recordFailure(exec failsafe.Execution[R]) {
-	cb.state.recordFailure()
+func (cb *circuitBreaker[R]) recordFailure(exec failsafe.Execution[R], class string) {
+	if _, isolated := cb.classFailureThreshold[class]; !isolated || class == "" || cb.state.state() != ClosedState {
+		cb.state.recordFailure()
+	}
+	cb.state.classStats().recordFailure(class)
 	cb.state.checkThresholdAndReleasePermit(exec)
 }
 
+// Returns the class for a failure, else an empty string if no failure classifier is configured.
+func (cb *circuitBreaker[R]) classify(result R, err error) string {
+	if cb.failureClassifier == nil {
+		return ""
+	}
+	return cb.failureClassifier(result, err)
+}
+
 func (cb *circuitBreaker[R]) Reset() {
-	cb.close()
+	cb.close(ManualReason)
+	cb.lastDelay = 0
+	cb.recentOpens = nil
 	cb.state.reset()
+	cb.state.classStats().reset()
 }
@@ -1,10 +1,14 @@
 package circuitbreaker
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
 )
 
 var _ CircuitBreaker[any] = &circuitBreaker[any]{}
@@ -77,3 +81,189 @@ func BenchmarkTimedCircuitBreaker(b *testing.B) {
 			Build()
 	}
 }
+
+func TestFailuresByClass(t *testing.T) {
+	// Given
+	breaker := Builder[any]().
+		WithFailureThresholdRatio(5, 10).
+		WithFailureClassifier(func(_ any, err error) string {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return "timeout"
+			}
+			return "other"
+		}).
+		Build()
+
+	// When
+	breaker.RecordError(context.DeadlineExceeded)
+	breaker.RecordError(context.DeadlineExceeded)
+	breaker.RecordError(errors.New("test"))
+	breaker.RecordSuccess()
+
+	// Then
+	assert.Equal(t, uint(3), breaker.Metrics().Failures())
+	assert.Equal(t, map[string]uint{"timeout": 2, "other": 1}, breaker.Metrics().FailuresByClass())
+}
+
+func TestClassFailureThreshold(t *testing.T) {
+	// Given
+	breaker := Builder[any]().
+		WithFailureThresholdRatio(2, 10).
+		WithFailureClassifier(func(_ any, err error) string {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return "timeout"
+			}
+			return ""
+		}).
+		WithClassFailureThreshold("timeout", 4).
+		Build()
+
+	// When / Then
+	for i := 0; i < 3; i++ {
+		breaker.RecordError(context.DeadlineExceeded)
+	}
+	assert.True(t, breaker.IsClosed())
+	assert.Equal(t, uint(0), breaker.Metrics().Failures())
+	assert.Equal(t, uint(0), breaker.Metrics().Successes())
+	assert.Equal(t, uint(0), breaker.Metrics().Executions())
+	breaker.RecordError(context.DeadlineExceeded)
+	assert.True(t, breaker.IsOpen())
+
+	// When / Then
+	breaker.Close()
+	breaker.RecordError(errors.New("test"))
+	assert.True(t, breaker.IsClosed())
+	breaker.RecordError(errors.New("test"))
+	assert.True(t, breaker.IsOpen())
+}
+
+// Asserts that class failure thresholds that are configured after a breaker is built don't affect it.
+func TestClassFailureThresholdIsCopied(t *testing.T) {
+	// Given
+	builder := Builder[any]().
+		WithFailureThreshold(2).
+		WithFailureClassifier(func(_ any, err error) string {
+			return "timeout"
+		})
+	breaker := builder.Build()
+
+	// When
+	builder.WithClassFailureThreshold("timeout", 1)
+	breaker.RecordError(context.DeadlineExceeded)
+
+	// Then
+	assert.True(t, breaker.IsClosed())
+	assert.Equal(t, uint(1), breaker.Metrics().Failures())
+}
+
+func TestClassFailureThresholdShouldPanicWhenZero(t *testing.T) {
+	assert.Panics(t, func() {
+		Builder[any]().WithClassFailureThreshold("timeout", 0)
+	})
+}
+
+func TestDelayBackoffShouldPanicWithInvalidConfig(t *testing.T) {
+	assert.Panics(t, func() {
+		Builder[any]().WithDelayBackoff(time.Second, 0, 2)
+	})
+	assert.Panics(t, func() {
+		Builder[any]().WithDelayBackoff(time.Second, time.Minute, 1)
+	})
+}
+
+// Asserts that a standalone breaker records results and errors together, according to its failure handling.
+func TestRecord(t *testing.T) {
+	// Given
+	breaker := Builder[string]().
+		HandleResult("bad").
+		WithFailureThreshold(2).
+		Build()
+
+	// When / Then
+	assert.True(t, breaker.TryAcquirePermit())
+	breaker.Record("good", nil)
+	assert.True(t, breaker.TryAcquirePermit())
+	breaker.Record("bad", nil)
+	assert.True(t, breaker.TryAcquirePermit())
+	breaker.Record("", errors.New("test"))
+	assert.Equal(t, uint(2), breaker.Metrics().Failures())
+	assert.True(t, breaker.IsOpen())
+	assert.False(t, breaker.TryAcquirePermit())
+}
+
+func TestStateMetrics(t *testing.T) {
+	breaker := Builder[any]().WithDelay(10 * time.Millisecond).Build()
+
+	// Closed
+	assert.True(t, breaker.TryAcquirePermit())
+	breaker.RecordFailure()
+
+	// Open
+	assert.False(t, breaker.TryAcquirePermit())
+	time.Sleep(20 * time.Millisecond)
+
+	// Half-open
+	assert.True(t, breaker.TryAcquirePermit())
+	assert.False(t, breaker.TryAcquirePermit())
+	breaker.RecordSuccess()
+
+	metrics := breaker.StateMetrics()
+	assert.Equal(t, StateMetrics{
+		ClosedPermits:      1,
+		OpenRejections:     1,
+		HalfOpenPermits:    1,
+		HalfOpenRejections: 1,
+		Transitions: map[StateTransition]uint64{
+			{ClosedState, OpenState}:     1,
+			{OpenState, HalfOpenState}:   1,
+			{HalfOpenState, ClosedState}: 1,
+		},
+	}, metrics)
+	assert.Equal(t, "closed->open", StateTransition{ClosedState, OpenState}.String())
+
+	// Snapshots are not affected by later transitions
+	breaker.Open()
+	assert.Equal(t, uint64(1), metrics.Transitions[StateTransition{ClosedState, OpenState}])
+	assert.Equal(t, uint64(2), breaker.StateMetrics().Transitions[StateTransition{ClosedState, OpenState}])
+}
+
+func TestFlappingDampening(t *testing.T) {
+	clock := &testutil.TestClock{}
+	breaker := Builder[any]().
+		WithDelay(time.Second).
+		WithFlappingDampening(time.Minute, 2).
+		Build().(*circuitBreaker[any])
+	breaker.clock = clock
+	flap := func() {
+		breaker.RecordFailure()
+		assert.True(t, breaker.IsOpen())
+	}
+
+	// When the circuit is opened up to maxTransitions times within the window
+	for i := 0; i < 2; i++ {
+		flap()
+		assert.Equal(t, time.Second, breaker.RemainingDelay())
+		breaker.Close()
+	}
+
+	// Then the circuit is dampened when opened again
+	flap()
+	assert.Equal(t, time.Minute, breaker.RemainingDelay())
+	breaker.Close()
+
+	// When the window passes
+	clock.CurrentTime += time.Minute.Nanoseconds()
+
+	// Then the circuit is not dampened
+	flap()
+	assert.Equal(t, time.Second, breaker.RemainingDelay())
+
+	// Manual opens are not counted
+	breaker.Close()
+	for i := 0; i < 3; i++ {
+		breaker.Open()
+		breaker.Close()
+	}
+	flap()
+	assert.Equal(t, time.Second, breaker.RemainingDelay())
+}
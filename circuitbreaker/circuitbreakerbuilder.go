@@ -1,6 +1,7 @@
 package circuitbreaker
 
 import (
+	"maps"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
@@ -22,6 +23,7 @@ R is the execution result type. This type is not concurrency safe.
 type CircuitBreakerBuilder[R any] interface {
 	failsafe.FailurePolicyBuilder[CircuitBreakerBuilder[R], R]
 	failsafe.DelayablePolicyBuilder[CircuitBreakerBuilder[R], R]
+	failsafe.NamedPolicyBuilder[CircuitBreakerBuilder[R]]
 
 	// OnStateChanged calls the listener when the CircuitBreaker state changes.
 	OnStateChanged(listener func(StateChangedEvent)) CircuitBreakerBuilder[R]
@@ -72,6 +74,15 @@ type CircuitBreakerBuilder[R any] interface {
 	// WithDelayFunc configures a function that provides the delay to wait in OpenState before transitioning to HalfOpenState.
 	WithDelayFunc(delayFunc failsafe.DelayFunc[R]) CircuitBreakerBuilder[R]
 
+	// WithDelayBackoff configures the delay to wait in OpenState before transitioning to HalfOpenState, which starts at the
+	// delay and is multiplied by the delayFactor each time the circuit is re-opened from HalfOpenState, up to the
+	// maxDelay. The delay is reset when the circuit is closed. This reduces how often a long unavailable dependency is
+	// probed. Replaces any previously configured fixed delay, but a WithDelayFunc takes precedence when it provides a
+	// delay.
+	//
+	// Panics if maxDelay is <= delay or delayFactor is <= 1.
+	WithDelayBackoff(delay time.Duration, maxDelay time.Duration, delayFactor float32) CircuitBreakerBuilder[R]
+
 	// WithSuccessThreshold configures count based success thresholding by setting the number of consecutive successful
 	// executions that must occur when in a HalfOpenState in order to close the circuit, else the circuit is re-opened when a
 	// failure occurs.
@@ -82,6 +93,25 @@ type CircuitBreakerBuilder[R any] interface {
 	// out of the last 10 executions were successful.
 	WithSuccessThresholdRatio(successThreshold uint, successThresholdingCapacity uint) CircuitBreakerBuilder[R]
 
+	// WithFailureClassifier configures a classifier that assigns failures to classes, such as timeouts or connection
+	// errors. Failures are counted per class, which is available via Metrics.FailuresByClass. Failures that are classified
+	// as an empty string are not counted by class.
+	WithFailureClassifier(classifier func(R, error) string) CircuitBreakerBuilder[R]
+
+	// WithClassFailureThreshold configures a separate failure threshold for failures of the class, as assigned by a
+	// WithFailureClassifier, when in a ClosedState. Failures of the class are counted within the same thresholding
+	// capacity or period as other failures, and the circuit is opened when the failureThreshold is reached. Failures of the
+	// class are not counted towards the CircuitBreaker's other failure thresholds when in a ClosedState, isolating the class
+	// from other failures. Panics if the failureThreshold is 0.
+	WithClassFailureThreshold(class string, failureThreshold uint) CircuitBreakerBuilder[R]
+
+	// WithFlappingDampening configures the CircuitBreaker to detect flapping, where the circuit is opened from ClosedState
+	// more than maxTransitions times within the window, which indicates a marginally healthy dependency that repeatedly
+	// recovers and fails again. When flapping is detected, the circuit stays in OpenState for at least the window, rather
+	// than for the configured delay, which avoids repeatedly sending bursts of executions to the dependency. Manual
+	// transitions are not counted.
+	WithFlappingDampening(window time.Duration, maxTransitions uint) CircuitBreakerBuilder[R]
+
 	// Build returns a new CircuitBreaker using the builder's configuration.
 	Build() CircuitBreaker[R]
 }
@@ -89,12 +119,17 @@ type CircuitBreakerBuilder[R any] interface {
 type config[R any] struct {
 	*policy.BaseFailurePolicy[R]
 	*policy.BaseDelayablePolicy[R]
+	name                 string
 	clock                util.Clock
 	stateChangedListener func(StateChangedEvent)
 	openListener         func(StateChangedEvent)
 	halfOpenListener     func(StateChangedEvent)
 	closeListener        func(StateChangedEvent)
 
+	// Delay backoff config
+	maxDelay    time.Duration
+	delayFactor float32
+
 	// Failure config
 	failureThreshold            uint
 	failureRateThreshold        uint
@@ -105,6 +140,14 @@ type config[R any] struct {
 	// Success config
 	successThreshold            uint
 	successThresholdingCapacity uint
+
+	// Failure class config
+	failureClassifier     func(R, error) string
+	classFailureThreshold map[string]uint
+
+	// Flapping config
+	flappingWindow         time.Duration
+	maxFlappingTransitions uint
 }
 
 var _ CircuitBreakerBuilder[any] = &config[any]{}
@@ -131,9 +174,17 @@ func Builder[R any]() CircuitBreakerBuilder[R] {
 	}
 }
 
+func (c *config[R]) WithName(name string) CircuitBreakerBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() CircuitBreaker[R] {
+	cbCopy := *c
+	// Copy the class thresholds so that further builder changes don't affect the breaker
+	cbCopy.classFailureThreshold = maps.Clone(c.classFailureThreshold)
 	breaker := &circuitBreaker[R]{
-		config: c, // TODO copy base fields
+		config: &cbCopy, // TODO copy base fields
 	}
 	breaker.state = newClosedState[R](breaker)
 	return breaker
@@ -159,6 +210,11 @@ func (c *config[R]) HandleIf(predicate func(R, error) bool) CircuitBreakerBuilde
 	return c
 }
 
+func (c *config[R]) HandleWith(classifier failsafe.Classifier[R]) CircuitBreakerBuilder[R] {
+	c.BaseFailurePolicy.HandleWith(classifier)
+	return c
+}
+
 func (c *config[R]) WithFailureThreshold(failureThreshold uint) CircuitBreakerBuilder[R] {
 	return c.WithFailureThresholdRatio(failureThreshold, failureThreshold)
 }
@@ -199,11 +255,46 @@ func (c *config[R]) WithDelay(delay time.Duration) CircuitBreakerBuilder[R] {
 	return c
 }
 
+func (c *config[R]) WithDelayBackoff(delay time.Duration, maxDelay time.Duration, delayFactor float32) CircuitBreakerBuilder[R] {
+	if maxDelay <= delay {
+		panic("maxDelay must be > delay")
+	}
+	if delayFactor <= 1 {
+		panic("delayFactor must be > 1")
+	}
+	c.BaseDelayablePolicy.WithDelay(delay)
+	c.maxDelay = maxDelay
+	c.delayFactor = delayFactor
+	return c
+}
+
 func (c *config[R]) WithDelayFunc(delayFunc failsafe.DelayFunc[R]) CircuitBreakerBuilder[R] {
 	c.BaseDelayablePolicy.WithDelayFunc(delayFunc)
 	return c
 }
 
+func (c *config[R]) WithFailureClassifier(classifier func(R, error) string) CircuitBreakerBuilder[R] {
+	c.failureClassifier = classifier
+	return c
+}
+
+func (c *config[R]) WithClassFailureThreshold(class string, failureThreshold uint) CircuitBreakerBuilder[R] {
+	if failureThreshold == 0 {
+		panic("class failureThreshold must be > 0")
+	}
+	if c.classFailureThreshold == nil {
+		c.classFailureThreshold = make(map[string]uint)
+	}
+	c.classFailureThreshold[class] = failureThreshold
+	return c
+}
+
+func (c *config[R]) WithFlappingDampening(window time.Duration, maxTransitions uint) CircuitBreakerBuilder[R] {
+	c.flappingWindow = window
+	c.maxFlappingTransitions = maxTransitions
+	return c
+}
+
 func (c *config[R]) OnStateChanged(listener func(event StateChangedEvent)) CircuitBreakerBuilder[R] {
 	c.stateChangedListener = listener
 	return c
@@ -1,6 +1,7 @@
 package circuitbreaker
 
 import (
+	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
 	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
@@ -14,16 +15,33 @@ type executor[R any] struct {
 
 var _ policy.Executor[any] = &executor[any]{}
 
-func (e *executor[R]) PreExecute(_ policy.ExecutionInternal[R]) *common.PolicyResult[R] {
-	if !e.TryAcquirePermit() {
-		return internal.FailureResult[R](ErrOpen)
+func (e *executor[R]) PreExecute(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	oldState := e.state.state()
+	permitted := e.acquirePermit()
+	e.logStateChange(exec, oldState)
+	if !permitted {
+		if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+			logger.Debug("circuit breaker rejected execution", "policy", failsafe.CircuitBreakerKind, "attempts", exec.Attempts())
+		}
+		return internal.FailureResult[R](OpenError{
+			CircuitBreaker: e.circuitBreaker,
+			Name:           e.name,
+			State:          e.state.state(),
+			RemainingDelay: e.state.remainingDelay(),
+		})
 	}
 	return nil
 }
 
 func (e *executor[R]) OnSuccess(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) {
 	e.BaseExecutor.OnSuccess(exec, result)
-	e.RecordSuccess()
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	oldState := e.state.state()
+	e.recordSuccess()
+	e.logStateChange(exec, oldState)
 }
 
 func (e *executor[R]) OnFailure(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
@@ -32,6 +50,22 @@ func (e *executor[R]) OnFailure(exec policy.ExecutionInternal[R], result *common
 	defer e.mtx.Unlock()
 
 	// Wrap the result in the execution, so it's available when computing a delay
-	e.recordFailure(exec.CopyWithResult(result))
+	oldState := e.state.state()
+	e.recordFailure(exec.CopyWithResult(result), e.classify(result.Result, result.Error))
+	e.logStateChange(exec, oldState)
 	return result
 }
+
+// logStateChange logs a debug event if the circuit breaker's state changed from the oldState while handling the exec.
+//
+// Requires external locking.
+func (e *executor[R]) logStateChange(exec policy.ExecutionInternal[R], oldState State) {
+	if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+		if newState := e.state.state(); newState != oldState {
+			logger.Debug("circuit breaker state changed",
+				"policy", failsafe.CircuitBreakerKind,
+				"oldState", oldState,
+				"newState", newState)
+		}
+	}
+}
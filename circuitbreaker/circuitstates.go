@@ -14,11 +14,13 @@ type circuitState[R any] interface {
 	remainingDelay() time.Duration
 	tryAcquirePermit() bool
 	checkThresholdAndReleasePermit(exec failsafe.Execution[R])
+	classStats() *classStats
 }
 
 type closedState[R any] struct {
 	breaker *circuitBreaker[R]
 	stats
+	classes *classStats
 }
 
 func newClosedState[R any](breaker *circuitBreaker[R]) *closedState[R] {
@@ -31,6 +33,9 @@ func newClosedState[R any](breaker *circuitBreaker[R]) *closedState[R] {
 	return &closedState[R]{
 		breaker: breaker,
 		stats:   newStats(breaker.config, true, capacity),
+		classes: newClassStats(breaker.config, func() stats {
+			return newStats(breaker.config, true, capacity)
+		}),
 	}
 }
 
@@ -38,6 +43,10 @@ func (s *closedState[R]) state() State {
 	return ClosedState
 }
 
+func (s *closedState[R]) classStats() *classStats {
+	return s.classes
+}
+
 func (s *closedState[R]) remainingDelay() time.Duration {
 	return 0
 }
@@ -46,15 +55,23 @@ func (s *closedState[R]) tryAcquirePermit() bool {
 	return true
 }
 
-// Checks to see if the executions and failure thresholds have been exceeded, opening the circuit if so.
+// Checks to see if the executions and failure thresholds, including any class failure thresholds, have been exceeded,
+// opening the circuit if so.
 func (s *closedState[R]) checkThresholdAndReleasePermit(exec failsafe.Execution[R]) {
+	for class, classFailureThreshold := range s.breaker.classFailureThreshold {
+		if s.classes.failureCount(class) >= classFailureThreshold {
+			s.breaker.open(exec, FailureThresholdReason)
+			return
+		}
+	}
+
 	// Execution threshold can only be set for time based thresholding
 	if s.executionCount() >= s.breaker.failureExecutionThreshold {
 		// Failure rate threshold can only be set for time based thresholding
 		failureRateThreshold := s.breaker.failureRateThreshold
 		if (failureRateThreshold != 0 && s.failureRate() >= failureRateThreshold) ||
 			(failureRateThreshold == 0 && s.failureCount() >= s.breaker.failureThreshold) {
-			s.breaker.open(exec)
+			s.breaker.open(exec, FailureThresholdReason)
 		}
 	}
 }
@@ -62,6 +79,7 @@ func (s *closedState[R]) checkThresholdAndReleasePermit(exec failsafe.Execution[
 type openState[R any] struct {
 	breaker *circuitBreaker[R]
 	stats
+	classes   *classStats
 	startTime int64
 	delay     time.Duration
 }
@@ -70,6 +88,7 @@ func newOpenState[R any](breaker *circuitBreaker[R], previousState circuitState[
 	return &openState[R]{
 		breaker:   breaker,
 		stats:     previousState,
+		classes:   previousState.classStats(),
 		startTime: breaker.clock.CurrentUnixNano(),
 		delay:     delay,
 	}
@@ -79,6 +98,10 @@ func (s *openState[R]) state() State {
 	return OpenState
 }
 
+func (s *openState[R]) classStats() *classStats {
+	return s.classes
+}
+
 func (s *openState[R]) remainingDelay() time.Duration {
 	elapsedTime := s.breaker.clock.CurrentUnixNano() - s.startTime
 	return max(0, s.delay-time.Duration(elapsedTime))
@@ -86,7 +109,7 @@ func (s *openState[R]) remainingDelay() time.Duration {
 
 func (s *openState[R]) tryAcquirePermit() bool {
 	if s.breaker.clock.CurrentUnixNano()-s.startTime >= s.delay.Nanoseconds() {
-		s.breaker.halfOpen()
+		s.breaker.halfOpen(DelayElapsedReason)
 		return s.breaker.tryAcquirePermit()
 	}
 	return false
@@ -98,6 +121,7 @@ func (s *openState[R]) checkThresholdAndReleasePermit(_ failsafe.Execution[R]) {
 type halfOpenState[R any] struct {
 	breaker *circuitBreaker[R]
 	stats
+	classes             *classStats
 	permittedExecutions uint
 }
 
@@ -110,8 +134,11 @@ func newHalfOpenState[R any](breaker *circuitBreaker[R]) *halfOpenState[R] {
 		capacity = breaker.failureThresholdingCapacity
 	}
 	return &halfOpenState[R]{
-		breaker:             breaker,
-		stats:               newStats[R](breaker.config, false, capacity),
+		breaker: breaker,
+		stats:   newStats[R](breaker.config, false, capacity),
+		classes: newClassStats(breaker.config, func() stats {
+			return newStats[R](breaker.config, false, capacity)
+		}),
 		permittedExecutions: capacity,
 	}
 }
@@ -120,6 +147,10 @@ func (s *halfOpenState[R]) state() State {
 	return HalfOpenState
 }
 
+func (s *halfOpenState[R]) classStats() *classStats {
+	return s.classes
+}
+
 func (s *halfOpenState[R]) remainingDelay() time.Duration {
 	return 0
 }
@@ -165,9 +196,9 @@ func (s *halfOpenState[R]) checkThresholdAndReleasePermit(exec failsafe.Executio
 	}
 
 	if successesExceeded {
-		s.breaker.close()
+		s.breaker.close(SuccessThresholdReason)
 	} else if failuresExceeded {
-		s.breaker.open(exec)
+		s.breaker.open(exec, FailureThresholdReason)
 	}
 	s.permittedExecutions++
 }
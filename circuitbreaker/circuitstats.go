@@ -226,3 +226,81 @@ func (s *timedStats) reset() {
 	s.summary.reset()
 	s.head = 0
 }
+
+// classStats tracks failures by class. Each class has a stats that records failures of the class as failures, and other
+// executions as successes, so that failures of the class are counted within the same capacity or period as other
+// failures. A nil classStats records nothing.
+// Not concurrency safe and must be guarded externally.
+type classStats struct {
+	newStats func() stats
+	byClass  map[string]stats
+}
+
+func newClassStats[R any](config *config[R], newStats func() stats) *classStats {
+	if config.failureClassifier == nil {
+		return nil
+	}
+	return &classStats{
+		newStats: newStats,
+		byClass:  make(map[string]stats),
+	}
+}
+
+func (c *classStats) recordSuccess() {
+	if c == nil {
+		return
+	}
+	for _, s := range c.byClass {
+		s.recordSuccess()
+	}
+}
+
+func (c *classStats) recordFailure(class string) {
+	if c == nil {
+		return
+	}
+	for k, s := range c.byClass {
+		if k != class {
+			s.recordSuccess()
+		}
+	}
+	if class == "" {
+		return
+	}
+	s, ok := c.byClass[class]
+	if !ok {
+		s = c.newStats()
+		c.byClass[class] = s
+	}
+	s.recordFailure()
+}
+
+func (c *classStats) failureCount(class string) uint {
+	if c == nil {
+		return 0
+	}
+	if s, ok := c.byClass[class]; ok {
+		return s.failureCount()
+	}
+	return 0
+}
+
+func (c *classStats) failureCounts() map[string]uint {
+	result := make(map[string]uint)
+	if c == nil {
+		return result
+	}
+	for class, s := range c.byClass {
+		if failures := s.failureCount(); failures > 0 {
+			result[class] = failures
+		}
+	}
+	return result
+}
+
+func (c *classStats) reset() {
+	if c == nil {
+		return
+	}
+	clear(c.byClass)
+}
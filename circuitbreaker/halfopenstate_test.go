@@ -359,6 +359,6 @@ func TestRemainingDelayInHalfOpenState(t *testing.T) {
 	}).Build().(*circuitBreaker[any])
 
 	// When / Then
-	breaker.halfOpen()
+	breaker.halfOpen(ManualReason)
 	assert.Equal(t, time.Duration(0), breaker.RemainingDelay())
 }
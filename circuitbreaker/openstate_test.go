@@ -16,7 +16,7 @@ func TestTryAcquirePermit(t *testing.T) {
 	breaker := Builder[any]().WithDelayFunc(func(exec failsafe.ExecutionAttempt[any]) time.Duration {
 		return 100 * time.Millisecond
 	}).Build().(*circuitBreaker[any])
-	breaker.open(testutil.TestExecution[any]{})
+	breaker.open(testutil.TestExecution[any]{}, ManualReason)
 	assert.True(t, breaker.IsOpen())
 	assert.False(t, breaker.TryAcquirePermit())
 
@@ -32,7 +32,7 @@ func TestRemainingDelay(t *testing.T) {
 	breaker := Builder[any]().WithDelayFunc(func(exec failsafe.ExecutionAttempt[any]) time.Duration {
 		return 1 * time.Second
 	}).Build().(*circuitBreaker[any])
-	breaker.open(testutil.TestExecution[any]{})
+	breaker.open(testutil.TestExecution[any]{}, ManualReason)
 
 	// When / Then
 	remainingDelay := breaker.RemainingDelay()
@@ -52,10 +52,47 @@ func TestNoRemainingDelay(t *testing.T) {
 	assert.Equal(t, time.Duration(0), breaker.RemainingDelay())
 
 	// When
-	breaker.open(testutil.TestExecution[any]{})
+	breaker.open(testutil.TestExecution[any]{}, ManualReason)
 	assert.True(t, breaker.RemainingDelay() > 0)
 	time.Sleep(50 * time.Millisecond)
 
 	// Then
 	assert.Equal(t, time.Duration(0), breaker.RemainingDelay())
 }
+
+func TestDelayBackoff(t *testing.T) {
+	breaker := Builder[any]().
+		WithDelayBackoff(time.Second, 5*time.Second, 2).
+		Build().(*circuitBreaker[any])
+	delay := func() time.Duration {
+		return breaker.state.(*openState[any]).delay
+	}
+
+	// When / Then
+	breaker.RecordFailure()
+	assert.Equal(t, time.Second, delay())
+	breaker.HalfOpen()
+	breaker.RecordFailure()
+	assert.Equal(t, 2*time.Second, delay())
+	breaker.HalfOpen()
+	breaker.RecordFailure()
+	assert.Equal(t, 4*time.Second, delay())
+	breaker.HalfOpen()
+	breaker.RecordFailure()
+	assert.Equal(t, 5*time.Second, delay())
+
+	// Manually opening from a closed state should reset the delay
+	breaker.Close()
+	breaker.Open()
+	assert.Equal(t, time.Second, delay())
+
+	// Closing after a success should reset the delay
+	breaker.HalfOpen()
+	breaker.RecordFailure()
+	assert.Equal(t, 2*time.Second, delay())
+	breaker.HalfOpen()
+	breaker.RecordSuccess()
+	assert.True(t, breaker.IsClosed())
+	breaker.RecordFailure()
+	assert.Equal(t, time.Second, delay())
+}
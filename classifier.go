@@ -0,0 +1,11 @@
+package failsafe
+
+// Classifier determines whether execution results and errors are failures. A Classifier can be shared across multiple
+// policies via FailurePolicyBuilder.HandleWith, so that policies in a composition use consistent failure conditions.
+// Classifiers can be built via the classifier package.
+//
+// R is the execution result type. This type is concurrency safe.
+type Classifier[R any] interface {
+	// IsFailure returns whether the result and err are a failure.
+	IsFailure(result R, err error) bool
+}
@@ -0,0 +1,77 @@
+package classifier
+
+import (
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+/*
+ClassifierBuilder builds failsafe.Classifier instances, which can be shared across multiple policies via
+FailurePolicyBuilder.HandleWith, so that policies in a composition use consistent failure conditions.
+
+  - By default, any error is considered a failure. You can override this by specifying your own handle conditions. The
+    default error handling condition will only be overridden by another condition that handles errors such as
+    HandleErrors or HandleIf. Specifying a condition that only handles results, such as HandleResult will not replace the
+    default error handling condition.
+  - If multiple handle conditions are specified, any condition that matches an execution result or error will be
+    considered a failure.
+
+R is the execution result type. This type is not concurrency safe.
+*/
+type ClassifierBuilder[R any] interface {
+	// HandleErrors specifies the errors to classify as failures. Any errs that evaluate to true for errors.Is and the
+	// execution error will be classified as failures.
+	HandleErrors(errs ...error) ClassifierBuilder[R]
+
+	// HandleErrorTypes specifies the errors whose types should be classified as failures. Any execution errors or their
+	// Unwrapped parents whose type matches any of the errs' types will be classified as failures.
+	HandleErrorTypes(errs ...any) ClassifierBuilder[R]
+
+	// HandleResult specifies the results to classify as failures. Any result that evaluates to true for
+	// reflect.DeepEqual and the execution result will be classified as a failure.
+	HandleResult(result R) ClassifierBuilder[R]
+
+	// HandleIf specifies that a failure has occurred if the predicate matches the execution result or error.
+	HandleIf(predicate func(R, error) bool) ClassifierBuilder[R]
+
+	// Build returns a new Classifier using the builder's configuration.
+	Build() failsafe.Classifier[R]
+}
+
+type config[R any] struct {
+	*policy.BaseFailurePolicy[R]
+}
+
+var _ ClassifierBuilder[any] = &config[any]{}
+
+// Builder returns a ClassifierBuilder for execution result type R.
+func Builder[R any]() ClassifierBuilder[R] {
+	return &config[R]{
+		BaseFailurePolicy: &policy.BaseFailurePolicy[R]{},
+	}
+}
+
+func (c *config[R]) HandleErrors(errs ...error) ClassifierBuilder[R] {
+	c.BaseFailurePolicy.HandleErrors(errs...)
+	return c
+}
+
+func (c *config[R]) HandleErrorTypes(errs ...any) ClassifierBuilder[R] {
+	c.BaseFailurePolicy.HandleErrorTypes(errs...)
+	return c
+}
+
+func (c *config[R]) HandleResult(result R) ClassifierBuilder[R] {
+	c.BaseFailurePolicy.HandleResult(result)
+	return c
+}
+
+func (c *config[R]) HandleIf(predicate func(R, error) bool) ClassifierBuilder[R] {
+	c.BaseFailurePolicy.HandleIf(predicate)
+	return c
+}
+
+func (c *config[R]) Build() failsafe.Classifier[R] {
+	bfpCopy := *c.BaseFailurePolicy
+	return &bfpCopy
+}
@@ -0,0 +1,63 @@
+package classifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+func TestClassifier(t *testing.T) {
+	classifier := Builder[bool]().
+		HandleErrors(testutil.ErrInvalidState).
+		HandleResult(false).
+		Build()
+
+	assert.False(t, classifier.IsFailure(true, nil))
+	assert.True(t, classifier.IsFailure(false, nil))
+	assert.True(t, classifier.IsFailure(true, testutil.ErrInvalidState))
+	assert.False(t, classifier.IsFailure(true, testutil.ErrConnecting))
+}
+
+func TestClassifierWithDefaults(t *testing.T) {
+	classifier := Builder[bool]().HandleResult(false).Build()
+
+	assert.False(t, classifier.IsFailure(true, nil))
+	assert.True(t, classifier.IsFailure(false, nil))
+	assert.True(t, classifier.IsFailure(true, errors.New("test")))
+}
+
+// Asserts that a Classifier shared across policies is applied consistently by each of them.
+func TestSharedClassifier(t *testing.T) {
+	classifier := Builder[string]().HandleErrors(testutil.ErrInvalidState).Build()
+	rp := retrypolicy.Builder[string]().HandleWith(classifier).WithMaxRetries(2).Build()
+	cb := circuitbreaker.Builder[string]().HandleWith(classifier).WithFailureThreshold(5).Build()
+	fb := fallback.BuilderWithResult("fallback").HandleWith(classifier).Build()
+
+	// Unhandled errors are not retried, recorded or fallen back from
+	attempts := 0
+	_, err := failsafe.Get(func() (string, error) {
+		attempts++
+		return "", testutil.ErrConnecting
+	}, fb, rp, cb)
+	assert.ErrorIs(t, err, testutil.ErrConnecting)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, uint(0), cb.Metrics().Failures())
+
+	// Handled errors are retried, recorded and fallen back from
+	attempts = 0
+	result, err := failsafe.Get(func() (string, error) {
+		attempts++
+		return "", testutil.ErrInvalidState
+	}, fb, rp, cb)
+	assert.Equal(t, "fallback", result)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, uint(3), cb.Metrics().Failures())
+}
@@ -0,0 +1,2 @@
+// Package classifier provides a Classifier that can be shared across failure policies.
+package classifier
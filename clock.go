@@ -0,0 +1,136 @@
+package failsafe
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time. A Clock can be configured on an Executor to control the time that executions observe,
+// such as when testing time based behavior.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// Timer is a func call that was scheduled by a TimerClock.
+type Timer interface {
+	// Stop prevents the func from being called, returning false if the func was already called or stopped.
+	Stop() bool
+}
+
+// TimerClock is a Clock that can also schedule funcs to be called after a delay. Time based policies, such as
+// RetryPolicy, RateLimiter, Timeout, and HedgePolicy, accept a TimerClock via WithClock, which they use for their
+// delays, waits, and time limits. This allows policy behavior to be tested deterministically with a FakeClock rather
+// than by sleeping.
+type TimerClock interface {
+	Clock
+
+	// AfterFunc calls the fn once the delay has elapsed, returning a Timer that can be used to stop the call.
+	AfterFunc(delay time.Duration, fn func()) Timer
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(delay time.Duration, fn func()) Timer {
+	return time.AfterFunc(delay, fn)
+}
+
+// SystemClock returns a TimerClock that uses the system time and timers.
+func SystemClock() TimerClock {
+	return systemClock{}
+}
+
+// FakeClock is a TimerClock whose time only changes when it's advanced, which can be used to test time based policy
+// behavior without sleeping. Funcs that are scheduled via AfterFunc are called when the clock is advanced to or past
+// their scheduled time, in the order of their scheduled times.
+//
+// This type is concurrency safe.
+type FakeClock struct {
+	mtx sync.Mutex
+	// Guarded by mtx
+	now time.Time
+	// Guarded by mtx
+	timers []*fakeTimer
+}
+
+var _ TimerClock = &FakeClock{}
+
+// NewFakeClock returns a new FakeClock whose current time is now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules the fn to be called when the clock is advanced by the delay. If the delay is not positive, the fn
+// is called right away in a new goroutine.
+func (c *FakeClock) AfterFunc(delay time.Duration, fn func()) Timer {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	timer := &fakeTimer{clock: c, when: c.now.Add(delay), fn: fn}
+	if delay <= 0 {
+		go fn()
+		return timer
+	}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the clock forward by the duration, calling any funcs that become due before returning.
+func (c *FakeClock) Advance(duration time.Duration) {
+	c.mtx.Lock()
+	c.now = c.now.Add(duration)
+	var due []*fakeTimer
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if !timer.when.After(c.now) {
+			due = append(due, timer)
+		} else {
+			pending = append(pending, timer)
+		}
+	}
+	c.timers = pending
+	c.mtx.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].when.Before(due[j].when)
+	})
+	for _, timer := range due {
+		timer.fn()
+	}
+}
+
+// PendingTimers returns the number of funcs that are scheduled and have not been called or stopped. This can be used to
+// wait for a policy to begin a delay before advancing the clock.
+func (c *FakeClock) PendingTimers() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return len(c.timers)
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	fn    func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mtx.Lock()
+	defer t.clock.mtx.Unlock()
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package failsafe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// Asserts that a FakeClock only calls funcs once it's advanced past their time, in order of their time.
+func TestFakeClock(t *testing.T) {
+	// Given
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := failsafe.NewFakeClock(start)
+	var calls []int
+	clock.AfterFunc(2*time.Second, func() { calls = append(calls, 2) })
+	clock.AfterFunc(time.Second, func() { calls = append(calls, 1) })
+	stopped := clock.AfterFunc(time.Second, func() { calls = append(calls, 3) })
+
+	// When / Then
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+	assert.Equal(t, 2, clock.PendingTimers())
+	clock.Advance(500 * time.Millisecond)
+	assert.Empty(t, calls)
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, []int{1, 2}, calls)
+	assert.Equal(t, start.Add(2500*time.Millisecond), clock.Now())
+	assert.Equal(t, 0, clock.PendingTimers())
+}
@@ -0,0 +1,157 @@
+package collapser
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// Collapser is a policy that collapses concurrent executions that share a key into a single execution, whose result is
+// shared with all of them. Optionally, a completed result can continue to be shared with executions for the same key
+// during a dedupe window. Since results are shared, they should not be modified by callers.
+//
+// Executions that wait on another execution's result can be canceled without affecting it. If the execution that is
+// performing the work is canceled, waiting executions will perform the work themselves rather than share its
+// cancellation.
+//
+// R is the execution result type. This type is concurrency safe.
+type Collapser[R any] interface {
+	failsafe.Policy[R]
+
+	// InFlight returns the number of keys with an execution in flight.
+	InFlight() int
+}
+
+// CollapsedEvent indicates that executions were collapsed into another execution, which is done.
+type CollapsedEvent[R any] struct {
+	failsafe.ExecutionDoneEvent[R]
+
+	// Key is the key that the executions shared.
+	Key string
+
+	// Collapsed is the number of executions, not including the execution that performed the work, that shared the result.
+	Collapsed int
+}
+
+// CollapserBuilder builds Collapser instances.
+//
+// R is the execution result type. This type is not concurrency safe.
+type CollapserBuilder[R any] interface {
+	failsafe.NamedPolicyBuilder[CollapserBuilder[R]]
+
+	// WithDedupeWindow configures how long a completed execution's result will continue to be shared with executions for
+	// the same key. By default, results are only shared with concurrent executions.
+	WithDedupeWindow(dedupeWindow time.Duration) CollapserBuilder[R]
+
+	// OnCollapsed registers the listener to be called when an execution whose result was shared with other executions is
+	// done.
+	OnCollapsed(listener func(event CollapsedEvent[R])) CollapserBuilder[R]
+
+	// Build returns a new Collapser using the builder's configuration.
+	Build() Collapser[R]
+}
+
+type config[R any] struct {
+	name         string
+	keyFunc      func(ctx context.Context) string
+	dedupeWindow time.Duration
+	onCollapsed  func(CollapsedEvent[R])
+}
+
+var _ CollapserBuilder[any] = &config[any]{}
+
+// call is an in flight or recently completed execution for a key.
+type call[R any] struct {
+	done chan struct{}
+	// Guarded by the collapser's mtx
+	waiters int
+	// Set before done is closed
+	result   *common.PolicyResult[R]
+	canceled bool
+}
+
+type collapser[R any] struct {
+	*config[R]
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	calls map[string]*call[R]
+}
+
+// With returns a new Collapser that collapses concurrent executions for which the keyFunc returns the same key. The
+// keyFunc is called with each execution's Context. Executions for which the keyFunc returns an empty key are not
+// collapsed.
+func With[R any](keyFunc func(ctx context.Context) string) Collapser[R] {
+	return Builder[R](keyFunc).Build()
+}
+
+// Builder returns a CollapserBuilder for execution result type R that builds Collapsers which collapse concurrent
+// executions for which the keyFunc returns the same key. The keyFunc is called with each execution's Context.
+// Executions for which the keyFunc returns an empty key are not collapsed.
+func Builder[R any](keyFunc func(ctx context.Context) string) CollapserBuilder[R] {
+	return &config[R]{
+		keyFunc: keyFunc,
+	}
+}
+
+func (c *config[R]) WithDedupeWindow(dedupeWindow time.Duration) CollapserBuilder[R] {
+	c.dedupeWindow = dedupeWindow
+	return c
+}
+
+func (c *config[R]) OnCollapsed(listener func(event CollapsedEvent[R])) CollapserBuilder[R] {
+	c.onCollapsed = listener
+	return c
+}
+
+func (c *config[R]) WithName(name string) CollapserBuilder[R] {
+	c.name = name
+	return c
+}
+
+func (c *config[R]) Build() Collapser[R] {
+	cCopy := *c
+	return &collapser[R]{
+		config: &cCopy,
+		calls:  make(map[string]*call[R]),
+	}
+}
+
+func (c *collapser[R]) InFlight() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	inFlight := 0
+	for _, call := range c.calls {
+		if call.result == nil {
+			inFlight++
+		}
+	}
+	return inFlight
+}
+
+func (c *collapser[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.CollapserKind
+}
+
+func (c *collapser[R]) PolicyName() string {
+	return c.name
+}
+
+func (c *collapser[R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"dedupeWindow": c.dedupeWindow,
+	}
+}
+
+func (c *collapser[R]) ToExecutor(_ R) any {
+	e := &executor[R]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		collapser:    c,
+	}
+	e.Executor = e
+	return e
+}
@@ -0,0 +1,110 @@
+package collapser
+
+import (
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// executor is a policy.Executor that handles executions according to a Collapser.
+type executor[R any] struct {
+	*policy.BaseExecutor[R]
+	*collapser[R]
+}
+
+var _ policy.Executor[any] = &executor[any]{}
+
+func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+		key := e.keyFunc(exec.Context())
+		if key == "" {
+			return innerFn(exec)
+		}
+
+		for {
+			e.mtx.Lock()
+			c, ok := e.calls[key]
+			if !ok {
+				// Perform the execution and share its result
+				c = &call[R]{done: make(chan struct{})}
+				e.calls[key] = c
+				e.mtx.Unlock()
+				return e.execute(execInternal, key, c, innerFn)
+			}
+			if c.result != nil {
+				// Share a result from the dedupe window
+				e.mtx.Unlock()
+				return copyResult(c.result)
+			}
+			c.waiters++
+			e.mtx.Unlock()
+
+			// Wait for the in flight execution
+			select {
+			case <-c.done:
+				if !c.canceled {
+					return copyResult(c.result)
+				}
+				// Perform the execution again since the in flight execution was canceled
+			case <-exec.Canceled():
+				e.mtx.Lock()
+				c.waiters--
+				e.mtx.Unlock()
+				if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled && cancelResult != nil {
+					return cancelResult
+				}
+				return &common.PolicyResult[R]{Error: exec.Context().Err(), Done: true}
+			}
+		}
+	}
+}
+
+func (e *executor[R]) execute(exec policy.ExecutionInternal[R], key string, c *call[R], innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) (result *common.PolicyResult[R]) {
+	// Complete the call even if the innerFn panics, so that waiting executions don't block
+	defer e.completeCall(exec, key, c, &result)
+	return innerFn(exec)
+}
+
+// completeCall shares the result with waiting executions. A nil result indicates that the innerFn panicked, in which
+// case the call is treated as canceled so that waiting executions perform the execution themselves.
+func (e *executor[R]) completeCall(exec policy.ExecutionInternal[R], key string, c *call[R], result **common.PolicyResult[R]) {
+	canceled := *result == nil || exec.IsCanceled()
+
+	e.mtx.Lock()
+	c.result = *result
+	c.canceled = canceled
+	waiters := c.waiters
+	if canceled || e.dedupeWindow <= 0 {
+		delete(e.calls, key)
+	} else {
+		time.AfterFunc(e.dedupeWindow, func() {
+			e.mtx.Lock()
+			defer e.mtx.Unlock()
+			if e.calls[key] == c {
+				delete(e.calls, key)
+			}
+		})
+	}
+	e.mtx.Unlock()
+	close(c.done)
+
+	if waiters > 0 && *result != nil && e.onCollapsed != nil {
+		e.onCollapsed(CollapsedEvent[R]{
+			ExecutionDoneEvent: failsafe.ExecutionDoneEvent[R]{
+				ExecutionInfo: exec,
+				Result:        (*result).Result,
+				Error:         (*result).Error,
+			},
+			Key:       key,
+			Collapsed: waiters,
+		})
+	}
+}
+
+func copyResult[R any](result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	c := *result
+	return &c
+}
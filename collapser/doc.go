@@ -0,0 +1,2 @@
+// Package collapser provides a Collapser policy.
+package collapser
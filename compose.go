@@ -0,0 +1,297 @@
+package failsafe
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PolicyKind identifies the kind of a Policy, which is used to validate policy composition order.
+type PolicyKind int
+
+const (
+	// UnknownKind is the kind of policy that does not report a PolicyKind. Unknown policies are not validated.
+	UnknownKind PolicyKind = iota
+	FallbackKind
+	CacheKind
+	RetryKind
+	HedgeKind
+	CircuitBreakerKind
+	RateLimiterKind
+	BulkheadKind
+	TimeoutKind
+	CollapserKind
+	BatcherKind
+	// BudgetKind is the kind of a Budget, which can be composed at any layer and is not validated.
+	BudgetKind
+)
+
+func (k PolicyKind) String() string {
+	switch k {
+	case FallbackKind:
+		return "Fallback"
+	case CacheKind:
+		return "CachePolicy"
+	case RetryKind:
+		return "RetryPolicy"
+	case HedgeKind:
+		return "HedgePolicy"
+	case CircuitBreakerKind:
+		return "CircuitBreaker"
+	case RateLimiterKind:
+		return "RateLimiter"
+	case BulkheadKind:
+		return "Bulkhead"
+	case TimeoutKind:
+		return "Timeout"
+	case CollapserKind:
+		return "Collapser"
+	case BatcherKind:
+		return "Batcher"
+	case BudgetKind:
+		return "Budget"
+	default:
+		return "Unknown"
+	}
+}
+
+// rank returns the position of the kind in a typical composition, from outermost to innermost. Kinds with the same rank
+// can be composed in any order.
+func (k PolicyKind) rank() int {
+	switch k {
+	case FallbackKind:
+		return 0
+	case CacheKind, CollapserKind, BatcherKind:
+		return 1
+	case RetryKind, HedgeKind:
+		return 2
+	case CircuitBreakerKind, RateLimiterKind:
+		return 3
+	case BulkheadKind:
+		return 4
+	case TimeoutKind:
+		return 5
+	default:
+		return -1
+	}
+}
+
+// KindOf returns the PolicyKind of the policy, else UnknownKind if the policy does not report a kind.
+func KindOf[R any](policy Policy[R]) PolicyKind {
+	if kp, ok := policy.(interface{ PolicyKind() PolicyKind }); ok {
+		return kp.PolicyKind()
+	}
+	return UnknownKind
+}
+
+// NameOf returns the name of the policy, else an empty string if the policy is not named. Policies report their name by
+// implementing a PolicyName() string method.
+func NameOf[R any](policy Policy[R]) string {
+	if np, ok := policy.(interface{ PolicyName() string }); ok {
+		return np.PolicyName()
+	}
+	return ""
+}
+
+// ConfigOf returns a summary of the policy's configuration, else nil if the policy does not describe its configuration.
+// Policies describe their configuration by implementing a PolicyConfig() map[string]any method.
+func ConfigOf[R any](policy Policy[R]) map[string]any {
+	if cp, ok := policy.(interface{ PolicyConfig() map[string]any }); ok {
+		return cp.PolicyConfig()
+	}
+	return nil
+}
+
+// MetricKind indicates how the value of a Metric changes over time.
+type MetricKind int
+
+const (
+	// GaugeMetric indicates a metric whose value can go up or down, such as a circuit breaker's state.
+	GaugeMetric MetricKind = iota
+
+	// CounterMetric indicates a metric whose value only increases, such as the number of retries a retry policy has
+	// performed.
+	CounterMetric
+)
+
+func (k MetricKind) String() string {
+	switch k {
+	case GaugeMetric:
+		return "gauge"
+	case CounterMetric:
+		return "counter"
+	default:
+		return "unknown"
+	}
+}
+
+// Metric is a named measurement of a policy.
+type Metric struct {
+	// Name is the name of the metric, such as "failures", which is unique within a policy.
+	Name string
+	// Kind indicates whether the metric is a gauge or counter.
+	Kind MetricKind
+	// Value is the value of the metric when it was read.
+	Value float64
+}
+
+// MetricsProvider is implemented by policies that expose metrics, such as a circuit breaker's state, a bulkhead's
+// permits in use, or a retry policy's retries. A telemetry backend can be wired to any MetricsProvider, or to all the
+// policies in an Executor via Executor.Metrics, with a single adapter.
+type MetricsProvider interface {
+	// PolicyMetrics returns the current metrics for the policy.
+	PolicyMetrics() []Metric
+}
+
+// MetricsOf returns the current metrics for the policy, else nil if the policy does not implement MetricsProvider.
+func MetricsOf[R any](policy Policy[R]) []Metric {
+	if mp, ok := policy.(MetricsProvider); ok {
+		return mp.PolicyMetrics()
+	}
+	return nil
+}
+
+// PolicyMetrics contains the metrics for a policy that an Executor is composed of.
+type PolicyMetrics struct {
+	// Position is the position of the policy in the composition, where 0 is the outermost policy.
+	Position int
+	// Kind is the kind of the policy.
+	Kind PolicyKind
+	// Name is the name of the policy, else an empty string if the policy is not named.
+	Name string
+	// Metrics contains the policy's current metrics.
+	Metrics []Metric
+}
+
+// PolicyInfo describes a policy that an Executor is composed of.
+type PolicyInfo struct {
+	// Position is the position of the policy in the composition, where 0 is the outermost policy.
+	Position int
+	// Kind is the kind of the policy.
+	Kind PolicyKind
+	// Name is the name of the policy, else an empty string if the policy is not named.
+	Name string
+	// Config summarizes the policy's configuration as it was built, such as its thresholds, limits, and delays, else nil
+	// if the policy does not describe its configuration. Listeners and other funcs are not included.
+	Config map[string]any
+	// Policy is the policy.
+	Policy any
+}
+
+// CompositionError is returned when policies are composed in an order that is likely unintended.
+type CompositionError struct {
+	// Outer is the kind of the outer policy.
+	Outer PolicyKind
+	// Inner is the kind of the inner policy.
+	Inner PolicyKind
+}
+
+func (e *CompositionError) Error() string {
+	return fmt.Sprintf("%s composed outside of %s. use ComposeBuilder.Allow or WithExplicitOrder if this is intended", e.Outer, e.Inner)
+}
+
+// PolicyTypeError is returned when a policy does not provide an executor for the result type of the Executor it's
+// composed into. This can occur with custom policies whose executors are built for a different result type. Policies
+// that handle results of type any can be adapted to other result types via policy.Adapt.
+type PolicyTypeError struct {
+	// Policy is the policy with the mismatched type.
+	Policy any
+	// ResultType is the result type of the Executor.
+	ResultType reflect.Type
+}
+
+func (e *PolicyTypeError) Error() string {
+	return fmt.Sprintf("policy %T does not provide an executor for result type %v", e.Policy, e.ResultType)
+}
+
+/*
+ComposeBuilder builds an Executor for a composition of policies, validating that the policies are composed in a typical
+order. From outermost to innermost, a typical composition is:
+
+	Fallback(CachePolicy|Collapser|Batcher(RetryPolicy|HedgePolicy(CircuitBreaker|RateLimiter(Bulkhead(Timeout(fn))))))
+
+Some atypical compositions are intended, such as placing a Timeout outside of a RetryPolicy to limit the overall
+execution time rather than each attempt. These can be permitted via Allow, or validation can be disabled via
+WithExplicitOrder.
+
+R is the execution result type. This type is not concurrency safe.
+*/
+type ComposeBuilder[R any] interface {
+	// With adds the policies to the composition, inside of any previously added policies. Policies are composed around an
+	// execution in the order they're added, with the first policy being the outermost.
+	With(policies ...Policy[R]) ComposeBuilder[R]
+
+	// Allow permits the outer kind of policy to be composed outside of the inner kind, when this would otherwise fail
+	// validation.
+	Allow(outer PolicyKind, inner PolicyKind) ComposeBuilder[R]
+
+	// WithExplicitOrder disables validation, composing policies in exactly the order they're added.
+	WithExplicitOrder() ComposeBuilder[R]
+
+	// Build returns a new Executor for the composed policies, else a *CompositionError if the policies are composed in an
+	// atypical order that was not allowed, or a *PolicyTypeError if a policy does not provide an executor for R.
+	Build() (Executor[R], error)
+}
+
+type composeConfig[R any] struct {
+	policies      []Policy[R]
+	allowed       map[[2]PolicyKind]struct{}
+	explicitOrder bool
+}
+
+var _ ComposeBuilder[any] = &composeConfig[any]{}
+
+// Compose returns a ComposeBuilder for execution result type R.
+func Compose[R any]() ComposeBuilder[R] {
+	return &composeConfig[R]{}
+}
+
+func (c *composeConfig[R]) With(policies ...Policy[R]) ComposeBuilder[R] {
+	c.policies = append(c.policies, policies...)
+	return c
+}
+
+func (c *composeConfig[R]) Allow(outer PolicyKind, inner PolicyKind) ComposeBuilder[R] {
+	if c.allowed == nil {
+		c.allowed = make(map[[2]PolicyKind]struct{})
+	}
+	c.allowed[[2]PolicyKind{outer, inner}] = struct{}{}
+	return c
+}
+
+func (c *composeConfig[R]) WithExplicitOrder() ComposeBuilder[R] {
+	c.explicitOrder = true
+	return c
+}
+
+func (c *composeConfig[R]) Build() (Executor[R], error) {
+	if !c.explicitOrder {
+		if err := c.validate(); err != nil {
+			return nil, err
+		}
+	}
+	e, err := newExecutor(append([]Policy[R](nil), c.policies...))
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// validate returns a CompositionError for the first pair of policies that are composed in an atypical order.
+func (c *composeConfig[R]) validate() error {
+	for i, outerPolicy := range c.policies {
+		outer := KindOf(outerPolicy)
+		if outer.rank() == -1 {
+			continue
+		}
+		for _, innerPolicy := range c.policies[i+1:] {
+			inner := KindOf(innerPolicy)
+			if inner.rank() == -1 || outer.rank() <= inner.rank() {
+				continue
+			}
+			if _, ok := c.allowed[[2]PolicyKind{outer, inner}]; !ok {
+				return &CompositionError{Outer: outer, Inner: inner}
+			}
+		}
+	}
+	return nil
+}
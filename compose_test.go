@@ -0,0 +1,148 @@
+package failsafe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func TestComposeWithTypicalOrder(t *testing.T) {
+	executor, err := failsafe.Compose[string]().
+		With(fallback.WithResult("fallback")).
+		With(retrypolicy.WithDefaults[string](), circuitbreaker.WithDefaults[string]()).
+		With(timeout.With[string](time.Second)).
+		Build()
+
+	assert.NoError(t, err)
+	result, err := executor.Get(func() (string, error) {
+		return "test", nil
+	})
+	assert.Equal(t, "test", result)
+	assert.NoError(t, err)
+}
+
+func TestComposeWithAtypicalOrder(t *testing.T) {
+	to := timeout.With[string](time.Second)
+	rp := retrypolicy.WithDefaults[string]()
+
+	// Without intent
+	executor, err := failsafe.Compose[string]().With(to, rp).Build()
+	assert.Nil(t, executor)
+	assert.Equal(t, &failsafe.CompositionError{Outer: failsafe.TimeoutKind, Inner: failsafe.RetryKind}, err)
+
+	// Allowed
+	executor, err = failsafe.Compose[string]().With(to, rp).Allow(failsafe.TimeoutKind, failsafe.RetryKind).Build()
+	assert.NotNil(t, executor)
+	assert.NoError(t, err)
+
+	// Explicit order
+	executor, err = failsafe.Compose[string]().With(to, rp).WithExplicitOrder().Build()
+	assert.NotNil(t, executor)
+	assert.NoError(t, err)
+}
+
+// mismatchedPolicy is a Policy[string] whose executor is built for results of type any.
+type mismatchedPolicy struct{}
+
+func (p mismatchedPolicy) ToExecutor(_ string) any {
+	return retrypolicy.WithDefaults[any]().ToExecutor(nil)
+}
+
+func TestComposeWithMismatchedType(t *testing.T) {
+	executor, err := failsafe.Compose[string]().With(mismatchedPolicy{}).Build()
+	assert.Nil(t, executor)
+	var typeErr *failsafe.PolicyTypeError
+	assert.ErrorAs(t, err, &typeErr)
+	assert.Equal(t, "policy failsafe_test.mismatchedPolicy does not provide an executor for result type string", err.Error())
+
+	assert.PanicsWithError(t, err.Error(), func() {
+		failsafe.NewExecutor[string](mismatchedPolicy{})
+	})
+}
+
+func TestKindOf(t *testing.T) {
+	assert.Equal(t, failsafe.RetryKind, failsafe.KindOf[any](retrypolicy.WithDefaults[any]()))
+	assert.Equal(t, failsafe.CircuitBreakerKind, failsafe.KindOf[any](circuitbreaker.WithDefaults[any]()))
+}
+
+func TestNameOf(t *testing.T) {
+	assert.Equal(t, "", failsafe.NameOf[any](retrypolicy.WithDefaults[any]()))
+	assert.Equal(t, "payments", failsafe.NameOf[any](circuitbreaker.Builder[any]().WithName("payments").Build()))
+}
+
+func TestExecutorPolicies(t *testing.T) {
+	// Given
+	fb := fallback.WithResult("fallback")
+	rp := retrypolicy.Builder[string]().WithName("retries").WithMaxRetries(5).WithDelay(time.Second).Build()
+	to := timeout.With[string](time.Minute)
+
+	// When
+	policies := failsafe.NewExecutor[string](fb, rp, to).Policies()
+
+	// Then
+	assert.Len(t, policies, 3)
+	assert.Equal(t, failsafe.PolicyInfo{Position: 0, Kind: failsafe.FallbackKind, Policy: fb}, policies[0])
+	assert.Equal(t, 1, policies[1].Position)
+	assert.Equal(t, failsafe.RetryKind, policies[1].Kind)
+	assert.Equal(t, "retries", policies[1].Name)
+	assert.Equal(t, 5, policies[1].Config["maxRetries"])
+	assert.Equal(t, time.Second, policies[1].Config["delay"])
+	assert.Equal(t, failsafe.PolicyInfo{
+		Position: 2,
+		Kind:     failsafe.TimeoutKind,
+		Config:   map[string]any{"timeLimit": time.Minute, "gracePeriod": time.Duration(0)},
+		Policy:   to,
+	}, policies[2])
+}
+
+func TestExecutorMetrics(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[string]().WithMaxRetries(2).Build()
+	cb := circuitbreaker.Builder[string]().WithFailureThreshold(5).Build()
+	to := timeout.With[string](time.Minute)
+	executor := failsafe.NewExecutor[string](rp, cb, to)
+
+	// When
+	_, err := executor.Get(func() (string, error) {
+		return "", testutil.ErrInvalidState
+	})
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, []failsafe.PolicyMetrics{
+		{
+			Position: 0,
+			Kind:     failsafe.RetryKind,
+			Metrics: []failsafe.Metric{
+				{Name: "retries", Kind: failsafe.CounterMetric, Value: 2},
+				{Name: "retriesExceeded", Kind: failsafe.CounterMetric, Value: 1},
+				{Name: "aborts", Kind: failsafe.CounterMetric, Value: 0},
+			},
+		},
+		{
+			Position: 1,
+			Kind:     failsafe.CircuitBreakerKind,
+			Metrics: []failsafe.Metric{
+				{Name: "state", Kind: failsafe.GaugeMetric, Value: float64(circuitbreaker.ClosedState)},
+				{Name: "executions", Kind: failsafe.GaugeMetric, Value: 3},
+				{Name: "failures", Kind: failsafe.GaugeMetric, Value: 3},
+				{Name: "failureRate", Kind: failsafe.GaugeMetric, Value: 100},
+				{Name: "successes", Kind: failsafe.GaugeMetric, Value: 0},
+				{Name: "successRate", Kind: failsafe.GaugeMetric, Value: 0},
+				{Name: "openRejections", Kind: failsafe.CounterMetric, Value: 0},
+				{Name: "halfOpenPermits", Kind: failsafe.CounterMetric, Value: 0},
+				{Name: "halfOpenRejections", Kind: failsafe.CounterMetric, Value: 0},
+				{Name: "opens", Kind: failsafe.CounterMetric, Value: 0},
+			},
+		},
+	}, executor.Metrics())
+	assert.Nil(t, failsafe.MetricsOf[string](to))
+}
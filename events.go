@@ -11,6 +11,15 @@ type ExecutionEvent[R any] struct {
 	ExecutionAttempt[R]
 }
 
+// ExecutionAttemptDoneEvent indicates an execution attempt is done.
+type ExecutionAttemptDoneEvent[R any] struct {
+	ExecutionAttempt[R]
+	// The attempt's result, else the zero value for R
+	Result R
+	// The attempt's error, else nil
+	Error error
+}
+
 // ExecutionScheduledEvent indicates an execution was scheduled.
 type ExecutionScheduledEvent[R any] struct {
 	ExecutionAttempt[R]
@@ -27,6 +36,22 @@ type ExecutionDoneEvent[R any] struct {
 	Error error
 }
 
+// UsageEvent reports the resources that a completed execution used. Usage can be attributed to a user or tenant via the
+// ExecutionInfo Tags, such as tags that are carried by the execution's context via WithTags.
+type UsageEvent struct {
+	ExecutionInfo
+	// The total time spent waiting for permits, such as from a RateLimiter or Bulkhead.
+	WaitTime time.Duration
+	// The total time spent in policy delays, such as between retries.
+	DelayTime time.Duration
+}
+
+// Recording returns the ExecutionRecording for the execution, else nil if execution recording is not enabled. See
+// Executor.WithExecutionRecording.
+func (e ExecutionDoneEvent[R]) Recording() *ExecutionRecording {
+	return RecordingOf(e.ExecutionInfo)
+}
+
 func newExecutionDoneEvent[R any](info ExecutionInfo, er *common.PolicyResult[R]) ExecutionDoneEvent[R] {
 	return ExecutionDoneEvent[R]{
 		ExecutionInfo: info,
@@ -34,3 +59,14 @@ func newExecutionDoneEvent[R any](info ExecutionInfo, er *common.PolicyResult[R]
 		Error:         er.Error,
 	}
 }
+
+// ListenerOrder configures the order that policy listeners are called in relative to Executor listeners.
+type ListenerOrder int
+
+const (
+	// PolicyListenersFirst calls policy OnSuccess and OnFailure listeners before Executor listeners. This is the default.
+	PolicyListenersFirst ListenerOrder = iota
+
+	// ExecutorListenersFirst calls Executor OnSuccess, OnFailure, and OnDone listeners before policy listeners.
+	ExecutorListenersFirst
+)
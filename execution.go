@@ -2,6 +2,7 @@ package failsafe
 
 import (
 	"context"
+	"log/slog"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -35,6 +36,21 @@ type ExecutionInfo interface {
 
 	// ElapsedTime returns the elapsed time since initial execution attempt began.
 	ElapsedTime() time.Duration
+
+	// Value returns the value that was stored for the key via SetValue, else nil. Values are shared across all attempts of
+	// an execution, including retries and hedges, and with the execution's event listeners.
+	Value(key any) any
+
+	// SetValue stores the value for the key, so that it's visible to all attempts of the execution, including retries and
+	// hedges, and to the execution's event listeners. This can be used to share state, such as tokens or trace info,
+	// across attempts. As with context keys, the key must be comparable and should be of an unexported type to avoid
+	// collisions.
+	SetValue(key any, value any)
+
+	// Tags returns the tags for the execution, which include tags configured via Executor.WithTag and tags carried by the
+	// execution's context via WithTags, else nil if there are none. Tags from the context take precedence. The returned
+	// map must not be modified.
+	Tags() map[string]string
 }
 
 // ExecutionAttempt contains information for an execution attempt.
@@ -86,17 +102,17 @@ func init() {
 
 type execution[R any] struct {
 	// Shared state across instances
-	mtx        *sync.Mutex
-	startTime  time.Time
-	attempts   *atomic.Uint32
-	retries    *atomic.Uint32
-	hedges     *atomic.Uint32
-	executions *atomic.Uint32
+	*sharedState[R]
+	clock     Clock
+	startTime time.Time
+
+	// The fn being executed, which is one of: func() error, func(Execution[R]) error, func() (R, error), or
+	// func(Execution[R]) (R, error). This is stored as an any to avoid allocating a closure that adapts the fn.
+	fn any
 
 	// Partly shared cancellation state
-	ctx            context.Context
-	cancelFunc     context.CancelFunc
-	canceledResult **common.PolicyResult[R]
+	ctx        context.Context
+	cancelFunc context.CancelFunc
 
 	// Per execution state
 	attemptStartTime time.Time
@@ -105,6 +121,62 @@ type execution[R any] struct {
 	lastError        error // The last error that occurred, else nil.
 }
 
+// sharedState is shared across copies of an execution.
+type sharedState[R any] struct {
+	attempts   atomic.Uint32
+	retries    atomic.Uint32
+	hedges     atomic.Uint32
+	executions atomic.Uint32
+	waitTime   atomic.Int64
+	delayTime  atomic.Int64
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	canceledResult *common.PolicyResult[R]
+	// Guarded by mtx. Stored as a slice rather than a map since there are only ever a few policies.
+	executorStates []executorState
+	// Initial storage for executorStates, to avoid an allocation for most executions
+	executorStatesBuf [2]executorState
+	// Whether policy listeners are deferred until the execution is done. Set before the execution begins.
+	deferListeners bool
+	// Guarded by mtx
+	deferredListeners []deferredListener
+	// Guarded by mtx. Whether the deferred listeners were taken, after which listeners are called immediately.
+	listenersTaken bool
+	// Tracks resources for leak detection, else nil. Set before the execution begins.
+	leakTracker *leakTracker
+	// Guarded by mtx. Values that are set by users of the execution.
+	values map[any]any
+	// Logs debug events for the execution, else nil. Set before the execution begins.
+	logger *slog.Logger
+	// Runs async work for the execution, else nil. Set before the execution begins.
+	scheduler Scheduler
+	// Whether panics in async work are propagated rather than recovered. Set before the execution begins.
+	repanic bool
+	// Whether panics in the execution's fn are recovered as a *PanicError. Set before the execution begins.
+	recoverPanics bool
+	// Tags for the execution, else nil. Set before the execution begins.
+	tags map[string]string
+	// Records events for the execution, else nil. Set before the execution begins.
+	executionRecording *ExecutionRecording
+	// Called for each attempt, else nil. Set before the execution begins.
+	onAttemptStart func(ExecutionEvent[R])
+	onAttemptEnd   func(ExecutionAttemptDoneEvent[R])
+}
+
+// deferredListener is a policy listener call that is deferred until the execution is done.
+type deferredListener struct {
+	success  bool
+	err      error
+	attempts int
+	listener func()
+}
+
+type executorState struct {
+	key   any
+	state any
+}
+
 var _ Execution[any] = &execution[any]{}
 var _ ExecutionInfo = &execution[any]{}
 
@@ -141,7 +213,26 @@ func (e *execution[R]) IsHedge() bool {
 }
 
 func (e *execution[R]) ElapsedTime() time.Duration {
-	return time.Since(e.startTime)
+	return e.clock.Now().Sub(e.startTime)
+}
+
+func (e *execution[R]) Value(key any) any {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.values[key]
+}
+
+func (e *execution[R]) Tags() map[string]string {
+	return e.tags
+}
+
+func (e *execution[R]) SetValue(key any, value any) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if e.values == nil {
+		e.values = make(map[any]any)
+	}
+	e.values[key] = value
 }
 
 func (e *execution[R]) LastResult() R {
@@ -164,7 +255,7 @@ func (e *execution[R]) AttemptStartTime() time.Time {
 }
 
 func (e *execution[_]) ElapsedAttemptTime() time.Duration {
-	return time.Since(e.attemptStartTime)
+	return e.clock.Now().Sub(e.attemptStartTime)
 }
 
 func (e *execution[_]) IsCanceled() bool {
@@ -200,8 +291,8 @@ func (e *execution[R]) InitializeRetry() *common.PolicyResult[R] {
 	if e.attempts.Add(1) > 1 {
 		e.retries.Add(1)
 	}
-	e.attemptStartTime = time.Now()
-	*e.canceledResult = nil
+	e.attemptStartTime = e.clock.Now()
+	e.canceledResult = nil
 	return nil
 }
 
@@ -212,7 +303,7 @@ func (e *execution[R]) Cancel(result *common.PolicyResult[R]) {
 		return
 	}
 
-	*e.canceledResult = result
+	e.canceledResult = result
 	if result != nil {
 		e.lastResult = result.Result
 		e.lastError = result.Error
@@ -222,6 +313,33 @@ func (e *execution[R]) Cancel(result *common.PolicyResult[R]) {
 	}
 }
 
+func (e *execution[R]) CallListener(success bool, result *common.PolicyResult[R], listener func()) {
+	if e.deferListeners {
+		e.mtx.Lock()
+		if !e.listenersTaken {
+			e.deferredListeners = append(e.deferredListeners, deferredListener{
+				success:  success,
+				err:      result.Error,
+				attempts: e.Attempts(),
+				listener: listener,
+			})
+			e.mtx.Unlock()
+			return
+		}
+		e.mtx.Unlock()
+	}
+	listener()
+}
+
+// takeDeferredListeners returns the deferred listeners. Any listeners that are called afterwards, such as by hedges that
+// are still running, are called immediately.
+func (e *execution[R]) takeDeferredListeners() []deferredListener {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.listenersTaken = true
+	return e.deferredListeners
+}
+
 func (e *execution[R]) IsCanceledWithResult() (bool, *common.PolicyResult[R]) {
 	e.mtx.Lock()
 	defer e.mtx.Unlock()
@@ -231,17 +349,71 @@ func (e *execution[R]) IsCanceledWithResult() (bool, *common.PolicyResult[R]) {
 // isCanceledWithResult must be locked externally
 func (e *execution[R]) isCanceledWithResult() (bool, *common.PolicyResult[R]) {
 	if e.ctx.Err() != nil {
-		if *e.canceledResult == nil {
+		if e.canceledResult == nil {
 			return true, &common.PolicyResult[R]{
 				Error: e.ctx.Err(),
 				Done:  true,
 			}
 		}
-		return true, *e.canceledResult
+		return true, e.canceledResult
 	}
 	return false, nil
 }
 
+func (e *execution[R]) RecordWaitTime(waitTime time.Duration) {
+	e.waitTime.Add(int64(waitTime))
+}
+
+func (e *execution[R]) RecordDelayTime(delayTime time.Duration) {
+	e.delayTime.Add(int64(delayTime))
+}
+
+func (e *execution[R]) TrackResource(kind ResourceKind) func() {
+	if e.leakTracker == nil {
+		return noopRelease
+	}
+	return e.leakTracker.track(kind)
+}
+
+func (e *execution[R]) Schedule(fn func() *common.PolicyResult[R], resultFn func(*common.PolicyResult[R])) bool {
+	run := func() {
+		// Handle the result outside of panic recovery, so that a panic in the resultFn is not recovered as the result
+		resultFn(performRecovered(e.repanic, fn))
+	}
+	if e.scheduler == nil {
+		go run()
+		return true
+	}
+	return e.scheduler.Schedule(run)
+}
+
+func (e *execution[R]) recording() *ExecutionRecording {
+	return e.executionRecording
+}
+
+func (e *execution[R]) Logger() *slog.Logger {
+	return e.logger
+}
+
+func (e *execution[R]) ExecutorState(key any, newStateFn func() any) any {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	for _, es := range e.executorStates {
+		if es.key == key {
+			return es.state
+		}
+	}
+	if newStateFn == nil {
+		return nil
+	}
+	if e.executorStates == nil {
+		e.executorStates = e.executorStatesBuf[:0]
+	}
+	state := newStateFn()
+	e.executorStates = append(e.executorStates, executorState{key, state})
+	return state
+}
+
 func (e *execution[R]) CopyWithResult(result *common.PolicyResult[R]) Execution[R] {
 	c := e.copy()
 	if result != nil {
@@ -257,15 +429,44 @@ func (e *execution[R]) CopyForCancellable() Execution[R] {
 	return c
 }
 
+func (e *execution[R]) CopyForDeadline(deadline time.Time) Execution[R] {
+	c := e.copy()
+	ctx, cancelFunc := context.WithCancel(c.ctx)
+	c.ctx, c.cancelFunc = &deadlineContext{Context: ctx, deadline: deadline}, cancelFunc
+	return c
+}
+
+func (e *execution[R]) CopyForDetached() Execution[R] {
+	c := e.copy()
+	c.canceledResult = nil
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c.ctx, c.cancelFunc = context.WithCancel(context.WithoutCancel(ctx))
+	return c
+}
+
 func (e *execution[R]) CopyForHedge() Execution[R] {
 	c := e.copy()
 	c.isHedge = true
+	c.attemptStartTime = c.clock.Now()
 	c.attempts.Add(1)
 	c.hedges.Add(1)
 	c.ctx, c.cancelFunc = context.WithCancel(c.ctx)
 	return c
 }
 
+func (e *execution[R]) CopyWithContextValue(key any, value any) Execution[R] {
+	c := e.copy()
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c.ctx = context.WithValue(ctx, key, value)
+	return c
+}
+
 func (e *execution[R]) copy() *execution[R] {
 	e.mtx.Lock()
 	c := *e
@@ -273,27 +474,39 @@ func (e *execution[R]) copy() *execution[R] {
 	return &c
 }
 
+// deadlineContext is a context that reports a deadline, so that clients can see the remaining time for an execution, but
+// that is not canceled when the deadline passes. This leaves cancellation to the policy that set the deadline, such as
+// a Timeout, so that the execution is canceled with the policy's result rather than with context.DeadlineExceeded.
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c *deadlineContext) Deadline() (time.Time, bool) {
+	if parentDeadline, ok := c.Context.Deadline(); ok && parentDeadline.Before(c.deadline) {
+		return parentDeadline, true
+	}
+	return c.deadline, true
+}
+
 func (e *execution[R]) record() {
 	e.executions.Add(1)
 }
 
-func newExecution[R any](ctx context.Context) *execution[R] {
-	attempts := atomic.Uint32{}
-	retries := atomic.Uint32{}
-	hedges := atomic.Uint32{}
-	executions := atomic.Uint32{}
-	attempts.Add(1)
-	var canceledResult *common.PolicyResult[R]
-	now := time.Now()
-	return &execution[R]{
+func newExecution[R any](ctx context.Context, clock Clock) *execution[R] {
+	// Allocate the execution and its shared state together
+	e := &struct {
+		execution[R]
+		sharedState[R]
+	}{}
+	now := clock.Now()
+	e.execution = execution[R]{
+		sharedState:      &e.sharedState,
 		ctx:              ctx,
-		mtx:              &sync.Mutex{},
-		attempts:         &attempts,
-		retries:          &retries,
-		hedges:           &hedges,
-		executions:       &executions,
-		canceledResult:   &canceledResult,
+		clock:            clock,
 		attemptStartTime: now,
 		startTime:        now,
 	}
+	e.attempts.Add(1)
+	return &e.execution
 }
@@ -2,6 +2,12 @@ package failsafe
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime/debug"
+	"time"
 
 	"github.com/failsafe-go/failsafe-go/common"
 )
@@ -62,9 +68,116 @@ type Executor[R any] interface {
 	// Execution.Canceled or Execution.IsCanceled.
 	WithContext(ctx context.Context) Executor[R]
 
+	// WithClock returns a new copy of the Executor with the clock configured. The clock is used to track the start and
+	// elapsed times of executions. By default, SystemClock is used.
+	WithClock(clock Clock) Executor[R]
+
+	// WithFailFastOnDone returns a new copy of the Executor with failFast configured. When failFast is true, executions
+	// whose context is already done when they start return immediately with an error that matches both ErrContextDone
+	// and the context's error, without calling any policies, event listeners, or the execution's fn. When failFast is
+	// false, which is the default, such executions flow through the policies and listeners as usual.
+	WithFailFastOnDone(failFast bool) Executor[R]
+
+	// WithMiddleware returns a new copy of the Executor with the middleware configured. The middleware wraps the
+	// innermost handling of each execution attempt, inside of all policies, and is called for every attempt, including
+	// retries and hedges. This can be used for cross-cutting concerns such as logging, metrics, or refreshing credentials,
+	// without implementing a Policy. The middleware should call next with the exec it receives. When middleware is
+	// configured more than once, the first middleware is the outermost.
+	WithMiddleware(middleware func(next ExecutionHandler[R]) ExecutionHandler[R]) Executor[R]
+
+	// WithRepanicAsync returns a new copy of the Executor with repanic configured. By default, a panic in an async
+	// execution is recovered and provided as a *PanicError by the ExecutionResult, and a panic in an attempt that a
+	// policy runs in another goroutine, such as a hedge attempt, is recovered as the attempt's *PanicError result. When
+	// repanic is true, the panic is instead propagated on the goroutine, which will crash the process unless it's
+	// recovered elsewhere.
+	WithRepanicAsync(repanic bool) Executor[R]
+
+	// WithPanicRecovery returns a new copy of the Executor with panic recovery configured. When recoverPanics is true, a
+	// panic in an execution's fn is recovered and returned as a *PanicError, which carries the panic's stack. Since the
+	// *PanicError is returned like any other error, policies handle it as a failure, so that a RetryPolicy can retry it,
+	// a CircuitBreaker can record it, and a Fallback can handle it. By default, panics propagate through policies and
+	// skip their handling.
+	WithPanicRecovery(recoverPanics bool) Executor[R]
+
+	// WithTag returns a new copy of the Executor with the tag configured, which is provided to event listeners via
+	// ExecutionInfo.Tags for each execution. Tags can be used to attribute events to an operation when listeners are
+	// shared across Executors. Tags can also be set for individual executions via WithTags, which take precedence.
+	WithTag(key string, value string) Executor[R]
+
+	// WithListenerOrder returns a new copy of the Executor with the order configured for calling policy OnSuccess and
+	// OnFailure listeners relative to the Executor's OnSuccess, OnFailure, and OnDone listeners. By default, policy
+	// listeners are called as each policy handles a result, before the Executor's listeners. With
+	// ExecutorListenersFirst, policy listeners are deferred until the Executor's listeners have been called, and are
+	// then called in the order the policies handled results.
+	WithListenerOrder(order ListenerOrder) Executor[R]
+
+	// WithListenerDedupe returns a new copy of the Executor with dedupe configured. When dedupe is true, a policy
+	// OnSuccess or OnFailure listener is not called for a result when the Executor has a listener of the same type that
+	// is called for the same result, meaning the same error, or an error that wraps it, after the same number of
+	// attempts. This avoids counting an outcome twice when listeners at both levels record it. When dedupe is enabled,
+	// policy listeners are called when the execution is done, according to the ListenerOrder.
+	WithListenerDedupe(dedupe bool) Executor[R]
+
+	// WithLeakDetection returns a new copy of the Executor with leak detection configured, which is a diagnostics mode
+	// intended for tests, such as soak tests, rather than production use. When enabled, the permits, timers, child
+	// contexts, and goroutines that policies create for each execution are tracked along with the stacks where they were
+	// created. After an execution is done, and after the gracePeriod has passed, the listener is called with any resources
+	// that have not been released. A gracePeriod allows attempts that were canceled, such as hedges, time to return.
+	WithLeakDetection(gracePeriod time.Duration, listener func(LeakEvent)) Executor[R]
+
+	// WithLogger returns a new copy of the Executor with the logger configured. When configured, structured debug events
+	// are logged for each execution attempt and when an execution is done, and policies log debug events such as retries,
+	// circuit breaker state changes, timeouts, hedges, fallbacks, and rejections. Each event includes the execution's
+	// attempts, and policy events include the kind of policy. By default, nothing is logged.
+	WithLogger(logger *slog.Logger) Executor[R]
+
+	// WithExecutionRecording returns a new copy of the Executor that records the events of each execution, such as the
+	// timing of attempts, policy decisions such as retries, circuit breaker rejections, and rate limiter rejections, and
+	// the outcome of the execution. The events are the same as those logged via WithLogger, which still receives them if
+	// configured. Up to maxEvents events are kept for each execution, dropping the oldest events first. The recording for
+	// an execution can be retrieved from its events via RecordingOf or ExecutionDoneEvent.Recording:
+	//
+	//	executor.OnFailure(func(e failsafe.ExecutionDoneEvent[R]) {
+	//	  logger.Warn("execution failed", "error", e.Error, "recording", e.Recording())
+	//	})
+	//
+	// Since recording allocates for each execution, it is disabled by default.
+	WithExecutionRecording(maxEvents int) Executor[R]
+
+	// WithScheduler returns a new copy of the Executor with the scheduler configured, such as a WorkerPool, which is used to
+	// run async executions and policy work, such as hedge attempts, on reused goroutines rather than new goroutines. Async
+	// executions that the scheduler rejects fail with ErrSchedulerRejected. Policy work that the scheduler rejects is not
+	// performed: hedges stop hedging, and Fallbacks with a background delay handle the rejection as a failed execution
+	// with ErrSchedulerRejected. Timer callbacks, such as for Timeouts, are run by the
+	// Go runtime. By default, a new goroutine is used for each async execution.
+	WithScheduler(scheduler Scheduler) Executor[R]
+
+	// Policies returns descriptions of the policies that the Executor is composed of, from outermost to innermost, which
+	// can be used to log, validate, or export an Executor's configuration.
+	Policies() []PolicyInfo
+
+	// Metrics returns the current metrics for the Executor's policies that implement MetricsProvider, from outermost to
+	// innermost. Telemetry backends can use Metrics to collect metrics for all of an Executor's policies at once.
+	Metrics() []PolicyMetrics
+
 	// OnDone registers the listener to be called when an execution is done.
 	OnDone(listener func(ExecutionDoneEvent[R])) Executor[R]
 
+	// OnAttemptStart registers the listener to be called before each execution attempt calls the fn, including retries and
+	// hedges. Attempt listeners are called inside of all policies, so they're not called for attempts that a policy
+	// rejects, such as via an open CircuitBreaker. State that is scoped to an attempt, such as a tracing span, can be
+	// stored via the event's SetValue and retrieved in an OnAttemptEnd listener. Listeners for concurrent hedge attempts
+	// may be called concurrently, so attempt scoped values should be keyed by the attempt's number when hedging.
+	OnAttemptStart(listener func(ExecutionEvent[R])) Executor[R]
+
+	// OnAttemptEnd registers the listener to be called with the result of each execution attempt after the fn returns,
+	// including retries and hedges. See OnAttemptStart.
+	OnAttemptEnd(listener func(ExecutionAttemptDoneEvent[R])) Executor[R]
+
+	// OnUsage registers the listener to be called with the resources that an execution used when it's done, including its
+	// elapsed time, attempts, time spent waiting for permits, and time spent in policy delays.
+	OnUsage(listener func(UsageEvent)) Executor[R]
+
 	// OnSuccess registers the listener to be called when an execution is successful. If multiple policies, are configured,
 	// this handler is called when execution is done and all policies succeed. If all policies do not succeed, then the
 	// OnFailure registered listener is called instead.
@@ -96,35 +209,109 @@ type Executor[R any] interface {
 	// Any panic causes the execution to stop immediately without calling any event listeners.
 	GetWithExecution(fn func(exec Execution[R]) (R, error)) (R, error)
 
-	// RunAsync executes the fn in a goroutine until successful or until the configured policies are exceeded.
+	// RunWithContext executes the fn until successful or until the configured policies are exceeded, using the ctx for the
+	// execution. The ctx overrides any context configured via WithContext, allowing a shared Executor to be used with
+	// request scoped contexts.
 	//
 	// Any panic causes the execution to stop immediately without calling any event listeners.
+	RunWithContext(ctx context.Context, fn func() error) error
+
+	// GetWithContext executes the fn until a successful result is returned or the configured policies are exceeded, using
+	// the ctx for the execution. The ctx overrides any context configured via WithContext, allowing a shared Executor to be
+	// used with request scoped contexts.
+	//
+	// Any panic causes the execution to stop immediately without calling any event listeners.
+	GetWithContext(ctx context.Context, fn func() (R, error)) (R, error)
+
+	// RunAsync executes the fn in a goroutine until successful or until the configured policies are exceeded.
+	//
+	// Any panic causes the execution to stop immediately without calling any event listeners, and is provided as a
+	// *PanicError by the ExecutionResult unless WithRepanicAsync is configured.
 	RunAsync(fn func() error) ExecutionResult[R]
 
 	// RunWithExecutionAsync executes the fn in a goroutine until successful or until the configured policies are exceeded,
 	// while providing an Execution to the fn.
 	//
-	// Any panic causes the execution to stop immediately without calling any event listeners.
+	// Any panic causes the execution to stop immediately without calling any event listeners, and is provided as a
+	// *PanicError by the ExecutionResult unless WithRepanicAsync is configured.
 	RunWithExecutionAsync(fn func(exec Execution[R]) error) ExecutionResult[R]
 
 	// GetAsync executes the fn in a goroutine until a successful result is returned or the configured policies are exceeded.
 	//
-	// Any panic causes the execution to stop immediately without calling any event listeners.
+	// Any panic causes the execution to stop immediately without calling any event listeners, and is provided as a
+	// *PanicError by the ExecutionResult unless WithRepanicAsync is configured.
 	GetAsync(fn func() (R, error)) ExecutionResult[R]
 
 	// GetWithExecutionAsync executes the fn in a goroutine until a successful result is returned or the configured policies
 	// are exceeded, while providing an Execution to the fn.
 	//
-	// Any panic causes the execution to stop immediately without calling any event listeners.
+	// Any panic causes the execution to stop immediately without calling any event listeners, and is provided as a
+	// *PanicError by the ExecutionResult unless WithRepanicAsync is configured.
 	GetWithExecutionAsync(fn func(exec Execution[R]) (R, error)) ExecutionResult[R]
+
+	// RunWithContextAsync executes the fn in a goroutine until successful or until the configured policies are exceeded,
+	// using the ctx for the execution. The ctx overrides any context configured via WithContext.
+	//
+	// Any panic causes the execution to stop immediately without calling any event listeners, and is provided as a
+	// *PanicError by the ExecutionResult unless WithRepanicAsync is configured.
+	RunWithContextAsync(ctx context.Context, fn func() error) ExecutionResult[R]
+
+	// GetWithContextAsync executes the fn in a goroutine until a successful result is returned or the configured policies
+	// are exceeded, using the ctx for the execution. The ctx overrides any context configured via WithContext.
+	//
+	// Any panic causes the execution to stop immediately without calling any event listeners, and is provided as a
+	// *PanicError by the ExecutionResult unless WithRepanicAsync is configured.
+	GetWithContextAsync(ctx context.Context, fn func() (R, error)) ExecutionResult[R]
+}
+
+// ExecutionHandler handles an execution attempt, returning its result and error.
+type ExecutionHandler[R any] func(exec Execution[R]) (R, error)
+
+// callDeferredListeners calls the deferred policy listeners, skipping those that duplicate an executor listener for the
+// result if dedupe is configured.
+func (e *executor[R]) callDeferredListeners(deferred []deferredListener, exec *execution[R], er *common.PolicyResult[R]) {
+	for _, d := range deferred {
+		if e.dedupeListeners && d.attempts == exec.Attempts() && errors.Is(er.Error, d.err) {
+			if (d.success && er.SuccessAll && e.onSuccess != nil) || (!d.success && !er.SuccessAll && e.onFailure != nil) {
+				continue
+			}
+		}
+		d.listener()
+	}
 }
 
 type executor[R any] struct {
-	policies  []Policy[R]
-	ctx       context.Context
+	policies   []Policy[R]
+	middleware []func(next ExecutionHandler[R]) ExecutionHandler[R]
+	// The policy executors composed around an execution's fn, which are built once and reused across executions
+	composedFn func(Execution[R]) *common.PolicyResult[R]
+	ctx        context.Context
+	clock      Clock
+	failFast   bool
+	repanic    bool
+	// Whether panics in an execution's fn are recovered as a *PanicError
+	recoverPanics bool
+	// Tags for each execution. Copied on write, since executors share it.
+	tags map[string]string
+	// Configures how policy listeners are called relative to the executor's listeners
+	listenerOrder   ListenerOrder
+	dedupeListeners bool
+	// Configures leak detection, which is enabled when onLeak is not nil
+	leakGracePeriod time.Duration
+	onLeak          func(LeakEvent)
+	// Logs debug events, else nil
+	logger *slog.Logger
+	// The max events to record for each execution, else 0 if recording is disabled
+	maxRecordedEvents int
+	// Runs async work, else nil
+	scheduler Scheduler
 	onDone    func(ExecutionDoneEvent[R])
 	onSuccess func(ExecutionDoneEvent[R])
 	onFailure func(ExecutionDoneEvent[R])
+	onUsage   func(UsageEvent)
+	// Called for each attempt, else nil
+	onAttemptStart func(ExecutionEvent[R])
+	onAttemptEnd   func(ExecutionAttemptDoneEvent[R])
 }
 
 // NewExecutor creates and returns a new Executor for result type R that will handle failures according to the given
@@ -135,11 +322,86 @@ type executor[R any] struct {
 // This creates the following composition when executing a func and handling its result:
 //
 //	Fallback(RetryPolicy(CircuitBreaker(func)))
+//
+// Panics with a *PolicyTypeError if any of the policies do not provide an executor for result type R. Use Compose to
+// get this as an error instead.
 func NewExecutor[R any](policies ...Policy[R]) Executor[R] {
+	e, err := newExecutor(policies)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func newExecutor[R any](policies []Policy[R]) (*executor[R], error) {
+	composedFn, err := compose(policies, nil)
+	if err != nil {
+		return nil, err
+	}
 	return &executor[R]{
-		policies: policies,
-		ctx:      context.Background(),
+		policies:   policies,
+		composedFn: composedFn,
+		ctx:        context.Background(),
+		clock:      SystemClock(),
+	}, nil
+}
+
+// compose returns a func that composes the policy executors from the innermost policy to the outermost, around any
+// middleware and an execution's fn. Returns a *PolicyTypeError if a policy does not provide an executor for R.
+func compose[R any](policies []Policy[R], middleware []func(next ExecutionHandler[R]) ExecutionHandler[R]) (func(Execution[R]) *common.PolicyResult[R], error) {
+	var handler ExecutionHandler[R]
+	if len(middleware) > 0 {
+		handler = func(exec Execution[R]) (R, error) {
+			return callFn(exec.(*execution[R]), exec)
+		}
+		for i := len(middleware) - 1; i >= 0; i-- {
+			handler = middleware[i](handler)
+		}
 	}
+
+	outerFn := func(exec Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(*execution[R])
+		if execInternal.onAttemptStart != nil {
+			execInternal.onAttemptStart(ExecutionEvent[R]{ExecutionAttempt: execInternal})
+		}
+		var result R
+		var err error
+		if execInternal.recoverPanics {
+			result, err = callHandlerRecoveringPanics(handler, execInternal)
+		} else {
+			result, err = callHandler(handler, execInternal)
+		}
+		execInternal.record()
+		if execInternal.logger != nil {
+			execInternal.logger.Debug("attempt completed",
+				"attempts", execInternal.Attempts(),
+				"hedge", execInternal.isHedge,
+				"error", err)
+		}
+		if execInternal.onAttemptEnd != nil {
+			execInternal.onAttemptEnd(ExecutionAttemptDoneEvent[R]{
+				ExecutionAttempt: execInternal,
+				Result:           result,
+				Error:            err,
+			})
+		}
+		return &common.PolicyResult[R]{
+			Result:     result,
+			Error:      err,
+			Done:       true,
+			Success:    true,
+			SuccessAll: true,
+		}
+	}
+
+	for i := len(policies) - 1; i >= 0; i-- {
+		pe, ok := policies[i].ToExecutor(*new(R)).(policyExecutor[R])
+		if !ok {
+			return nil, &PolicyTypeError{Policy: policies[i], ResultType: reflect.TypeOf((*R)(nil)).Elem()}
+		}
+		outerFn = pe.Apply(outerFn)
+	}
+	return outerFn, nil
 }
 
 func (e *executor[R]) WithContext(ctx context.Context) Executor[R] {
@@ -150,11 +412,172 @@ func (e *executor[R]) WithContext(ctx context.Context) Executor[R] {
 	return &c
 }
 
+// callHandler calls the handler, if any, else the execInternal's fn.
+func callHandler[R any](handler ExecutionHandler[R], execInternal *execution[R]) (R, error) {
+	if handler != nil {
+		return handler(execInternal.copy())
+	}
+	return callFn(execInternal, nil)
+}
+
+// callHandlerRecoveringPanics calls the handler, if any, else the execInternal's fn, returning a *PanicError if it panics.
+func callHandlerRecoveringPanics[R any](handler ExecutionHandler[R], execInternal *execution[R]) (result R, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = *new(R), &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return callHandler(handler, execInternal)
+}
+
+// callFn calls the execInternal's fn, providing the exec, else a copy of the execInternal, to fns that accept one.
+func callFn[R any](execInternal *execution[R], exec Execution[R]) (result R, err error) {
+	// Only copy and provide an execution to the user fn if needed
+	switch fn := execInternal.fn.(type) {
+	case func() error:
+		err = fn()
+	case func(Execution[R]) error:
+		if exec == nil {
+			exec = execInternal.copy()
+		}
+		err = fn(exec)
+	case func() (R, error):
+		result, err = fn()
+	case func(Execution[R]) (R, error):
+		if exec == nil {
+			exec = execInternal.copy()
+		}
+		result, err = fn(exec)
+	}
+	return result, err
+}
+
+func (e *executor[R]) WithClock(clock Clock) Executor[R] {
+	c := *e
+	if clock != nil {
+		c.clock = clock
+	}
+	return &c
+}
+
+func (e *executor[R]) WithFailFastOnDone(failFast bool) Executor[R] {
+	c := *e
+	c.failFast = failFast
+	return &c
+}
+
+func (e *executor[R]) WithMiddleware(middleware func(next ExecutionHandler[R]) ExecutionHandler[R]) Executor[R] {
+	c := *e
+	c.middleware = append(e.middleware[:len(e.middleware):len(e.middleware)], middleware)
+	// Policies were already validated when the executor was created
+	c.composedFn, _ = compose(c.policies, c.middleware)
+	return &c
+}
+
+func (e *executor[R]) WithRepanicAsync(repanic bool) Executor[R] {
+	c := *e
+	c.repanic = repanic
+	return &c
+}
+
+func (e *executor[R]) WithPanicRecovery(recoverPanics bool) Executor[R] {
+	c := *e
+	c.recoverPanics = recoverPanics
+	return &c
+}
+
+func (e *executor[R]) WithTag(key string, value string) Executor[R] {
+	c := *e
+	c.tags = mergeTags(e.tags, map[string]string{key: value})
+	return &c
+}
+
+func (e *executor[R]) WithListenerOrder(order ListenerOrder) Executor[R] {
+	c := *e
+	c.listenerOrder = order
+	return &c
+}
+
+func (e *executor[R]) WithListenerDedupe(dedupe bool) Executor[R] {
+	c := *e
+	c.dedupeListeners = dedupe
+	return &c
+}
+
+func (e *executor[R]) WithLeakDetection(gracePeriod time.Duration, listener func(LeakEvent)) Executor[R] {
+	c := *e
+	c.leakGracePeriod = gracePeriod
+	c.onLeak = listener
+	return &c
+}
+
+func (e *executor[R]) WithLogger(logger *slog.Logger) Executor[R] {
+	c := *e
+	c.logger = logger
+	return &c
+}
+
+func (e *executor[R]) WithExecutionRecording(maxEvents int) Executor[R] {
+	c := *e
+	c.maxRecordedEvents = maxEvents
+	return &c
+}
+
+func (e *executor[R]) WithScheduler(scheduler Scheduler) Executor[R] {
+	c := *e
+	c.scheduler = scheduler
+	return &c
+}
+
+func (e *executor[R]) Policies() []PolicyInfo {
+	infos := make([]PolicyInfo, len(e.policies))
+	for i, p := range e.policies {
+		infos[i] = PolicyInfo{
+			Position: i,
+			Kind:     KindOf(p),
+			Name:     NameOf(p),
+			Config:   ConfigOf(p),
+			Policy:   p,
+		}
+	}
+	return infos
+}
+
+func (e *executor[R]) Metrics() []PolicyMetrics {
+	var metrics []PolicyMetrics
+	for i, p := range e.policies {
+		if mp, ok := p.(MetricsProvider); ok {
+			metrics = append(metrics, PolicyMetrics{
+				Position: i,
+				Kind:     KindOf(p),
+				Name:     NameOf(p),
+				Metrics:  mp.PolicyMetrics(),
+			})
+		}
+	}
+	return metrics
+}
+
 func (e *executor[R]) OnDone(listener func(ExecutionDoneEvent[R])) Executor[R] {
 	e.onDone = listener
 	return e
 }
 
+func (e *executor[R]) OnAttemptStart(listener func(ExecutionEvent[R])) Executor[R] {
+	e.onAttemptStart = listener
+	return e
+}
+
+func (e *executor[R]) OnAttemptEnd(listener func(ExecutionAttemptDoneEvent[R])) Executor[R] {
+	e.onAttemptEnd = listener
+	return e
+}
+
+func (e *executor[R]) OnUsage(listener func(UsageEvent)) Executor[R] {
+	e.onUsage = listener
+	return e
+}
+
 func (e *executor[R]) OnSuccess(listener func(ExecutionDoneEvent[R])) Executor[R] {
 	e.onSuccess = listener
 	return e
@@ -166,53 +589,62 @@ func (e *executor[R]) OnFailure(listener func(ExecutionDoneEvent[R])) Executor[R
 }
 
 func (e *executor[R]) Run(fn func() error) error {
-	_, err := e.executeSync(func(_ Execution[R]) (R, error) {
-		return *new(R), fn()
-	}, false)
+	_, err := e.executeSync(e.ctx, fn)
 	return err
 }
 
 func (e *executor[R]) RunWithExecution(fn func(exec Execution[R]) error) error {
-	_, err := e.executeSync(func(exec Execution[R]) (R, error) {
-		return *new(R), fn(exec)
-	}, true)
+	_, err := e.executeSync(e.ctx, fn)
+	return err
+}
+
+func (e *executor[R]) RunWithContext(ctx context.Context, fn func() error) error {
+	_, err := e.executeSync(e.contextOrDefault(ctx), fn)
 	return err
 }
 
 func (e *executor[R]) Get(fn func() (R, error)) (R, error) {
-	return e.executeSync(func(_ Execution[R]) (R, error) {
-		return fn()
-	}, false)
+	return e.executeSync(e.ctx, fn)
 }
 
 func (e *executor[R]) GetWithExecution(fn func(exec Execution[R]) (R, error)) (R, error) {
-	return e.executeSync(func(exec Execution[R]) (R, error) {
-		return fn(exec)
-	}, true)
+	return e.executeSync(e.ctx, fn)
+}
+
+func (e *executor[R]) GetWithContext(ctx context.Context, fn func() (R, error)) (R, error) {
+	return e.executeSync(e.contextOrDefault(ctx), fn)
 }
 
 func (e *executor[R]) RunAsync(fn func() error) ExecutionResult[R] {
-	return e.executeAsync(func(_ Execution[R]) (R, error) {
-		return *new(R), fn()
-	}, false)
+	return e.executeAsync(e.ctx, fn)
 }
 
 func (e *executor[R]) RunWithExecutionAsync(fn func(exec Execution[R]) error) ExecutionResult[R] {
-	return e.executeAsync(func(exec Execution[R]) (R, error) {
-		return *new(R), fn(exec)
-	}, true)
+	return e.executeAsync(e.ctx, fn)
+}
+
+func (e *executor[R]) RunWithContextAsync(ctx context.Context, fn func() error) ExecutionResult[R] {
+	return e.executeAsync(e.contextOrDefault(ctx), fn)
 }
 
 func (e *executor[R]) GetAsync(fn func() (R, error)) ExecutionResult[R] {
-	return e.executeAsync(func(e Execution[R]) (R, error) {
-		return fn()
-	}, false)
+	return e.executeAsync(e.ctx, fn)
 }
 
 func (e *executor[R]) GetWithExecutionAsync(fn func(exec Execution[R]) (R, error)) ExecutionResult[R] {
-	return e.executeAsync(func(exec Execution[R]) (R, error) {
-		return fn(exec)
-	}, true)
+	return e.executeAsync(e.ctx, fn)
+}
+
+func (e *executor[R]) GetWithContextAsync(ctx context.Context, fn func() (R, error)) ExecutionResult[R] {
+	return e.executeAsync(e.contextOrDefault(ctx), fn)
+}
+
+// contextOrDefault returns the ctx, else the Executor's configured context if ctx is nil.
+func (e *executor[R]) contextOrDefault(ctx context.Context) context.Context {
+	if ctx == nil {
+		return e.ctx
+	}
+	return ctx
 }
 
 // This type mirrors part of policy.Executor, which we don't import here to avoid a cycle.
@@ -220,57 +652,109 @@ type policyExecutor[R any] interface {
 	Apply(innerFn func(Execution[R]) *common.PolicyResult[R]) func(Execution[R]) *common.PolicyResult[R]
 }
 
-func (e *executor[R]) executeSync(fn func(exec Execution[R]) (R, error), withExec bool) (R, error) {
-	er := e.execute(fn, newExecution[R](e.ctx), withExec)
+// executeSync executes the fn, which must be one of the func types supported by execution.fn.
+func (e *executor[R]) executeSync(ctx context.Context, fn any) (R, error) {
+	if err := e.failFastErr(ctx); err != nil {
+		return *new(R), err
+	}
+	er := e.execute(fn, newExecution[R](ctx, e.clock))
 	return er.Result, er.Error
 }
 
-func (e *executor[R]) executeAsync(fn func(exec Execution[R]) (R, error), withExec bool) ExecutionResult[R] {
+// executeAsync executes the fn, which must be one of the func types supported by execution.fn.
+func (e *executor[R]) executeAsync(ctx context.Context, fn any) ExecutionResult[R] {
 	var cancelFunc func()
-	ctx := e.ctx
 	if ctx != nil {
 		ctx, cancelFunc = context.WithCancel(ctx)
 	}
-	exec := newExecution[R](ctx)
+	exec := newExecution[R](ctx, e.clock)
 	result := &executionResult[R]{
 		execution:  exec,
 		cancelFunc: cancelFunc,
 		doneChan:   make(chan any, 1),
 	}
-	go func() {
-		result.record(e.execute(fn, exec, withExec))
-	}()
+	if err := e.failFastErr(ctx); err != nil {
+		result.record(&common.PolicyResult[R]{Error: err, Done: true})
+		return result
+	}
+	run := func() {
+		// Record the result outside of panic recovery, so that a panic in a Then fn is not recovered as the result
+		result.record(e.executeRecovered(fn, exec))
+	}
+	if e.scheduler == nil {
+		go run()
+	} else if !e.scheduler.Schedule(run) {
+		result.record(&common.PolicyResult[R]{Error: ErrSchedulerRejected, Done: true})
+	}
 	return result
 }
 
-func (e *executor[R]) execute(fn func(exec Execution[R]) (R, error), outerExec *execution[R], withExec bool) *common.PolicyResult[R] {
-	outerFn := func(exec Execution[R]) *common.PolicyResult[R] {
-		execInternal := exec.(*execution[R])
-		var execForUser Execution[R]
-		if withExec {
-			// Only copy and provide an execution to the user fn if needed
-			execForUser = execInternal.copy()
-		}
-		result, err := fn(execForUser)
-		execInternal.record()
-		return &common.PolicyResult[R]{
-			Result:     result,
-			Error:      err,
-			Done:       true,
-			Success:    true,
-			SuccessAll: true,
-		}
+// executeRecovered executes the fn, recovering any panic as a PanicError result unless repanic is configured.
+func (e *executor[R]) executeRecovered(fn any, exec *execution[R]) *common.PolicyResult[R] {
+	return performRecovered(e.repanic, func() *common.PolicyResult[R] {
+		return e.execute(fn, exec)
+	})
+}
+
+// performRecovered performs the fn, recovering any panic as a PanicError result unless repanic is true.
+func performRecovered[R any](repanic bool, fn func() *common.PolicyResult[R]) (result *common.PolicyResult[R]) {
+	if !repanic {
+		defer func() {
+			if r := recover(); r != nil {
+				result = &common.PolicyResult[R]{
+					Error: &PanicError{Value: r, Stack: debug.Stack()},
+					Done:  true,
+				}
+			}
+		}()
 	}
+	return fn()
+}
 
-	// Compose policy executors from the innermost policy to the outermost
-	for i := len(e.policies) - 1; i >= 0; i-- {
-		pe := e.policies[i].ToExecutor(*new(R)).(policyExecutor[R])
-		outerFn = pe.Apply(outerFn)
+// failFastErr returns an error if failFast is configured and the ctx is already done, else nil.
+func (e *executor[R]) failFastErr(ctx context.Context) error {
+	if e.failFast && ctx != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ErrContextDone, ctx.Err())
+	}
+	return nil
+}
+
+func (e *executor[R]) execute(fn any, outerExec *execution[R]) *common.PolicyResult[R] {
+	outerExec.fn = fn
+	outerExec.deferListeners = e.listenerOrder == ExecutorListenersFirst || e.dedupeListeners
+	if e.onLeak != nil {
+		outerExec.leakTracker = newLeakTracker()
 	}
+	outerExec.logger = e.logger
+	if e.maxRecordedEvents > 0 {
+		outerExec.executionRecording = &ExecutionRecording{maxEvents: e.maxRecordedEvents}
+		outerExec.logger = newRecordingLogger(outerExec.executionRecording, e.logger)
+	}
+	outerExec.scheduler = e.scheduler
+	outerExec.repanic = e.repanic
+	outerExec.recoverPanics = e.recoverPanics
+	outerExec.tags = mergeTags(e.tags, contextTags(outerExec.ctx))
+	outerExec.onAttemptStart = e.onAttemptStart
+	outerExec.onAttemptEnd = e.onAttemptEnd
 
 	// Execute
-	er := outerFn(outerExec)
+	er := e.composedFn(outerExec)
+
+	if outerExec.logger != nil {
+		outerExec.logger.Debug("execution done",
+			"attempts", outerExec.Attempts(),
+			"executions", outerExec.Executions(),
+			"success", er.SuccessAll,
+			"error", er.Error)
+	}
 
+	var deferred []deferredListener
+	if outerExec.deferListeners {
+		deferred = outerExec.takeDeferredListeners()
+	}
+	if e.listenerOrder == PolicyListenersFirst {
+		e.callDeferredListeners(deferred, outerExec, er)
+	}
 	if e.onSuccess != nil && er.SuccessAll {
 		e.onSuccess(newExecutionDoneEvent(outerExec, er))
 	} else if e.onFailure != nil && !er.SuccessAll {
@@ -279,5 +763,34 @@ func (e *executor[R]) execute(fn func(exec Execution[R]) (R, error), outerExec *
 	if e.onDone != nil {
 		e.onDone(newExecutionDoneEvent(outerExec, er))
 	}
+	if e.listenerOrder == ExecutorListenersFirst {
+		e.callDeferredListeners(deferred, outerExec, er)
+	}
+	if e.onUsage != nil {
+		e.onUsage(UsageEvent{
+			ExecutionInfo: outerExec,
+			WaitTime:      time.Duration(outerExec.waitTime.Load()),
+			DelayTime:     time.Duration(outerExec.delayTime.Load()),
+		})
+	}
+	if e.onLeak != nil {
+		if e.leakGracePeriod > 0 {
+			time.AfterFunc(e.leakGracePeriod, func() {
+				e.checkLeaks(outerExec)
+			})
+		} else {
+			e.checkLeaks(outerExec)
+		}
+	}
 	return er
 }
+
+// checkLeaks calls the onLeak listener with any resources that the execution has not released.
+func (e *executor[R]) checkLeaks(exec *execution[R]) {
+	if leaks := exec.leakTracker.leaks(); len(leaks) > 0 {
+		e.onLeak(LeakEvent{
+			ExecutionInfo: exec,
+			Leaks:         leaks,
+		})
+	}
+}
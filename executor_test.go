@@ -1,14 +1,21 @@
 package failsafe_test
 
 import (
+	"bytes"
 	"context"
+	"log/slog"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
 	"github.com/failsafe-go/failsafe-go/fallback"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
 )
 
@@ -84,6 +91,52 @@ func TestWithContext(t *testing.T) {
 	})
 }
 
+// Asserts that a configured clock is used to track execution times.
+func TestWithClock(t *testing.T) {
+	clock := &testClock{now: time.UnixMilli(1000)}
+	var elapsed time.Duration
+	var startTime time.Time
+	failsafe.NewExecutor[any](retrypolicy.WithDefaults[any]()).
+		WithClock(clock).
+		RunWithExecution(func(e failsafe.Execution[any]) error {
+			clock.now = clock.now.Add(100 * time.Millisecond)
+			startTime = e.StartTime()
+			elapsed = e.ElapsedTime()
+			return nil
+		})
+	assert.Equal(t, time.UnixMilli(1000), startTime)
+	assert.Equal(t, 100*time.Millisecond, elapsed)
+}
+
+// Asserts that a per-execution context overrides an Executor's configured context.
+func TestGetWithContext(t *testing.T) {
+	executor := failsafe.NewExecutor[string](retrypolicy.WithDefaults[string]()).WithContext(context.Background())
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fn := func() (string, error) {
+		return "test", nil
+	}
+
+	// Sync
+	result, err := executor.GetWithContext(canceledCtx, fn)
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, context.Canceled)
+	err = executor.RunWithContext(canceledCtx, func() error {
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Async
+	result, err = executor.GetWithContextAsync(canceledCtx, fn).Get()
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The shared executor is unaffected by a per-execution context
+	result, err = executor.Get(fn)
+	assert.Equal(t, "test", result)
+	assert.NoError(t, err)
+}
+
 func TestExecutionWithNoPolicies(t *testing.T) {
 	result, err := failsafe.Get(func() (string, error) {
 		return "test", testutil.ErrInvalidArgument
@@ -92,3 +145,412 @@ func TestExecutionWithNoPolicies(t *testing.T) {
 	assert.Equal(t, "test", result)
 	assert.ErrorIs(t, testutil.ErrInvalidArgument, err)
 }
+
+func BenchmarkGetWithRetryPolicy(b *testing.B) {
+	benchmarkGet(b, retrypolicy.WithDefaults[string]())
+}
+
+func BenchmarkGetWithCircuitBreaker(b *testing.B) {
+	benchmarkGet(b, circuitbreaker.WithDefaults[string]())
+}
+
+func BenchmarkGetWithRateLimiter(b *testing.B) {
+	benchmarkGet(b, ratelimiter.Bursty[string](math.MaxInt32, time.Second))
+}
+
+func BenchmarkGetWithPolicies(b *testing.B) {
+	executor := failsafe.NewExecutor[string](fallback.WithResult("fallback"), retrypolicy.WithDefaults[string]())
+	fn := func() (string, error) {
+		return "test", nil
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = executor.Get(fn)
+	}
+}
+
+func BenchmarkGetWithRetries(b *testing.B) {
+	executor := failsafe.NewExecutor[string](retrypolicy.Builder[string]().WithMaxDuration(time.Minute).Build())
+	fn := func(exec failsafe.Execution[string]) (string, error) {
+		if exec.IsFirstAttempt() {
+			return "", testutil.ErrInvalidArgument
+		}
+		return "test", nil
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = executor.GetWithExecution(fn)
+	}
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func benchmarkGet(b *testing.B, policy failsafe.Policy[string]) {
+	executor := failsafe.NewExecutor[string](policy)
+	fn := func() (string, error) {
+		return "test", nil
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = executor.Get(fn)
+	}
+}
+
+func TestOnUsage(t *testing.T) {
+	rp := retrypolicy.Builder[any]().WithDelay(10 * time.Millisecond).Build()
+	rl := ratelimiter.SmoothBuilder[any](1, 20*time.Millisecond).WithMaxWaitTime(time.Second).Build()
+	var event failsafe.UsageEvent
+	err := failsafe.NewExecutor[any](rp, rl).
+		OnUsage(func(e failsafe.UsageEvent) {
+			event = e
+		}).
+		Run(func() error {
+			return testutil.ErrInvalidState
+		})
+
+	assert.Error(t, err)
+	assert.Equal(t, 3, event.Attempts())
+	assert.Equal(t, 20*time.Millisecond, event.DelayTime)
+	assert.Greater(t, event.WaitTime, time.Duration(0))
+	assert.GreaterOrEqual(t, event.ElapsedTime(), event.DelayTime+event.WaitTime)
+}
+
+func TestOnUsageWithTags(t *testing.T) {
+	var event failsafe.UsageEvent
+	ctx := failsafe.WithTags(context.Background(), map[string]string{"tenant": "a"})
+	err := failsafe.NewExecutor[any](bulkhead.Builder[any](1).Build()).
+		OnUsage(func(e failsafe.UsageEvent) {
+			event = e
+		}).
+		RunWithContext(ctx, func() error {
+			return nil
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a", event.Tags()["tenant"])
+	assert.Equal(t, time.Duration(0), event.WaitTime)
+}
+
+func TestExecutionValues(t *testing.T) {
+	type tokenKey struct{}
+	rp := retrypolicy.Builder[string]().
+		OnRetry(func(e failsafe.ExecutionEvent[string]) {
+			e.SetValue(tokenKey{}, e.Value(tokenKey{}).(int)+1)
+		}).
+		Build()
+	var doneValue any
+	result, err := failsafe.NewExecutor[string](rp).
+		OnDone(func(e failsafe.ExecutionDoneEvent[string]) {
+			doneValue = e.Value(tokenKey{})
+		}).
+		GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+			if exec.IsFirstAttempt() {
+				assert.Nil(t, exec.Value(tokenKey{}))
+				exec.SetValue(tokenKey{}, 1)
+			}
+			if exec.Value(tokenKey{}).(int) < 3 {
+				return "", testutil.ErrInvalidState
+			}
+			return "test", nil
+		})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test", result)
+	assert.Equal(t, 3, doneValue)
+}
+
+func TestWithLogger(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	rp := retrypolicy.Builder[any]().WithMaxRetries(2).Build()
+	cb := circuitbreaker.Builder[any]().WithFailureThreshold(2).Build()
+
+	// When
+	err := failsafe.NewExecutor[any](rp, cb).WithLogger(logger).Run(func() error {
+		return testutil.ErrInvalidState
+	})
+
+	// Then
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	logs := buf.String()
+	assert.Contains(t, logs, `msg="attempt completed" attempts=1 hedge=false error="invalid state"`)
+	assert.Contains(t, logs, `msg="retry scheduled" policy=RetryPolicy attempts=1 delay=0s error="invalid state"`)
+	assert.Contains(t, logs, `msg="circuit breaker state changed" policy=CircuitBreaker oldState=closed newState=open`)
+	assert.Contains(t, logs, `msg="circuit breaker rejected execution" policy=CircuitBreaker attempts=3`)
+	assert.Contains(t, logs, `msg="retries exceeded" policy=RetryPolicy attempts=3`)
+	assert.Contains(t, logs, `msg="execution done" attempts=3 executions=2 success=false`)
+}
+
+func TestWithLoggerForNamedPolicies(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	rp := retrypolicy.Builder[any]().WithName("outer").WithMaxRetries(2).Build()
+	cb := circuitbreaker.Builder[any]().WithName("payments").Build()
+	cb.Open()
+
+	// When
+	err := failsafe.NewExecutor[any](rp, cb).WithLogger(logger).Run(testutil.NoopFn)
+
+	// Then
+	var openErr circuitbreaker.OpenError
+	assert.ErrorAs(t, err, &openErr)
+	assert.Equal(t, "payments", openErr.Name)
+	var exceededErr retrypolicy.ExceededError
+	assert.ErrorAs(t, err, &exceededErr)
+	assert.Equal(t, "outer", exceededErr.Name)
+	logs := buf.String()
+	assert.Contains(t, logs, `msg="circuit breaker rejected execution" name=payments policy=CircuitBreaker attempts=1`)
+	assert.Contains(t, logs, `msg="retries exceeded" name=outer policy=RetryPolicy attempts=3`)
+}
+
+func TestOnAttemptStartAndEnd(t *testing.T) {
+	// Given
+	type spanKey struct{}
+	rp := retrypolicy.Builder[string]().WithMaxRetries(2).Build()
+	var starts []int
+	var ends []failsafe.ExecutionAttemptDoneEvent[string]
+	var spans []int
+	executor := failsafe.NewExecutor[string](rp).
+		OnAttemptStart(func(e failsafe.ExecutionEvent[string]) {
+			starts = append(starts, e.Attempts())
+			e.SetValue(spanKey{}, e.Attempts())
+		}).
+		OnAttemptEnd(func(e failsafe.ExecutionAttemptDoneEvent[string]) {
+			ends = append(ends, e)
+			spans = append(spans, e.Value(spanKey{}).(int))
+		})
+
+	// When
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		if exec.Attempts() < 2 {
+			return "", testutil.ErrInvalidState
+		}
+		return "test", nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "test", result)
+	assert.Equal(t, []int{1, 2}, starts)
+	assert.Equal(t, []int{1, 2}, spans)
+	assert.Len(t, ends, 2)
+	assert.ErrorIs(t, ends[0].Error, testutil.ErrInvalidState)
+	assert.Equal(t, "test", ends[1].Result)
+	assert.NoError(t, ends[1].Error)
+}
+
+func TestWithFailFastOnDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	rp := retrypolicy.Builder[any]().
+		OnRetry(func(failsafe.ExecutionEvent[any]) {
+			calls++
+		}).
+		Build()
+	executor := failsafe.NewExecutor[any](rp).
+		OnDone(func(failsafe.ExecutionDoneEvent[any]) {
+			calls++
+		})
+	fn := func() error {
+		calls++
+		return nil
+	}
+
+	t.Run("should fail fast when context is done", func(t *testing.T) {
+		calls = 0
+		err := executor.WithFailFastOnDone(true).RunWithContext(ctx, fn)
+		assert.ErrorIs(t, err, failsafe.ErrContextDone)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, calls)
+
+		err = executor.WithFailFastOnDone(true).RunWithContextAsync(ctx, fn).Error()
+		assert.ErrorIs(t, err, failsafe.ErrContextDone)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("should flow through policies by default", func(t *testing.T) {
+		calls = 0
+		err := executor.RunWithContext(ctx, fn)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, failsafe.ErrContextDone)
+		assert.Positive(t, calls)
+	})
+
+	t.Run("should execute when context is not done", func(t *testing.T) {
+		calls = 0
+		assert.NoError(t, executor.WithFailFastOnDone(true).Run(fn))
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestWithMiddleware(t *testing.T) {
+	var events []string
+	middleware := func(name string) func(failsafe.ExecutionHandler[string]) failsafe.ExecutionHandler[string] {
+		return func(next failsafe.ExecutionHandler[string]) failsafe.ExecutionHandler[string] {
+			return func(exec failsafe.Execution[string]) (string, error) {
+				events = append(events, name+" before")
+				result, err := next(exec)
+				events = append(events, name+" after")
+				return result, err
+			}
+		}
+	}
+	rp := retrypolicy.Builder[string]().HandleResult("retry").Build()
+	executor := failsafe.NewExecutor[string](rp).
+		WithMiddleware(middleware("outer")).
+		WithMiddleware(middleware("inner"))
+
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		events = append(events, "fn")
+		if exec.Attempts() < 2 {
+			return "retry", nil
+		}
+		return "done", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "done", result)
+	assert.Equal(t, []string{
+		"outer before", "inner before", "fn", "inner after", "outer after",
+		"outer before", "inner before", "fn", "inner after", "outer after",
+	}, events)
+}
+
+func TestAsyncPanic(t *testing.T) {
+	err := failsafe.NewExecutor[any](retrypolicy.WithDefaults[any]()).
+		RunAsync(func() error {
+			panic(testutil.ErrInvalidState)
+		}).
+		Error()
+
+	var panicErr *failsafe.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.ErrorIs(t, err, testutil.ErrInvalidState)
+	assert.Equal(t, testutil.ErrInvalidState, panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "TestAsyncPanic")
+}
+
+func TestWithPanicRecovery(t *testing.T) {
+	// Given
+	var fallbackErr error
+	fb := fallback.WithFunc[any](func(exec failsafe.Execution[any]) (any, error) {
+		fallbackErr = exec.LastError()
+		return "fallback", nil
+	})
+	executor := failsafe.NewExecutor[any](fb, retrypolicy.WithDefaults[any]()).WithPanicRecovery(true)
+
+	// When
+	attempts := 0
+	result, err := executor.Get(func() (any, error) {
+		attempts++
+		panic("test panic")
+	})
+
+	// Then
+	assert.Equal(t, "fallback", result)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	var panicErr *failsafe.PanicError
+	assert.ErrorAs(t, fallbackErr, &panicErr)
+	assert.Equal(t, "test panic", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "TestWithPanicRecovery")
+}
+
+func TestTags(t *testing.T) {
+	// Given
+	var retryTags, doneTags []map[string]string
+	rp := retrypolicy.Builder[any]().
+		OnRetry(func(e failsafe.ExecutionEvent[any]) {
+			retryTags = append(retryTags, e.Tags())
+		}).
+		Build()
+	executor := failsafe.NewExecutor[any](rp).
+		WithTag("service", "orders").
+		WithTag("operation", "unknown").
+		OnDone(func(e failsafe.ExecutionDoneEvent[any]) {
+			doneTags = append(doneTags, e.Tags())
+		})
+	expected := map[string]string{"service": "orders", "operation": "create"}
+
+	// When
+	ctx := failsafe.WithTags(context.Background(), map[string]string{"operation": "create"})
+	attempts := 0
+	executor.RunWithContext(ctx, func() error {
+		if attempts++; attempts == 1 {
+			return testutil.ErrInvalidState
+		}
+		return nil
+	})
+	executor.Run(func() error { return nil })
+
+	// Then
+	assert.Equal(t, []map[string]string{expected}, retryTags)
+	assert.Equal(t, []map[string]string{expected, {"service": "orders", "operation": "unknown"}}, doneTags)
+}
+
+func TestListenerOrderAndDedupe(t *testing.T) {
+	var events []string
+	newExecutor := func() failsafe.Executor[string] {
+		events = nil
+		rp := retrypolicy.Builder[string]().
+			WithMaxRetries(1).
+			OnFailure(func(e failsafe.ExecutionEvent[string]) {
+				events = append(events, "policy failure")
+			}).
+			OnSuccess(func(e failsafe.ExecutionEvent[string]) {
+				events = append(events, "policy success")
+			}).
+			Build()
+		return failsafe.NewExecutor[string](rp).
+			OnFailure(func(e failsafe.ExecutionDoneEvent[string]) {
+				events = append(events, "executor failure")
+			}).
+			OnDone(func(e failsafe.ExecutionDoneEvent[string]) {
+				events = append(events, "executor done")
+			})
+	}
+	failingFn := func() (string, error) {
+		return "", testutil.ErrInvalidState
+	}
+
+	t.Run("should call policy listeners first by default", func(t *testing.T) {
+		_, _ = newExecutor().Get(failingFn)
+		assert.Equal(t, []string{"policy failure", "policy failure", "executor failure", "executor done"}, events)
+	})
+
+	t.Run("should call executor listeners first", func(t *testing.T) {
+		_, _ = newExecutor().WithListenerOrder(failsafe.ExecutorListenersFirst).Get(failingFn)
+		assert.Equal(t, []string{"executor failure", "executor done", "policy failure", "policy failure"}, events)
+	})
+
+	t.Run("should dedupe policy listeners for the final result", func(t *testing.T) {
+		_, _ = newExecutor().WithListenerDedupe(true).Get(failingFn)
+		assert.Equal(t, []string{"policy failure", "executor failure", "executor done"}, events)
+	})
+
+	t.Run("should not dedupe policy listeners for different results", func(t *testing.T) {
+		_, _ = newExecutor().WithListenerDedupe(true).GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+			if exec.IsFirstAttempt() {
+				return "", testutil.ErrInvalidState
+			}
+			return "success", nil
+		})
+		// Policy success is not deduped since the executor has no OnSuccess listener
+		assert.Equal(t, []string{"policy failure", "policy success", "executor done"}, events)
+	})
+}
@@ -0,0 +1,2 @@
+// Package failover provides a Failover policy.
+package failover
@@ -0,0 +1,124 @@
+package failover
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// Failover is a Policy that selects a target, such as an endpoint, replica, or region, for each execution attempt, and
+// exposes it to the attempt via its context. When composed inside a RetryPolicy or HedgePolicy, each retry or hedge
+// attempt can try a different target rather than repeatedly trying the same failed target:
+//
+//	fo := failover.New[*http.Response]([]string{"us-east", "us-west", "eu-west"}, failover.Sequential[string]())
+//	executor := failsafe.NewExecutor[*http.Response](retryPolicy, fo)
+//	response, err := executor.GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+//	  region, _ := failover.Target[string](exec.Context())
+//	  return sendRequest(exec.Context(), region)
+//	})
+//
+// R is the execution result type and T is the target type. This type is concurrency safe.
+type Failover[R any, T any] interface {
+	failsafe.Policy[R]
+
+	// Targets returns the targets that the Failover selects from.
+	Targets() []T
+}
+
+// Selector selects a target for an execution attempt, where attempt is the execution's attempt number, starting at 1.
+// The targets are never empty.
+//
+// Implementations must be concurrency safe.
+type Selector[T any] func(targets []T, attempt int) T
+
+// Sequential returns a Selector that selects the first target for the first attempt of each execution, and each
+// following target for later attempts, wrapping around to the first target. This is useful when the first target is
+// preferred, such as a primary region.
+func Sequential[T any]() Selector[T] {
+	return func(targets []T, attempt int) T {
+		return targets[(attempt-1)%len(targets)]
+	}
+}
+
+// RoundRobin returns a Selector that selects the next target, in order, for each attempt across all executions, which
+// spreads attempts evenly across the targets.
+func RoundRobin[T any]() Selector[T] {
+	var next atomic.Uint64
+	return func(targets []T, _ int) T {
+		return targets[(next.Add(1)-1)%uint64(len(targets))]
+	}
+}
+
+type targetKey struct{}
+
+// Target returns the target that was selected for an execution attempt from the attempt's context, if any.
+//
+// T is the target type.
+func Target[T any](ctx context.Context) (T, bool) {
+	if ctx == nil {
+		return *new(T), false
+	}
+	target, ok := ctx.Value(targetKey{}).(T)
+	return target, ok
+}
+
+type failover[R any, T any] struct {
+	targets  []T
+	selector Selector[T]
+}
+
+var _ Failover[any, any] = &failover[any, any]{}
+
+// New returns a new Failover for execution result type R that selects from the targets via the selector. If the
+// selector is nil, Sequential is used. Panics if targets is empty.
+//
+// R is the execution result type and T is the target type.
+func New[R any, T any](targets []T, selector Selector[T]) Failover[R, T] {
+	if len(targets) == 0 {
+		panic("failover targets must not be empty")
+	}
+	if selector == nil {
+		selector = Sequential[T]()
+	}
+	return &failover[R, T]{
+		targets:  append([]T(nil), targets...),
+		selector: selector,
+	}
+}
+
+func (f *failover[R, T]) Targets() []T {
+	return append([]T(nil), f.targets...)
+}
+
+func (f *failover[R, T]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"targets": len(f.targets),
+	}
+}
+
+func (f *failover[R, T]) ToExecutor(_ R) any {
+	fe := &executor[R, T]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		failover:     f,
+	}
+	fe.Executor = fe
+	return fe
+}
+
+// executor is a policy.Executor that selects targets according to a Failover.
+type executor[R any, T any] struct {
+	*policy.BaseExecutor[R]
+	*failover[R, T]
+}
+
+var _ policy.Executor[any] = &executor[any, any]{}
+
+func (e *executor[R, T]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		target := e.selector(e.targets, exec.Attempts())
+		return innerFn(exec.(policy.ExecutionInternal[R]).CopyWithContextValue(targetKey{}, target))
+	}
+}
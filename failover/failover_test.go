@@ -0,0 +1,58 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+// Asserts that retries are attempted against different targets.
+func TestFailoverWithRetries(t *testing.T) {
+	// Given
+	fo := New[string]([]string{"a", "b", "c"}, nil)
+	executor := failsafe.NewExecutor[string](retrypolicy.WithDefaults[string](), fo)
+
+	// When
+	var targets []string
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		target, _ := Target[string](exec.Context())
+		targets = append(targets, target)
+		if target != "c" {
+			return "", errors.New("unavailable")
+		}
+		return "success from " + target, nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "success from c", result)
+	assert.Equal(t, []string{"a", "b", "c"}, targets)
+}
+
+func TestSequential(t *testing.T) {
+	selector := Sequential[int]()
+	targets := []int{1, 2}
+	assert.Equal(t, 1, selector(targets, 1))
+	assert.Equal(t, 2, selector(targets, 2))
+	assert.Equal(t, 1, selector(targets, 3))
+}
+
+func TestRoundRobin(t *testing.T) {
+	selector := RoundRobin[int]()
+	targets := []int{1, 2, 3}
+	var selected []int
+	for i := 0; i < 4; i++ {
+		selected = append(selected, selector(targets, 1))
+	}
+	assert.Equal(t, []int{1, 2, 3, 1}, selected)
+}
+
+func TestTarget(t *testing.T) {
+	_, ok := Target[string](context.Background())
+	assert.False(t, ok)
+}
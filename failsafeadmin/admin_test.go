@@ -0,0 +1,178 @@
+package failsafeadmin
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func newTestRegistry() (*Registry, circuitbreaker.CircuitBreaker[string]) {
+	cb := circuitbreaker.WithDefaults[string]()
+	rp := retrypolicy.WithDefaults[string]()
+	rl := ratelimiter.Bursty[string](1, time.Hour)
+	registry := NewRegistry()
+	registry.Register("cb", cb)
+	registry.Register("rp", rp)
+	registry.Register("rl", rl)
+	RegisterExecutor[string](registry, "client", rp, cb, timeout.With[string](time.Second))
+	cb.Open()
+	return registry, cb
+}
+
+func TestState(t *testing.T) {
+	registry, _ := newTestRegistry()
+
+	assert.Equal(t, &State{
+		CircuitBreakers: []CircuitBreakerState{{Name: "cb", State: "open"}},
+		Policies: []PolicyState{
+			{Name: "cb", Kind: "CircuitBreaker", Metrics: map[string]float64{
+				"state": 1, "executions": 0, "failures": 0, "failureRate": 0, "successes": 0, "successRate": 0,
+				"openRejections": 0, "halfOpenPermits": 0, "halfOpenRejections": 0, "opens": 1,
+			}},
+			{Name: "rl", Kind: "RateLimiter"},
+			{Name: "rp", Kind: "RetryPolicy", Metrics: map[string]float64{"retries": 0, "retriesExceeded": 0, "aborts": 0}},
+		},
+		Executors: []ExecutorState{{Name: "client", Policies: []string{"RetryPolicy", "CircuitBreaker", "Timeout"}}},
+	}, registry.State())
+}
+
+func TestHandler(t *testing.T) {
+	registry, cb := newTestRegistry()
+	server := httptest.NewServer(NewHandler(registry, WithSetRate(true)))
+	defer server.Close()
+
+	// Get state
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	state := &State{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(state))
+	resp.Body.Close()
+	assert.Equal(t, registry.State(), state)
+
+	// Reset unsupported policy
+	resp, err = http.Post(server.URL+"/circuitbreakers/rp/reset", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Reset unknown policy
+	resp, err = http.Post(server.URL+"/circuitbreakers/unknown/reset", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Reset circuit breaker
+	resp, err = http.Post(server.URL+"/circuitbreakers/cb/reset", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.True(t, cb.IsClosed())
+
+	// Set rate
+	resp, err = http.Post(server.URL+"/ratelimiters/rl/rate", "application/json", strings.NewReader(`{"maxExecutions": 10, "period": "1s"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Set invalid rate
+	resp, err = http.Post(server.URL+"/ratelimiters/rl/rate", "application/json", strings.NewReader(`{"maxExecutions": 0, "period": "1s"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Set rate for unsupported policy
+	resp, err = http.Post(server.URL+"/ratelimiters/cb/rate", "application/json", strings.NewReader(`{"maxExecutions": 10, "period": "1s"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// Asserts that setting rates is not served unless it's allowed.
+func TestHandlerWithoutSetRate(t *testing.T) {
+	registry, _ := newTestRegistry()
+	server := httptest.NewServer(NewHandler(registry))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/ratelimiters/rl/rate", "application/json", strings.NewReader(`{"maxExecutions": 10, "period": "1s"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestService(t *testing.T) {
+	registry, cb := newTestRegistry()
+	client := newTestClient(t, registry, WithSetRate(true))
+	ctx := context.Background()
+
+	// Get state
+	state, err := client.GetState(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, registry.State(), state)
+
+	// Reset unknown policy
+	err = client.ResetCircuitBreaker(ctx, "unknown")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Reset circuit breaker
+	assert.NoError(t, client.ResetCircuitBreaker(ctx, "cb"))
+	assert.True(t, cb.IsClosed())
+
+	// Set rate
+	assert.NoError(t, client.SetRate(ctx, "rl", 10, time.Second))
+	assert.Equal(t, codes.NotFound, status.Code(client.SetRate(ctx, "unknown", 10, time.Second)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(client.SetRate(ctx, "rl", 0, time.Second)))
+}
+
+// Asserts that setting rates is not registered unless it's allowed.
+func TestServiceWithoutSetRate(t *testing.T) {
+	registry, _ := newTestRegistry()
+	client := newTestClient(t, registry)
+
+	err := client.SetRate(context.Background(), "rl", 10, time.Second)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func newTestClient(t *testing.T, registry *Registry, opts ...Option) *Client {
+	server := grpc.NewServer()
+	RegisterService(server, registry, opts...)
+	listen := bufconn.Listen(1024)
+	go func() {
+		if err := server.Serve(listen); err != nil {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+	}()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough://bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listen.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewClient(conn)
+}
+
+func TestSetRate(t *testing.T) {
+	rl := ratelimiter.Bursty[string](1, time.Hour)
+	registry := NewRegistry()
+	registry.Register("rl", rl)
+	assert.True(t, rl.TryAcquirePermit())
+	assert.False(t, rl.TryAcquirePermit())
+
+	assert.NoError(t, registry.SetRate("rl", 2, time.Hour))
+
+	assert.True(t, rl.TryAcquirePermit())
+	assert.False(t, rl.TryAcquirePermit())
+	assert.ErrorIs(t, registry.SetRate("rl", 2, 0), ErrInvalidRate)
+}
@@ -0,0 +1,3 @@
+// Package failsafeadmin provides an admin service, over HTTP and gRPC, that exposes the state of registered policies and
+// supports safe operations on them.
+package failsafeadmin
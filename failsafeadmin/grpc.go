@@ -0,0 +1,184 @@
+package failsafeadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// ServiceName is the fully qualified name of the admin gRPC service.
+const ServiceName = "failsafe.admin.v1.Admin"
+
+const (
+	getStateMethod            = "/" + ServiceName + "/GetState"
+	resetCircuitBreakerMethod = "/" + ServiceName + "/ResetCircuitBreaker"
+	setRateMethod             = "/" + ServiceName + "/SetRate"
+)
+
+// RegisterService registers the admin gRPC service for the registry with the server. The service uses well known
+// protobuf types for its messages so that no generated code is needed to call it:
+//
+//   - GetState(google.protobuf.Empty) returns the registry State as a google.protobuf.Struct.
+//   - ResetCircuitBreaker(google.protobuf.StringValue) closes the CircuitBreaker registered with the name, returning
+//     google.protobuf.Empty.
+//   - SetRate(google.protobuf.Struct) sets the rate of the RateLimiter registered with the name, given a struct such as
+//     {"name": "limiter", "maxExecutions": 100, "period": "1s"}, returning google.protobuf.Empty. This is only registered
+//     when WithSetRate is configured, else calls fail with codes.Unimplemented.
+func RegisterService(server grpc.ServiceRegistrar, registry *Registry, opts ...Option) {
+	serviceDesc := grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetState",
+				Handler:    getStateHandler,
+			},
+			{
+				MethodName: "ResetCircuitBreaker",
+				Handler:    resetCircuitBreakerHandler,
+			},
+		},
+	}
+	if newOptions(opts).allowSetRate {
+		serviceDesc.Methods = append(serviceDesc.Methods, grpc.MethodDesc{
+			MethodName: "SetRate",
+			Handler:    setRateHandler,
+		})
+	}
+	server.RegisterService(&serviceDesc, registry)
+}
+
+func getStateHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return toStruct(srv.(*Registry).State())
+	}
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: getStateMethod}, handler)
+}
+
+func resetCircuitBreakerHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		if err := srv.(*Registry).ResetCircuitBreaker(req.(*wrapperspb.StringValue).GetValue()); err != nil {
+			return nil, toStatus(err)
+		}
+		return &emptypb.Empty{}, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: resetCircuitBreakerMethod}, handler)
+}
+
+func setRateHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		fields := req.(*structpb.Struct).GetFields()
+		period, err := time.ParseDuration(fields["period"].GetStringValue())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		maxExecutions := fields["maxExecutions"].GetNumberValue()
+		if maxExecutions < 0 {
+			return nil, toStatus(ErrInvalidRate)
+		}
+		if err := srv.(*Registry).SetRate(fields["name"].GetStringValue(), uint(maxExecutions), period); err != nil {
+			return nil, toStatus(err)
+		}
+		return &emptypb.Empty{}, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: setRateMethod}, handler)
+}
+
+// Client calls an admin gRPC service.
+//
+// This type is concurrency safe.
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+// NewClient returns a new Client that calls the admin gRPC service over the conn.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn}
+}
+
+// GetState returns the State of the remote registry.
+func (c *Client) GetState(ctx context.Context) (*State, error) {
+	out := new(structpb.Struct)
+	if err := c.conn.Invoke(ctx, getStateMethod, &emptypb.Empty{}, out); err != nil {
+		return nil, err
+	}
+	bytes, err := out.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	state := &State{}
+	if err := json.Unmarshal(bytes, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ResetCircuitBreaker closes the CircuitBreaker registered with the name in the remote registry.
+func (c *Client) ResetCircuitBreaker(ctx context.Context, name string) error {
+	return c.conn.Invoke(ctx, resetCircuitBreakerMethod, wrapperspb.String(name), new(emptypb.Empty))
+}
+
+// SetRate sets the rate of the RateLimiter registered with the name in the remote registry to the maxExecutions per
+// period. The remote service must be registered with WithSetRate.
+func (c *Client) SetRate(ctx context.Context, name string, maxExecutions uint, period time.Duration) error {
+	in := &structpb.Struct{Fields: map[string]*structpb.Value{
+		"name":          structpb.NewStringValue(name),
+		"maxExecutions": structpb.NewNumberValue(float64(maxExecutions)),
+		"period":        structpb.NewStringValue(period.String()),
+	}}
+	return c.conn.Invoke(ctx, setRateMethod, in, new(emptypb.Empty))
+}
+
+func toStruct(state *State) (*structpb.Struct, error) {
+	bytes, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := s.UnmarshalJSON(bytes); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrUnsupported):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrInvalidRate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
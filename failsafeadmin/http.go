@@ -0,0 +1,83 @@
+package failsafeadmin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	circuitBreakersPath = "/circuitbreakers/"
+	rateLimitersPath    = "/ratelimiters/"
+)
+
+// rateRequest is the request body for setting a RateLimiter's rate, where the period is a duration string such as "1s".
+type rateRequest struct {
+	MaxExecutions uint   `json:"maxExecutions"`
+	Period        string `json:"period"`
+}
+
+// NewHandler returns an http.Handler that serves the admin service for the registry. The handler serves:
+//
+//   - GET / returns the registry State as JSON.
+//   - POST /circuitbreakers/{name}/reset closes the CircuitBreaker registered with the name.
+//   - POST /ratelimiters/{name}/rate sets the rate of the RateLimiter registered with the name, given a JSON body such
+//     as {"maxExecutions": 100, "period": "1s"}. This is only served when WithSetRate is configured.
+//
+// The handler can be mounted under a prefix using http.StripPrefix.
+func NewHandler(registry *Registry, opts ...Option) http.Handler {
+	o := newOptions(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path := req.URL.Path
+		switch {
+		case path == "/" || path == "":
+			if req.Method != http.MethodGet {
+				http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(registry.State())
+		case strings.HasPrefix(path, circuitBreakersPath) && strings.HasSuffix(path, "/reset"):
+			if req.Method != http.MethodPost {
+				http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+				return
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(path, circuitBreakersPath), "/reset")
+			writeError(w, registry.ResetCircuitBreaker(name))
+		case o.allowSetRate && strings.HasPrefix(path, rateLimitersPath) && strings.HasSuffix(path, "/rate"):
+			if req.Method != http.MethodPost {
+				http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+				return
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(path, rateLimitersPath), "/rate")
+			var rate rateRequest
+			if err := json.NewDecoder(req.Body).Decode(&rate); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			period, err := time.ParseDuration(rate.Period)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			writeError(w, registry.SetRate(name, rate.MaxExecutions, period))
+		default:
+			http.NotFound(w, req)
+		}
+	})
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrUnsupported), errors.Is(err, ErrInvalidRate):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
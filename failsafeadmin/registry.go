@@ -0,0 +1,220 @@
+package failsafeadmin
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+)
+
+// ErrNotFound is returned when an operation is performed against a policy that is not registered.
+var ErrNotFound = errors.New("policy not found")
+
+// ErrUnsupported is returned when an operation is performed against a policy that does not support it.
+var ErrUnsupported = errors.New("operation not supported by policy")
+
+// ErrInvalidRate is returned when an invalid rate is set. It is the same as ratelimiter.ErrInvalidRate.
+var ErrInvalidRate = ratelimiter.ErrInvalidRate
+
+// circuitBreaker is the part of a circuitbreaker.CircuitBreaker that the Registry uses.
+type circuitBreaker interface {
+	State() circuitbreaker.State
+	Metrics() circuitbreaker.Metrics
+	Close()
+}
+
+// rateLimiter is the part of a ratelimiter.RateLimiter that the Registry uses.
+type rateLimiter interface {
+	SetRate(maxExecutions uint, period time.Duration) error
+}
+
+// State contains the state of the policies and executors in a Registry.
+type State struct {
+	CircuitBreakers []CircuitBreakerState `json:"circuitBreakers"`
+	Policies        []PolicyState         `json:"policies"`
+	Executors       []ExecutorState       `json:"executors"`
+}
+
+// CircuitBreakerState contains the state of a registered CircuitBreaker.
+type CircuitBreakerState struct {
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Executions  uint   `json:"executions"`
+	Failures    uint   `json:"failures"`
+	FailureRate uint   `json:"failureRate"`
+	Successes   uint   `json:"successes"`
+	SuccessRate uint   `json:"successRate"`
+}
+
+// PolicyState describes a registered policy, along with its metrics if the policy is a failsafe.MetricsProvider.
+type PolicyState struct {
+	Name    string             `json:"name"`
+	Kind    string             `json:"kind"`
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+// ExecutorState describes a registered executor by the kinds of its policies, from outermost to innermost.
+type ExecutorState struct {
+	Name     string   `json:"name"`
+	Policies []string `json:"policies"`
+}
+
+// Registry contains named policies and executor descriptions that are exposed by the admin service.
+//
+// This type is concurrency safe.
+type Registry struct {
+	mtx sync.Mutex
+	// Guarded by mtx
+	policies map[string]any
+	// Guarded by mtx
+	executors map[string][]string
+}
+
+// NewRegistry returns a new Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		policies:  make(map[string]any),
+		executors: make(map[string][]string),
+	}
+}
+
+// Option configures the admin service that is served by NewHandler or RegisterService.
+type Option func(*options)
+
+type options struct {
+	allowSetRate bool
+}
+
+// WithSetRate configures whether the admin service allows setting the rate of registered RateLimiters. Defaults to
+// false, since changing a rate at runtime can overload the services that a RateLimiter protects, and should only be
+// exposed to trusted clients.
+func WithSetRate(allowSetRate bool) Option {
+	return func(o *options) {
+		o.allowSetRate = allowSetRate
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Register registers the policy with the name, replacing any policy previously registered with the name. Policies can be
+// of any result type.
+func (r *Registry) Register(name string, policy any) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.policies[name] = policy
+}
+
+// RegisterExecutor registers a description of an executor composed of the policies with the name, replacing any
+// executor previously registered with the name.
+func RegisterExecutor[R any](r *Registry, name string, policies ...failsafe.Policy[R]) {
+	kinds := make([]string, 0, len(policies))
+	for _, p := range policies {
+		kinds = append(kinds, failsafe.KindOf(p).String())
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.executors[name] = kinds
+}
+
+// State returns the current state of the registered policies and executors, sorted by name.
+func (r *Registry) State() *State {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	state := &State{
+		CircuitBreakers: []CircuitBreakerState{},
+		Policies:        []PolicyState{},
+		Executors:       []ExecutorState{},
+	}
+	for _, name := range sortedKeys(r.policies) {
+		p := r.policies[name]
+		state.Policies = append(state.Policies, PolicyState{Name: name, Kind: kindOf(p).String(), Metrics: metricsOf(p)})
+		if cb, ok := p.(circuitBreaker); ok {
+			metrics := cb.Metrics()
+			state.CircuitBreakers = append(state.CircuitBreakers, CircuitBreakerState{
+				Name:        name,
+				State:       cb.State().String(),
+				Executions:  metrics.Executions(),
+				Failures:    metrics.Failures(),
+				FailureRate: metrics.FailureRate(),
+				Successes:   metrics.Successes(),
+				SuccessRate: metrics.SuccessRate(),
+			})
+		}
+	}
+	for _, name := range sortedKeys(r.executors) {
+		state.Executors = append(state.Executors, ExecutorState{Name: name, Policies: r.executors[name]})
+	}
+	return state
+}
+
+// ResetCircuitBreaker closes the CircuitBreaker registered with the name. Returns ErrNotFound if no policy is registered
+// with the name, or ErrUnsupported if the policy is not a CircuitBreaker.
+func (r *Registry) ResetCircuitBreaker(name string) error {
+	r.mtx.Lock()
+	p, ok := r.policies[name]
+	r.mtx.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	cb, ok := p.(circuitBreaker)
+	if !ok {
+		return ErrUnsupported
+	}
+	cb.Close()
+	return nil
+}
+
+// SetRate sets the rate of the RateLimiter registered with the name to the maxExecutions per period. Returns ErrNotFound
+// if no policy is registered with the name, ErrUnsupported if the policy is not a RateLimiter, or ErrInvalidRate if the
+// rate is invalid.
+func (r *Registry) SetRate(name string, maxExecutions uint, period time.Duration) error {
+	r.mtx.Lock()
+	p, ok := r.policies[name]
+	r.mtx.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	rl, ok := p.(rateLimiter)
+	if !ok {
+		return ErrUnsupported
+	}
+	return rl.SetRate(maxExecutions, period)
+}
+
+func metricsOf(policy any) map[string]float64 {
+	mp, ok := policy.(failsafe.MetricsProvider)
+	if !ok {
+		return nil
+	}
+	metrics := make(map[string]float64)
+	for _, m := range mp.PolicyMetrics() {
+		metrics[m.Name] = m.Value
+	}
+	return metrics
+}
+
+func kindOf(policy any) failsafe.PolicyKind {
+	if kp, ok := policy.(interface{ PolicyKind() failsafe.PolicyKind }); ok {
+		return kp.PolicyKind()
+	}
+	return failsafe.UnknownKind
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
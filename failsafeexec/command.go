@@ -0,0 +1,86 @@
+package failsafeexec
+
+import (
+	"bytes"
+	"os/exec"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// Output is the output of a command attempt.
+type Output struct {
+	// Stdout is the standard output of the attempt, unless the command was configured with a different Stdout.
+	Stdout []byte
+	// Stderr is the standard error of the attempt, unless the command was configured with a different Stderr.
+	Stderr []byte
+	// ExitCode is the exit code of the attempt's process, else -1 if the process did not exit or was killed.
+	ExitCode int
+}
+
+// Command runs subprocesses with failsafe policies. Each attempt, including retries and hedges, runs a new process from
+// an exec.Cmd that is created by a cmdFn. When an attempt is canceled, such as by a Timeout or a Context, the attempt's
+// process group is killed on platforms that support it, else the process is killed. Output is captured separately for
+// each attempt.
+//
+// Commands are considered failed when they cannot be started or exit with a non-zero exit code, in which case an
+// *exec.ExitError is returned with the Output.
+//
+// This type is concurrency safe.
+type Command struct {
+	executor failsafe.Executor[*Output]
+	cmdFn    func() *exec.Cmd
+}
+
+// NewCommand creates and returns a new Command that will run processes from the cmdFn via the policies. The cmdFn
+// should return a new exec.Cmd each time it's called, since an exec.Cmd cannot be reused. The policies are composed
+// around processes and will handle their results in reverse order.
+func NewCommand(cmdFn func() *exec.Cmd, policies ...failsafe.Policy[*Output]) *Command {
+	return NewCommandWithExecutor(cmdFn, failsafe.NewExecutor(policies...))
+}
+
+// NewCommandWithExecutor creates and returns a new Command that will run processes from the cmdFn via the executor. The
+// cmdFn should return a new exec.Cmd each time it's called, since an exec.Cmd cannot be reused.
+func NewCommandWithExecutor(cmdFn func() *exec.Cmd, executor failsafe.Executor[*Output]) *Command {
+	return &Command{
+		executor: executor,
+		cmdFn:    cmdFn,
+	}
+}
+
+// Run runs processes until one succeeds or the policies are exceeded, and returns the Output of the last attempt.
+func (c *Command) Run() (*Output, error) {
+	return c.executor.GetWithExecution(c.run)
+}
+
+func (c *Command) run(exec failsafe.Execution[*Output]) (*Output, error) {
+	cmd := c.cmdFn()
+	var stdout, stderr bytes.Buffer
+	if cmd.Stdout == nil {
+		cmd.Stdout = &stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = &stderr
+	}
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	// Kill the process if the attempt is canceled
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-exec.Canceled():
+			killProcessGroup(cmd)
+		case <-done:
+		}
+	}()
+	err := cmd.Wait()
+	close(done)
+
+	return &Output{
+		Stdout:   stdout.Bytes(),
+		Stderr:   stderr.Bytes(),
+		ExitCode: cmd.ProcessState.ExitCode(),
+	}, err
+}
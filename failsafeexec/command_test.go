@@ -0,0 +1,85 @@
+//go:build unix
+
+package failsafeexec
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+func TestSuccess(t *testing.T) {
+	cmd := NewCommand(func() *exec.Cmd {
+		return exec.Command("sh", "-c", "echo out; echo err >&2")
+	})
+
+	output, err := cmd.Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "out\n", string(output.Stdout))
+	assert.Equal(t, "err\n", string(output.Stderr))
+	assert.Equal(t, 0, output.ExitCode)
+}
+
+func TestRetryCapturesOutputPerAttempt(t *testing.T) {
+	var outputs []string
+	rp := retrypolicy.Builder[*Output]().
+		WithMaxRetries(2).
+		ReturnLastFailure().
+		OnRetry(func(e failsafe.ExecutionEvent[*Output]) {
+			outputs = append(outputs, string(e.LastResult().Stdout))
+		}).
+		Build()
+	attempts := 0
+	cmd := NewCommand(func() *exec.Cmd {
+		attempts++
+		return exec.Command("sh", "-c", "echo attempt; exit 3")
+	}, rp)
+
+	output, err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, output.ExitCode)
+	assert.Equal(t, "attempt\n", string(output.Stdout))
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []string{"attempt\n", "attempt\n"}, outputs)
+}
+
+func TestTimeoutKillsProcessGroup(t *testing.T) {
+	cmd := NewCommand(func() *exec.Cmd {
+		// The child sleep keeps stdout open unless the whole group is killed
+		return exec.Command("sh", "-c", "sleep 10 & wait")
+	}, timeout.With[*Output](100*time.Millisecond))
+
+	start := time.Now()
+	_, err := cmd.Run()
+
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestCircuitBreakerGuardsCrashLoop(t *testing.T) {
+	cb := circuitbreaker.Builder[*Output]().WithFailureThreshold(2).WithDelay(time.Minute).Build()
+	attempts := 0
+	cmd := NewCommand(func() *exec.Cmd {
+		attempts++
+		return exec.Command("sh", "-c", "exit 1")
+	}, cb)
+
+	for i := 0; i < 3; i++ {
+		_, _ = cmd.Run()
+	}
+	_, err := cmd.Run()
+
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Equal(t, 2, attempts)
+}
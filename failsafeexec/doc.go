@@ -0,0 +1,2 @@
+// Package failsafeexec provides functions that can be used to run subprocesses with Failsafe-go policies.
+package failsafeexec
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package failsafeexec
+
+import (
+	"os/exec"
+)
+
+func setProcessGroup(_ *exec.Cmd) {
+}
+
+// killProcessGroup kills the cmd's process, since process groups are not supported.
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
@@ -0,0 +1,21 @@
+//go:build unix
+
+package failsafeexec
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup configures the cmd to start its process in a new process group, so that the group can be killed.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// killProcessGroup kills the cmd's process group, including any child processes.
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
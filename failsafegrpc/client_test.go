@@ -113,7 +113,7 @@ func TestHedgePolicy(t *testing.T) {
 		stats.Reset()
 		return context.Background()
 	}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[*http.Response](80*time.Millisecond), stats).Build()
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[*http.Response](80*time.Millisecond), stats).Build()
 	executor := failsafe.NewExecutor[*http.Response](hp)
 
 	// When / Then
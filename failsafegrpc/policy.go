@@ -1,6 +1,9 @@
 package failsafegrpc
 
 import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -14,23 +17,51 @@ var retryableStatusCodes = map[codes.Code]struct{}{
 }
 
 // RetryPolicyBuilder returns a retrypolicy.RetryPolicyBuilder that will retry on gRPC status codes that are considered
-// retryable (UNAVAILABLE, DEADLINE_EXCEEDED, RESOURCE_EXHAUSTED), up to 2 times by default, with no delay between
-// attempts. Additional handling can be added by chaining the builder with more conditions.
+// retryable (UNAVAILABLE, DEADLINE_EXCEEDED, RESOURCE_EXHAUSTED), up to 2 times by default. If a status carries a
+// google.rpc.RetryInfo detail, its retry delay will be used as a delay between retries, else any configured delay is
+// used. Retries are aborted for CANCELED statuses, since the caller no longer wants a result. Additional handling and
+// delay configuration can be added to the resulting builder.
+//
+// Other status codes, such as ABORTED, INTERNAL, and UNKNOWN, may indicate that a call was partially applied, and are
+// not retried. Executions that are marked as not idempotent via failsafe.MarkIdempotent are not retried at all.
 //
 // R is the execution result type.
 func RetryPolicyBuilder[R any]() retrypolicy.RetryPolicyBuilder[R] {
-	return retrypolicy.Builder[R]().HandleIf(func(_ R, err error) bool {
-		if err != nil {
-			s, ok := status.FromError(err)
-			if !ok {
-				return false
-			}
+	return retrypolicy.Builder[R]().
+		HandleIf(func(_ R, err error) bool {
+			if err != nil {
+				s, ok := status.FromError(err)
+				if !ok {
+					return false
+				}
 
-			if _, ok := retryableStatusCodes[s.Code()]; ok {
-				return true
+				if _, ok := retryableStatusCodes[s.Code()]; ok {
+					return true
+				}
 			}
-		}
 
-		return false
-	})
+			return false
+		}).
+		AbortIf(func(_ R, err error) bool {
+			return err != nil && status.Code(err) == codes.Canceled
+		}).
+		WithDelayFromError(RetryDelay)
+}
+
+// RetryDelay returns the retry delay from the google.rpc.RetryInfo detail of a gRPC status error, along with whether a
+// delay was present. This can be used with RetryPolicyBuilder.WithDelayFromError.
+func RetryDelay(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	for _, detail := range s.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay() != nil {
+			return max(retryInfo.GetRetryDelay().AsDuration(), 0), true
+		}
+	}
+	return 0, false
 }
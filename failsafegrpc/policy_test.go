@@ -0,0 +1,89 @@
+package failsafegrpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+func TestRetryPolicyBuilder(t *testing.T) {
+	tests := []struct {
+		name             string
+		err              error
+		expectedAttempts int
+	}{
+		{
+			"with unavailable error",
+			status.Error(codes.Unavailable, "err"),
+			3,
+		},
+		{
+			"with retry info",
+			newStatusWithRetryInfo(codes.ResourceExhausted, 10*time.Millisecond),
+			3,
+		},
+		{
+			"with non-retryable error",
+			status.Error(codes.Internal, "err"),
+			1,
+		},
+		{
+			"with canceled error",
+			status.Error(codes.Canceled, "err"),
+			1,
+		},
+		{
+			"with non-status error",
+			errors.New("err"),
+			1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			executor := failsafe.NewExecutor[any](RetryPolicyBuilder[any]().Build())
+
+			// When
+			attempts := 0
+			start := time.Now()
+			err := executor.Run(func() error {
+				attempts++
+				return tc.err
+			})
+
+			// Then
+			assert.Error(t, err)
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			if delay, ok := RetryDelay(tc.err); ok {
+				assert.GreaterOrEqual(t, time.Since(start), delay*time.Duration(attempts-1))
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	_, ok := RetryDelay(nil)
+	assert.False(t, ok)
+	_, ok = RetryDelay(errors.New("err"))
+	assert.False(t, ok)
+	_, ok = RetryDelay(status.Error(codes.Unavailable, "err"))
+	assert.False(t, ok)
+
+	delay, ok := RetryDelay(newStatusWithRetryInfo(codes.Unavailable, 5*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+}
+
+func newStatusWithRetryInfo(code codes.Code, delay time.Duration) error {
+	s, _ := status.New(code, "err").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	return s.Err()
+}
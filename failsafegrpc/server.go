@@ -2,12 +2,17 @@ package failsafegrpc
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/tap"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
 	"github.com/failsafe-go/failsafe-go/internal/util"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/timeout"
 )
 
 // NewServerInHandle returns a tap.ServerInHandle that wraps the handler with the policies. This can be used to limit
@@ -22,10 +27,10 @@ func NewServerInHandle[R any](policies ...failsafe.Policy[R]) tap.ServerInHandle
 // NewUnaryServerInterceptorWithExecutor since it does not waste resources for requests that are rejected.
 func NewServerInHandleWithExecutor[R any](executor failsafe.Executor[R]) tap.ServerInHandle {
 	return func(ctx context.Context, info *tap.Info) (context.Context, error) {
-		return ctx, executor.Run(func() error {
+		return ctx, toSentinel(executor.Run(func() error {
 			// The execution is a noop since it's meant to be used with load limiting policies
 			return nil
-		})
+		}))
 	}
 }
 
@@ -43,7 +48,7 @@ func NewUnaryServerInterceptor[R any](policies ...failsafe.Policy[R]) grpc.Unary
 // R is the response type.
 func NewUnaryServerInterceptorWithExecutor[R any](executor failsafe.Executor[R]) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		return executor.GetWithExecution(func(exec failsafe.Execution[R]) (R, error) {
+		response, err := executor.GetWithExecution(func(exec failsafe.Execution[R]) (R, error) {
 			mergedCtx, cancel := util.MergeContexts(ctx, exec.Context())
 			defer cancel(nil)
 			resp, err := handler(mergedCtx, req)
@@ -51,5 +56,28 @@ func NewUnaryServerInterceptorWithExecutor[R any](executor failsafe.Executor[R])
 			response, _ = resp.(R)
 			return response, err
 		})
+		return response, toSentinel(err)
+	}
+}
+
+// sentinelErrors are the errors that policy errors are converted to before they're returned to clients, so that the
+// status messages clients see do not expose details about a server's policies, such as their names.
+var sentinelErrors = []error{
+	circuitbreaker.ErrOpen,
+	bulkhead.ErrFull,
+	ratelimiter.ErrExceeded,
+	timeout.ErrExceeded,
+}
+
+// toSentinel returns the sentinel error that the err matches, if any, else the err.
+func toSentinel(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, sentinel := range sentinelErrors {
+		if errors.Is(err, sentinel) {
+			return sentinel
+		}
 	}
+	return err
 }
@@ -0,0 +1,191 @@
+package failsafegrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/util"
+)
+
+// errorRecorder is implemented by policies, such as CircuitBreaker, that can record errors which occur outside of an
+// execution.
+type errorRecorder interface {
+	RecordError(err error)
+}
+
+// NewStreamClientInterceptor returns a grpc.StreamClientInterceptor that wraps stream establishment with the policies.
+// Policies such as RetryPolicy will retry failures to establish a stream, and policies such as Timeout only apply
+// while a stream is being established, not for the lifetime of the stream. Any CircuitBreakers in the policies will
+// also record errors that are later received on established streams, other than cancellations.
+//
+// R is the result type, which is typically any. When R can hold a grpc.ClientStream, the established stream is provided
+// as the execution result. Streams that are established by attempts whose results are not used, such as hedges, are
+// canceled.
+func NewStreamClientInterceptor[R any](policies ...failsafe.Policy[R]) grpc.StreamClientInterceptor {
+	var recorders []errorRecorder
+	for _, p := range policies {
+		if recorder, ok := p.(errorRecorder); ok {
+			recorders = append(recorders, recorder)
+		}
+	}
+	return newStreamClientInterceptor(failsafe.NewExecutor(policies...), recorders)
+}
+
+// NewStreamClientInterceptorWithExecutor returns a grpc.StreamClientInterceptor that wraps stream establishment with a
+// failsafe.Executor. Policies such as RetryPolicy will retry failures to establish a stream, and policies such as
+// Timeout only apply while a stream is being established, not for the lifetime of the stream. Since the executor's
+// policies are not known, errors that are received on established streams are not recorded with any CircuitBreakers.
+//
+// R is the result type, which is typically any. When R can hold a grpc.ClientStream, the established stream is provided
+// as the execution result. Streams that are established by attempts whose results are not used, such as hedges, are
+// canceled.
+func NewStreamClientInterceptorWithExecutor[R any](executor failsafe.Executor[R]) grpc.StreamClientInterceptor {
+	return newStreamClientInterceptor(executor, nil)
+}
+
+func newStreamClientInterceptor[R any](executor failsafe.Executor[R], recorders []errorRecorder) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		// Track established streams, since multiple attempts, such as hedges, may establish streams
+		var mtx sync.Mutex
+		var established []*clientStream
+		var done bool
+		result, err := executor.GetWithExecution(func(exec failsafe.Execution[R]) (R, error) {
+			// The stream outlives the execution, so execution cancellation is only propagated until the execution is done
+			streamCtx, cancel := context.WithCancel(ctx)
+			stop := context.AfterFunc(exec.Context(), cancel)
+			stream, err := streamer(streamCtx, desc, cc, method, opts...)
+			if err == nil {
+				err = exec.Context().Err()
+			}
+			var response R
+			if err != nil {
+				stop()
+				cancel()
+				return response, err
+			}
+			cs := &clientStream{ClientStream: stream, execCtx: exec.Context(), cancel: cancel, stop: stop, recorders: recorders}
+			mtx.Lock()
+			defer mtx.Unlock()
+			if done {
+				// The execution is already done, such as for a hedge that lost
+				stop()
+				cancel()
+				return response, context.Canceled
+			}
+			established = append(established, cs)
+			response, _ = any(cs).(R)
+			return response, nil
+		})
+
+		// Keep the stream that was returned and cancel the rest
+		mtx.Lock()
+		defer mtx.Unlock()
+		done = true
+		var stream *clientStream
+		var other grpc.ClientStream
+		if err == nil {
+			if other, _ = any(result).(grpc.ClientStream); other != nil {
+				stream, _ = other.(*clientStream)
+			} else {
+				stream = lastActive(established)
+			}
+		}
+		for _, cs := range established {
+			if cs != stream {
+				cs.stop()
+				cs.cancel()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if stream == nil && other != nil {
+			// The stream was provided some other way, such as by a Fallback
+			return other, nil
+		}
+		if stream == nil || !stream.stop() {
+			// The stream was canceled with its execution
+			return nil, status.FromContextError(context.Canceled).Err()
+		}
+		return stream, nil
+	}
+}
+
+// lastActive returns the last of the established streams whose execution was not canceled, which is used when R cannot
+// hold the stream.
+func lastActive(established []*clientStream) *clientStream {
+	for i := len(established) - 1; i >= 0; i-- {
+		if established[i].execCtx.Err() == nil {
+			return established[i]
+		}
+	}
+	return nil
+}
+
+// clientStream releases a stream's context when it ends, and records errors that are received on the stream.
+type clientStream struct {
+	grpc.ClientStream
+	// The context of the execution attempt that established the stream
+	execCtx context.Context
+	cancel  context.CancelFunc
+	// Stops propagating the execution's cancellation to the stream
+	stop      func() bool
+	recorders []errorRecorder
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.cancel()
+		if !errors.Is(err, io.EOF) && status.Code(err) != codes.Canceled {
+			for _, recorder := range s.recorders {
+				recorder.RecordError(err)
+			}
+		}
+	}
+	return err
+}
+
+// NewStreamServerInterceptor returns a grpc.StreamServerInterceptor that wraps the handler with the policies. This can
+// be used to limit the number or rate of concurrent streams with policies such as Bulkhead and RateLimiter, or to
+// reject streams with a CircuitBreaker. Since the handler serves the entire stream, policies such as Timeout apply to
+// the lifetime of the stream. For load limiting that does not require inspecting streams, prefer NewServerInHandle.
+//
+// R is the result type, which is always the zero value since stream handlers do not return a result.
+func NewStreamServerInterceptor[R any](policies ...failsafe.Policy[R]) grpc.StreamServerInterceptor {
+	return NewStreamServerInterceptorWithExecutor(failsafe.NewExecutor(policies...))
+}
+
+// NewStreamServerInterceptorWithExecutor returns a grpc.StreamServerInterceptor that wraps the handler with a
+// failsafe.Executor. This can be used to limit the number or rate of concurrent streams with policies such as Bulkhead
+// and RateLimiter, or to reject streams with a CircuitBreaker. Since the handler serves the entire stream, policies such
+// as Timeout apply to the lifetime of the stream. For load limiting that does not require inspecting streams, prefer
+// NewServerInHandleWithExecutor.
+//
+// R is the result type, which is always the zero value since stream handlers do not return a result.
+func NewStreamServerInterceptorWithExecutor[R any](executor failsafe.Executor[R]) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toSentinel(executor.RunWithExecution(func(exec failsafe.Execution[R]) error {
+			mergedCtx, cancel := util.MergeContexts(ss.Context(), exec.Context())
+			defer cancel(nil)
+			return handler(srv, &serverStream{ServerStream: ss, ctx: mergedCtx})
+		}))
+	}
+}
+
+// serverStream provides a merged context to a stream handler.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
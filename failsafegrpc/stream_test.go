@@ -0,0 +1,209 @@
+package failsafegrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/internal/testutil/pbfixtures"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+type pingStreamService struct {
+	pbfixtures.UnimplementedPingServiceServer
+	responses []string
+	err       error
+}
+
+func (s *pingStreamService) PingStream(_ *pbfixtures.PingRequest, stream pbfixtures.PingService_PingStreamServer) error {
+	for _, response := range s.responses {
+		if err := stream.Send(&pbfixtures.PingResponse{Msg: response}); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+type mockClientStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *mockClientStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamClientRetryOnEstablishmentFailure(t *testing.T) {
+	// Given
+	attempts := 0
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, status.Error(codes.Unavailable, "unavailable")
+		}
+		return &mockClientStream{ctx: ctx}, nil
+	}
+	interceptor := NewStreamClientInterceptor[any](RetryPolicyBuilder[any]().Build())
+
+	// When
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "method", streamer)
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotNil(t, stream)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestStreamClientTimeoutOnlyCoversEstablishment(t *testing.T) {
+	var streamCtx context.Context
+	slowStreamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	fastStreamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamCtx = ctx
+		return &mockClientStream{ctx: ctx}, nil
+	}
+	interceptor := NewStreamClientInterceptor[any](timeout.With[any](50 * time.Millisecond))
+
+	t.Run("should timeout establishment", func(t *testing.T) {
+		_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "method", slowStreamer)
+		assert.ErrorIs(t, err, timeout.ErrExceeded)
+	})
+
+	t.Run("should not timeout established stream", func(t *testing.T) {
+		_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "method", fastStreamer)
+		assert.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, streamCtx.Err())
+	})
+}
+
+// Asserts that streams which are established by hedges that are not used are canceled.
+func TestStreamClientHedgeCancelsUnusedStreams(t *testing.T) {
+	// Selects the first stream after both the initial attempt and the hedge establish streams
+	selectFirst := func(results []hedgepolicy.AttemptResult[any]) int {
+		if len(results) == 2 {
+			return 0
+		}
+		return -1
+	}
+	test := func(t *testing.T, interceptor grpc.StreamClientInterceptor) {
+		// Given
+		var mtx sync.Mutex
+		var streamCtxs []context.Context
+		streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			streamCtxs = append(streamCtxs, ctx)
+			return &mockClientStream{ctx: ctx}, nil
+		}
+
+		// When
+		stream, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "method", streamer)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			mtx.Lock()
+			defer mtx.Unlock()
+			return streamCtxs[1].Err() != nil
+		}, time.Second, 10*time.Millisecond)
+		assert.Same(t, streamCtxs[0], stream.Context())
+		assert.NoError(t, stream.Context().Err())
+	}
+
+	t.Run("with stream result", func(t *testing.T) {
+		hp := hedgepolicy.BuilderWithDelay[any](10 * time.Millisecond).WithResultSelector(selectFirst).Build()
+		test(t, NewStreamClientInterceptor[any](hp))
+	})
+
+	t.Run("without stream result", func(t *testing.T) {
+		hp := hedgepolicy.BuilderWithDelay[string](10 * time.Millisecond).
+			WithResultSelector(func(results []hedgepolicy.AttemptResult[string]) int {
+				return selectFirst(make([]hedgepolicy.AttemptResult[any], len(results)))
+			}).
+			Build()
+		test(t, NewStreamClientInterceptor[string](hp))
+	})
+}
+
+// Asserts that circuit breakers record errors that occur on established streams.
+func TestStreamClientCircuitBreaker(t *testing.T) {
+	// Given
+	service := &pingStreamService{responses: []string{"foo"}, err: status.Error(codes.Internal, "err")}
+	cb := circuitbreaker.Builder[any]().WithFailureThreshold(1).WithDelay(time.Minute).Build()
+	grpcServer, dialer := testutil.GrpcServer(service)
+	grpcClient := testutil.GrpcClient(dialer, grpc.WithStreamInterceptor(NewStreamClientInterceptor[any](cb)))
+	t.Cleanup(func() {
+		grpcServer.Stop()
+		grpcClient.Close()
+	})
+	client := pbfixtures.NewPingServiceClient(grpcClient)
+
+	// When
+	stream, err := client.PingStream(context.Background(), &pbfixtures.PingRequest{Msg: "ping"})
+	assert.NoError(t, err)
+	response, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", response.Msg)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// Then
+	assert.True(t, cb.IsOpen())
+	_, err = client.PingStream(context.Background(), &pbfixtures.PingRequest{Msg: "ping"})
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+}
+
+func TestStreamServerBulkhead(t *testing.T) {
+	// Given
+	service := &pingStreamService{responses: []string{"foo", "bar"}}
+	bh := bulkhead.With[any](1)
+	grpcServer, dialer := testutil.GrpcServer(service, grpc.StreamInterceptor(NewStreamServerInterceptor[any](bh)))
+	grpcClient := testutil.GrpcClient(dialer)
+	t.Cleanup(func() {
+		grpcServer.Stop()
+		grpcClient.Close()
+	})
+	client := pbfixtures.NewPingServiceClient(grpcClient)
+	recvAll := func() ([]string, error) {
+		stream, err := client.PingStream(context.Background(), &pbfixtures.PingRequest{Msg: "ping"})
+		if err != nil {
+			return nil, err
+		}
+		var msgs []string
+		for {
+			response, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return msgs, nil
+			} else if err != nil {
+				return msgs, err
+			}
+			msgs = append(msgs, response.Msg)
+		}
+	}
+
+	// When / Then
+	msgs, err := recvAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, msgs)
+
+	// When / Then
+	bh.TryAcquirePermit() // Exhaust permits
+	_, err = recvAll()
+	assert.Equal(t, codes.Unknown, status.Code(err))
+	assert.Equal(t, bulkhead.ErrFull.Error(), status.Convert(err).Message())
+}
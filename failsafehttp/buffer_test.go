@@ -0,0 +1,79 @@
+package failsafehttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// Asserts that a Timeout bounds reading a slowly streamed response body when responses are buffered.
+func TestBufferedResponsesWithTimeout(t *testing.T) {
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-request.Context().Done():
+		}
+		_, _ = w.Write([]byte("foo"))
+	}))
+	defer server.Close()
+	executor := failsafe.NewExecutor[*http.Response](timeout.With[*http.Response](50 * time.Millisecond))
+
+	t.Run("should not bound body reads without buffering", func(t *testing.T) {
+		resp, err := NewRoundTripperWithExecutor(nil, executor).RoundTrip(newGetRequest(server.URL))
+		assert.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "foo", string(body))
+	})
+
+	t.Run("should bound body reads with buffering", func(t *testing.T) {
+		resp, err := NewRoundTripperWithExecutor(nil, executor, WithBufferedResponses(0)).RoundTrip(newGetRequest(server.URL))
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, timeout.ErrExceeded)
+	})
+}
+
+func TestBufferedResponsesWithMaxBytes(t *testing.T) {
+	// Given
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(strings.Repeat("a", 10)))
+	}))
+	defer server.Close()
+	executor := failsafe.NewExecutor[*http.Response](RetryPolicyBuilder().Build())
+
+	t.Run("should read body within limit", func(t *testing.T) {
+		request := NewRequestWithExecutor(newGetRequest(server.URL), http.DefaultClient, executor, WithBufferedResponses(10))
+		resp, err := request.Do()
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Len(t, body, 10)
+	})
+
+	t.Run("should fail without retrying when body exceeds limit", func(t *testing.T) {
+		requests.Store(0)
+		request := NewRequestWithExecutor(newGetRequest(server.URL), http.DefaultClient, executor, WithBufferedResponses(9))
+		resp, err := request.Do()
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+func newGetRequest(url string) *http.Request {
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	return request
+}
@@ -0,0 +1,376 @@
+package failsafehttp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/budget"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// OverloadLevelHeader is a response header that a server uses to report how overloaded it is, from 0.0, not overloaded,
+// to 1.0, fully overloaded. Clients configured with WithServerHints shed that fraction of their requests to the server
+// for a short time after it's reported.
+const OverloadLevelHeader = "Failsafe-Overload-Level"
+
+// overloadLevelTTL is how long an overload level that's reported by a server is used for.
+const overloadLevelTTL = time.Second
+
+// defaultRetryAfter is the Retry-After delay a Handler reports when a rejection does not provide a delay.
+const defaultRetryAfter = time.Second
+
+// ErrServerBackoff is a convenience error sentinel that can be used to build policies that handle ServerBackoffError,
+// such as via HandleErrors(failsafehttp.ErrServerBackoff).
+var ErrServerBackoff = errors.New("backing off from server")
+
+// ServerBackoffError is returned, without a request being sent, when a client configured with WithServerHints is backing
+// off from a server that reported a Retry-After delay or an overload level. ServerBackoffError is a
+// retrypolicy.DelayHint, so a RetryPolicy will delay until the backoff is expected to end before retrying.
+type ServerBackoffError struct {
+	// Host is the host that is being backed off from.
+	Host string
+	// Delay is the remaining time that the host is expected to be backed off from.
+	Delay time.Duration
+}
+
+func (e *ServerBackoffError) Error() string {
+	return fmt.Sprintf("backing off from server %s. remaining delay: %v", e.Host, e.Delay)
+}
+
+func (e *ServerBackoffError) Is(err error) bool {
+	return err == ErrServerBackoff
+}
+
+func (e *ServerBackoffError) DelayHint() time.Duration {
+	return e.Delay
+}
+
+// RetryAfter returns the delay from the resp Retry-After header, which can be either a number of seconds or an HTTP
+// date, along with whether a valid header was present.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Second * time.Duration(seconds), true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// OverloadLevel returns the overload level from the resp OverloadLevelHeader, along with whether a valid header was
+// present.
+func OverloadLevel(resp *http.Response) (float64, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	header := resp.Header.Get(OverloadLevelHeader)
+	if header == "" {
+		return 0, false
+	}
+	level, err := strconv.ParseFloat(header, 64)
+	if err != nil || math.IsNaN(level) {
+		return 0, false
+	}
+	return min(max(level, 0), 1), true
+}
+
+// SetRetryAfter sets a Retry-After header for the delay, rounded up to the nearest second.
+func SetRetryAfter(header http.Header, delay time.Duration) {
+	header.Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+}
+
+// SetOverloadLevel sets an OverloadLevelHeader for the level, which should be from 0.0 to 1.0.
+func SetOverloadLevel(header http.Header, level float64) {
+	header.Set(OverloadLevelHeader, strconv.FormatFloat(min(max(level, 0), 1), 'f', 2, 64))
+}
+
+// delayer is implemented by policies that can provide a delay until they allow executions again, such as a
+// circuitbreaker.CircuitBreaker.
+type delayer interface {
+	RemainingDelay() time.Duration
+}
+
+type handler struct {
+	next     http.Handler
+	executor failsafe.Executor[any]
+	delayers []delayer
+}
+
+// NewHandler returns a new http.Handler that serves requests via the next handler and policies, such as a RateLimiter,
+// Bulkhead, or CircuitBreaker, which are composed around each request. Requests that are rejected by the policies
+// before reaching the next handler are responded to with hints for clients:
+//
+//   - Requests rejected by a RateLimiter get a 429 response, and requests rejected by other policies, such as a Bulkhead
+//     or CircuitBreaker, get a 503 response.
+//   - A Retry-After header is set to the remaining delay of any CircuitBreakers, else 1 second.
+//   - An OverloadLevelHeader of 1.0 is set for requests that are rejected because the server is at capacity, such as by
+//     a RateLimiter or Bulkhead. It is omitted for other rejections, such as by a CircuitBreaker.
+//
+// Requests that exceed a Timeout or Budget get a 503 response, if the next handler has not already written a response.
+//
+// Clients that use failsafehttp.RetryPolicyBuilder will delay retries according to the Retry-After header, and clients
+// configured with WithServerHints will back off from the server.
+func NewHandler(next http.Handler, policies ...failsafe.Policy[any]) http.Handler {
+	h := NewHandlerWithExecutor(next, failsafe.NewExecutor(policies...)).(*handler)
+	for _, p := range policies {
+		if d, ok := p.(delayer); ok {
+			h.delayers = append(h.delayers, d)
+		}
+	}
+	return h
+}
+
+// NewHandlerWithExecutor returns a new http.Handler that serves requests via the next handler and executor. See
+// NewHandler.
+func NewHandlerWithExecutor(next http.Handler, executor failsafe.Executor[any]) http.Handler {
+	return &handler{
+		next:     next,
+		executor: executor,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Attempts may run concurrently, such as when hedging
+	var served atomic.Bool
+	rw := &responseWriter{ResponseWriter: w}
+	wrapped := rw.wrap()
+	err := h.executor.WithContext(req.Context()).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		served.Store(true)
+		h.next.ServeHTTP(wrapped, req.WithContext(exec.Context()))
+		return nil
+	})
+	if err == nil {
+		return
+	}
+	if served.Load() {
+		if !rw.wroteHeader && (errors.Is(err, timeout.ErrExceeded) || errors.Is(err, budget.ErrExceeded)) {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		}
+		return
+	}
+
+	var retryAfter time.Duration
+	for _, d := range h.delayers {
+		retryAfter = max(retryAfter, d.RemainingDelay())
+	}
+	if retryAfter == 0 {
+		retryAfter = defaultRetryAfter
+	}
+	SetRetryAfter(w.Header(), retryAfter)
+	if errors.Is(err, ratelimiter.ErrExceeded) || errors.Is(err, bulkhead.ErrFull) {
+		SetOverloadLevel(w.Header(), 1)
+	}
+	status := http.StatusServiceUnavailable
+	if errors.Is(err, ratelimiter.ErrExceeded) {
+		status = http.StatusTooManyRequests
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// responseWriter tracks whether a response has been written.
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter, which allows an http.ResponseController to be used.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// wrap returns a ResponseWriter for w that implements only the optional http.Flusher, http.Hijacker, and http.Pusher
+// interfaces that the underlying ResponseWriter implements, so that handlers can detect which are supported.
+func (w *responseWriter) wrap() http.ResponseWriter {
+	_, isFlusher := w.ResponseWriter.(http.Flusher)
+	_, isHijacker := w.ResponseWriter.(http.Hijacker)
+	p, isPusher := w.ResponseWriter.(http.Pusher)
+	f := flusher{w}
+	h := hijacker{w}
+	switch {
+	case isFlusher && isHijacker && isPusher:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{w, f, h, p}
+	case isFlusher && isHijacker:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+		}{w, f, h}
+	case isFlusher && isPusher:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Pusher
+		}{w, f, p}
+	case isHijacker && isPusher:
+		return struct {
+			*responseWriter
+			http.Hijacker
+			http.Pusher
+		}{w, h, p}
+	case isFlusher:
+		return struct {
+			*responseWriter
+			http.Flusher
+		}{w, f}
+	case isHijacker:
+		return struct {
+			*responseWriter
+			http.Hijacker
+		}{w, h}
+	case isPusher:
+		return struct {
+			*responseWriter
+			http.Pusher
+		}{w, p}
+	default:
+		return w
+	}
+}
+
+// flusher flushes a responseWriter whose underlying ResponseWriter is an http.Flusher.
+type flusher struct {
+	*responseWriter
+}
+
+func (w flusher) Flush() {
+	w.wroteHeader = true
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+// hijacker hijacks a responseWriter whose underlying ResponseWriter is an http.Hijacker. A hijacked response is
+// considered written, since the connection is no longer managed by the server.
+type hijacker struct {
+	*responseWriter
+}
+
+func (w hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+// WithServerHints configures requests to consume hints from servers, such as from servers that use NewHandler. When a
+// server responds with a 429 or 503 and a Retry-After header, requests to its host fail with a ServerBackoffError,
+// without being sent, until the delay has elapsed. When a server reports an OverloadLevelHeader, that fraction of
+// requests to its host fail with a ServerBackoffError for a short time afterwards.
+func WithServerHints() Option {
+	return func(o *options) {
+		o.hints = &serverHints{
+			now:   time.Now,
+			hosts: make(map[string]*hostHints),
+		}
+	}
+}
+
+// serverHints tracks hints that were received from servers, by host.
+type serverHints struct {
+	now func() time.Time
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	hosts map[string]*hostHints
+}
+
+type hostHints struct {
+	retryAfter     time.Time
+	overloadLevel  float64
+	overloadExpiry time.Time
+}
+
+// expired returns whether the hints no longer apply as of now.
+func (h *hostHints) expired(now time.Time) bool {
+	return !now.Before(h.retryAfter) && !now.Before(h.overloadExpiry)
+}
+
+// admit returns a ServerBackoffError if a request to the host should not be sent, else nil.
+func (s *serverHints) admit(host string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	h, ok := s.hosts[host]
+	if !ok {
+		return nil
+	}
+	now := s.now()
+	if h.expired(now) {
+		delete(s.hosts, host)
+		return nil
+	}
+	if now.Before(h.retryAfter) {
+		return &ServerBackoffError{Host: host, Delay: h.retryAfter.Sub(now)}
+	}
+	if rand.Float64() < h.overloadLevel {
+		return &ServerBackoffError{Host: host, Delay: h.overloadExpiry.Sub(now)}
+	}
+	return nil
+}
+
+// record records any hints from the resp for the host.
+func (s *serverHints) record(host string, resp *http.Response) {
+	retryAfter, hasRetryAfter := RetryAfter(resp)
+	hasRetryAfter = hasRetryAfter && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable)
+	overloadLevel, hasOverloadLevel := OverloadLevel(resp)
+	if !hasRetryAfter && !hasOverloadLevel {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	now := s.now()
+	s.pruneExpired(now)
+	h, ok := s.hosts[host]
+	if !ok {
+		h = &hostHints{}
+		s.hosts[host] = h
+	}
+	if hasRetryAfter {
+		h.retryAfter = now.Add(retryAfter)
+	}
+	if hasOverloadLevel {
+		h.overloadLevel = overloadLevel
+		h.overloadExpiry = now.Add(overloadLevelTTL)
+	}
+}
+
+// pruneExpired removes hints that have expired as of now, so that hosts which are no longer requested are not retained.
+//
+// Requires external locking.
+func (s *serverHints) pruneExpired(now time.Time) {
+	for host, h := range s.hosts {
+		if h.expired(now) {
+			delete(s.hosts, host)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package failsafehttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/ratelimiter"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// Asserts that a Handler responds to rejected requests with hints.
+func TestHandlerWithRejections(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("should respond with 429 when rate limited", func(t *testing.T) {
+		rl := ratelimiter.Bursty[any](1, time.Minute)
+		server := httptest.NewServer(NewHandler(ok, rl))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp, err = http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+		level, _ := OverloadLevel(resp)
+		assert.Equal(t, 1.0, level)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusText(http.StatusTooManyRequests)+"\n", string(body))
+	})
+
+	t.Run("should respond with 503 and remaining delay when circuit breaker is open", func(t *testing.T) {
+		cb := circuitbreaker.Builder[any]().WithDelay(time.Minute).Build()
+		cb.Open()
+		server := httptest.NewServer(NewHandler(ok, cb))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		delay, _ := RetryAfter(resp)
+		assert.Equal(t, time.Minute, delay)
+		_, ok := OverloadLevel(resp)
+		assert.False(t, ok)
+	})
+
+	t.Run("should respond with 503 when timeout is exceeded", func(t *testing.T) {
+		slow := http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			<-request.Context().Done()
+		})
+		server := httptest.NewServer(NewHandler(slow, timeout.With[any](10*time.Millisecond)))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+
+	t.Run("should not overwrite a response when timeout is exceeded", func(t *testing.T) {
+		slow := http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			<-request.Context().Done()
+		})
+		server := httptest.NewServer(NewHandler(slow, timeout.With[any](10*time.Millisecond)))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	})
+}
+
+// Asserts that a Handler exposes only the optional interfaces that the underlying ResponseWriter supports.
+func TestHandlerResponseWriterInterfaces(t *testing.T) {
+	var isFlusher, isHijacker, isPusher bool
+	inspect := http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		_, isFlusher = w.(http.Flusher)
+		_, isHijacker = w.(http.Hijacker)
+		_, isPusher = w.(http.Pusher)
+	})
+	handler := NewHandler(inspect, timeout.With[any](time.Minute))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	t.Run("should only expose flusher when the underlying writer only flushes", func(t *testing.T) {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		assert.True(t, isFlusher)
+		assert.False(t, isHijacker)
+		assert.False(t, isPusher)
+	})
+
+	t.Run("should not expose flusher when the underlying writer does not flush", func(t *testing.T) {
+		w := struct{ http.ResponseWriter }{httptest.NewRecorder()}
+		handler.ServeHTTP(w, req)
+		assert.False(t, isFlusher)
+		assert.False(t, isHijacker)
+		assert.False(t, isPusher)
+	})
+
+	t.Run("should allow connections to be hijacked", func(t *testing.T) {
+		upgrade := http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer conn.Close()
+			_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")
+			_ = rw.Flush()
+		})
+		server := httptest.NewServer(NewHandler(upgrade, timeout.With[any](time.Minute)))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
+
+// Asserts that a client configured with WithServerHints backs off from a server that responds with a Retry-After.
+func TestWithServerHints(t *testing.T) {
+	// Given
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		if requests.Add(1) == 1 {
+			SetRetryAfter(w.Header(), time.Minute)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	rt := NewRoundTripperWithExecutor(nil, failsafe.NewExecutor[*http.Response](), WithServerHints())
+
+	// When / Then
+	resp, err := rt.RoundTrip(newGetRequest(server.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	resp, err = rt.RoundTrip(newGetRequest(server.URL))
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrServerBackoff)
+	var backoffErr *ServerBackoffError
+	assert.ErrorAs(t, err, &backoffErr)
+	assert.InDelta(t, time.Minute, backoffErr.DelayHint(), float64(time.Second))
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// Asserts that a client configured with WithServerHints sheds requests to a fully overloaded server.
+func TestWithServerHintsOverloadLevel(t *testing.T) {
+	// Given
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		SetOverloadLevel(w.Header(), 1)
+	}))
+	defer server.Close()
+	rt := NewRoundTripperWithExecutor(nil, failsafe.NewExecutor[*http.Response](), WithServerHints())
+
+	// When / Then
+	_, err := rt.RoundTrip(newGetRequest(server.URL))
+	assert.NoError(t, err)
+	_, err = rt.RoundTrip(newGetRequest(server.URL))
+	assert.ErrorIs(t, err, ErrServerBackoff)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// Asserts that expired hints are pruned when other hints are recorded.
+func TestServerHintsPruneExpired(t *testing.T) {
+	// Given
+	now := time.Now()
+	hints := &serverHints{
+		now:   func() time.Time { return now },
+		hosts: make(map[string]*hostHints),
+	}
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	SetOverloadLevel(resp.Header, 1)
+	hints.record("a", resp)
+	resp.Header.Set("Retry-After", "5")
+	hints.record("b", resp)
+	assert.Len(t, hints.hosts, 2)
+
+	// When
+	now = now.Add(2 * overloadLevelTTL)
+	hints.record("c", resp)
+
+	// Then
+	assert.Len(t, hints.hosts, 2)
+	assert.NotContains(t, hints.hosts, "a")
+	assert.ErrorIs(t, hints.admit("b"), ErrServerBackoff)
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	_, ok := RetryAfter(resp)
+	assert.False(t, ok)
+
+	resp.Header.Set("Retry-After", "5")
+	delay, ok := RetryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	delay, ok = RetryAfter(resp)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Hour, delay, float64(2*time.Second))
+
+	resp.Header.Set("Retry-After", "invalid")
+	_, ok = RetryAfter(resp)
+	assert.False(t, ok)
+}
@@ -2,46 +2,145 @@ package failsafehttp
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"sync"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/keyed"
 	"github.com/failsafe-go/failsafe-go/internal/util"
 )
 
+// ErrResponseTooLarge is returned when a response body exceeds the max size configured via WithBufferedResponses.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// maxDrainBytes is the max number of bytes that are read when draining a response body so that its connection can be
+// reused. Larger bodies are closed without being fully read.
+const maxDrainBytes = 64 << 10
+
+// Option configures a failsafe RoundTripper or Request.
+type Option func(*options)
+
+type options struct {
+	bufferResponses    bool
+	maxResponseBytes   int64
+	hints              *serverHints
+	hostBreakers       *keyed.Policies[circuitbreaker.CircuitBreaker[*http.Response]]
+	skipDraining       bool
+	hedgeHeader        string
+	retryNonIdempotent bool
+}
+
+// WithBufferedResponses configures response bodies to be read into memory as part of each execution attempt, rather
+// than by the caller after execution is done. This allows reading response bodies to be bounded by the same policies
+// that bound requests. For example, a Timeout will bound the time spent reading a slowly streamed response body, and a
+// RetryPolicy will retry failures that occur while reading a response body. If maxBytes is > 0, responses whose body
+// exceeds maxBytes will fail with ErrResponseTooLarge.
+func WithBufferedResponses(maxBytes int64) Option {
+	return func(o *options) {
+		o.bufferResponses = true
+		o.maxResponseBytes = maxBytes
+	}
+}
+
+// WithHostCircuitBreakers configures requests to use a separate CircuitBreaker for each destination host, so that a
+// failing host does not open the circuit for other hosts. CircuitBreakers are created via the builder as hosts are
+// first requested, and up to maxHosts CircuitBreakers are kept, evicting the least recently used. If maxHosts is 0, the
+// number of CircuitBreakers is unbounded.
+//
+// Host CircuitBreakers are applied to each attempt, inside of any policies that the requests are performed with. Requests
+// that are rejected by an open CircuitBreaker fail with circuitbreaker.ErrOpen and are not sent, so they're safe to
+// retry.
+func WithHostCircuitBreakers(builder circuitbreaker.CircuitBreakerBuilder[*http.Response], maxHosts uint) Option {
+	return func(o *options) {
+		o.hostBreakers = keyed.New[circuitbreaker.CircuitBreaker[*http.Response]](int(maxHosts), builder.Build)
+	}
+}
+
+// WithResponseDraining configures whether the response body of an attempt is drained and closed when the attempt is
+// retried, before the next attempt is sent, so that the attempt's connection can be reused. Defaults to true. Draining
+// can be disabled if retried responses are used after the next attempt begins, such as by a listener that stores them.
+func WithResponseDraining(drain bool) Option {
+	return func(o *options) {
+		o.skipDraining = !drain
+	}
+}
+
+// WithHedgeHeader configures hedged attempts to be sent with the header, whose value is the attempt's number, such as
+// "X-Hedge-Attempt: 2". This allows servers to identify hedges, such as to dedupe them. The header is not set for
+// attempts that are not hedges.
+func WithHedgeHeader(name string) Option {
+	return func(o *options) {
+		o.hedgeHeader = name
+	}
+}
+
+// WithRetryNonIdempotent configures whether requests with non-idempotent methods, such as POST and PATCH, may be retried
+// or hedged even when they do not carry an Idempotency-Key header. Defaults to false, in which case such requests are
+// performed with a copy of the executor whose context is the request's context, marked as not idempotent via
+// failsafe.MarkIdempotent, since retrying them may cause them to be applied more than once.
+func WithRetryNonIdempotent(retryNonIdempotent bool) Option {
+	return func(o *options) {
+		o.retryNonIdempotent = retryNonIdempotent
+	}
+}
+
+var defaultOptions = &options{}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
 type roundTripper struct {
 	next     http.RoundTripper
 	executor failsafe.Executor[*http.Response]
+	options  *options
 }
 
 // NewRoundTripper returns a new http.RoundTripper that will perform failsafe round trips via the policies and
 // innerRoundTripper. If innerRoundTripper is nil, http.DefaultTransport will be used. The policies are composed around
 // requests and will handle responses in reverse order.
+//
+// Since a request may be attempted more than once, such as by retries or hedges, a new request body is needed for each
+// attempt. If the request's GetBody func is set, which http.NewRequest does for in-memory bodies, and which can be set
+// for streaming bodies such as multipart uploads, it is called to get the body for each attempt. Otherwise, the request
+// body is read into memory and re-read for each attempt, unless it's an io.ReaderAt and io.Seeker, such as an *os.File,
+// in which case each attempt reads it via a separate io.SectionReader. Bodies are not shared across attempts, so
+// concurrent hedges can safely read them.
 func NewRoundTripper(innerRoundTripper http.RoundTripper, policies ...failsafe.Policy[*http.Response]) http.RoundTripper {
 	return NewRoundTripperWithExecutor(innerRoundTripper, failsafe.NewExecutor(policies...))
 }
 
 // NewRoundTripperWithExecutor returns a new http.RoundTripper that will perform failsafe round trips via the executor and
-// innerRoundTripper. If innerRoundTripper is nil, http.DefaultTransport will be used.
-func NewRoundTripperWithExecutor(innerRoundTripper http.RoundTripper, executor failsafe.Executor[*http.Response]) http.RoundTripper {
+// innerRoundTripper, configured with the opts. If innerRoundTripper is nil, http.DefaultTransport will be used.
+func NewRoundTripperWithExecutor(innerRoundTripper http.RoundTripper, executor failsafe.Executor[*http.Response], opts ...Option) http.RoundTripper {
 	if innerRoundTripper == nil {
 		innerRoundTripper = http.DefaultTransport
 	}
 	return &roundTripper{
 		next:     innerRoundTripper,
 		executor: executor,
+		options:  newOptions(opts),
 	}
 }
 
 func (r *roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
-	return doRequest(request, r.executor, r.next.RoundTrip)
+	return doRequest(request, r.executor, r.options, r.next.RoundTrip)
 }
 
 type Request struct {
 	executor failsafe.Executor[*http.Response]
 	request  *http.Request
 	client   *http.Client
+	options  *options
 }
 
 // NewRequest creates and returns a new Request that will perform failsafe round trips via the request, client, and
@@ -51,29 +150,52 @@ func NewRequest(request *http.Request, client *http.Client, policies ...failsafe
 }
 
 // NewRequestWithExecutor creates and returns a new Request that will perform failsafe round trips via the request,
-// client, and executor.
-func NewRequestWithExecutor(request *http.Request, client *http.Client, executor failsafe.Executor[*http.Response]) *Request {
+// client, and executor, configured with the opts.
+func NewRequestWithExecutor(request *http.Request, client *http.Client, executor failsafe.Executor[*http.Response], opts ...Option) *Request {
 	return &Request{
 		executor: executor,
 		request:  request,
 		client:   client,
+		options:  newOptions(opts),
 	}
 }
 
 func (r *Request) Do() (*http.Response, error) {
-	return doRequest(r.request, r.executor, r.client.Do)
+	return doRequest(r.request, r.executor, r.options, r.client.Do)
 }
 
-func doRequest(request *http.Request, executor failsafe.Executor[*http.Response], reqFn func(r *http.Request) (*http.Response, error)) (*http.Response, error) {
-	bodyFunc, err := bodyReader(request.Body)
+func doRequest(request *http.Request, executor failsafe.Executor[*http.Response], opts *options, reqFn func(r *http.Request) (*http.Response, error)) (*http.Response, error) {
+	bodyFunc, err := requestBodyFunc(request)
 	if err != nil {
 		return nil, err
 	}
 
+	var d *drainer
+	if !opts.skipDraining {
+		d = &drainer{}
+	}
+	if !opts.retryNonIdempotent && !isIdempotent(request.Method, request.Header) {
+		// Mark the execution so that policies do not re-execute the request
+		executor = executor.WithContext(failsafe.MarkIdempotent(request.Context(), false))
+	}
+
 	return executor.GetWithExecution(func(exec failsafe.Execution[*http.Response]) (*http.Response, error) {
+		// The last result is from an attempt that is being retried, and won't be used
+		if d != nil {
+			d.drain(exec.LastResult())
+		}
+
 		ctx, cancel := util.MergeContexts(request.Context(), exec.Context())
 		defer cancel(nil)
 		req := request.WithContext(ctx)
+		if opts.hedgeHeader != "" && exec.IsHedge() {
+			// Clone the headers since they're shared with concurrent attempts
+			req.Header = req.Header.Clone()
+			if req.Header == nil {
+				req.Header = make(http.Header)
+			}
+			req.Header.Set(opts.hedgeHeader, strconv.Itoa(exec.Attempts()))
+		}
 
 		// Get new body for each attempt
 		if bodyFunc != nil {
@@ -88,10 +210,106 @@ func doRequest(request *http.Request, executor failsafe.Executor[*http.Response]
 			}
 		}
 
-		return reqFn(req)
+		if opts.hints != nil {
+			if err := opts.hints.admit(req.URL.Host); err != nil {
+				// The request was not sent, so it's safe to retry
+				return nil, err
+			}
+		}
+		send := func() (*http.Response, error) {
+			resp, err := reqFn(req)
+			if err == nil && opts.hints != nil {
+				opts.hints.record(req.URL.Host, resp)
+			}
+			if err == nil && opts.bufferResponses {
+				// Read the body before the attempt's context is canceled
+				if err = readBody(resp, opts.maxResponseBytes); err != nil {
+					resp = nil
+				}
+			}
+			return resp, err
+		}
+
+		var resp *http.Response
+		var err error
+		if opts.hostBreakers != nil {
+			breaker := opts.hostBreakers.Get(req.URL.Host)
+			resp, err = failsafe.NewExecutor[*http.Response](breaker).WithContext(ctx).Get(send)
+			if errors.Is(err, circuitbreaker.ErrOpen) {
+				// The request was not sent, so it's safe to retry
+				return nil, err
+			}
+		} else {
+			resp, err = send()
+		}
+		return resp, err
 	})
 }
 
+// drainer drains and closes the bodies of responses from attempts that are retried, so that their connections can be
+// reused. A response may be seen by multiple attempts, such as by concurrent hedges, but is only drained once.
+type drainer struct {
+	mtx sync.Mutex
+	// Guarded by mtx
+	drained map[*http.Response]struct{}
+}
+
+func (d *drainer) drain(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if _, ok := d.drained[resp]; ok {
+		return
+	}
+	if d.drained == nil {
+		d.drained = make(map[*http.Response]struct{})
+	}
+	d.drained[resp] = struct{}{}
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+	_ = resp.Body.Close()
+}
+
+// readBody reads the resp body into memory, returning ErrResponseTooLarge if it exceeds maxBytes when maxBytes > 0.
+func readBody(resp *http.Response, maxBytes int64) error {
+	reader := io.Reader(resp.Body)
+	if maxBytes > 0 {
+		reader = io.LimitReader(resp.Body, maxBytes+1)
+	}
+	buf, err := io.ReadAll(reader)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	if maxBytes > 0 && int64(len(buf)) > maxBytes {
+		return ErrResponseTooLarge
+	}
+	resp.Body = &bufferedBody{Reader: bytes.NewReader(buf), buf: buf}
+	return nil
+}
+
+// requestBodyFunc returns a function that provides a new body for each attempt of the request, else nil if the request
+// has no body. When the request has a GetBody func, such as for streaming bodies, it's used so that the body is not
+// buffered into memory, and the request's original body is closed since it won't be sent. Otherwise the body is read
+// via bodyReader.
+func requestBodyFunc(request *http.Request) (func() (io.Reader, error), error) {
+	if request.GetBody == nil || request.Body == nil || request.Body == http.NoBody {
+		return bodyReader(request.Body)
+	}
+	if err := request.Body.Close(); err != nil {
+		return nil, err
+	}
+	return func() (io.Reader, error) {
+		return request.GetBody()
+	}, nil
+}
+
+type readerAtSeeker interface {
+	io.ReaderAt
+	io.Seeker
+}
+
 // bodyReader returns a function that can repeatedly read the untypedBody of an http.Request.
 func bodyReader(untypedBody any) (func() (io.Reader, error), error) {
 	switch body := untypedBody.(type) {
@@ -113,6 +331,16 @@ func bodyReader(untypedBody any) (func() (io.Reader, error), error) {
 			return bytes.NewReader(buf), nil
 		}, nil
 
+	// Match io.ReaderAt before io.ReadSeeker so that concurrent attempts do not share the body's offset
+	case readerAtSeeker:
+		size, err := body.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		return func() (io.Reader, error) {
+			return io.NewSectionReader(body, 0, size), nil
+		}, nil
+
 	case io.ReadSeeker:
 		return func() (io.Reader, error) {
 			_, err := body.Seek(0, 0)
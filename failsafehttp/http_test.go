@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -65,6 +69,41 @@ func TestBodyReader(t *testing.T) {
 	}
 }
 
+// Asserts that a request's GetBody func is used to get a new body for each attempt, such as for streaming bodies.
+func TestRequestWithGetBody(t *testing.T) {
+	// Given
+	var requests atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	var getBodyCalls int
+	newBody := func() (io.ReadCloser, error) {
+		getBodyCalls++
+		// Wrap the reader so that it's only an io.Reader, as with a stream
+		return io.NopCloser(io.MultiReader(strings.NewReader("streamed body"))), nil
+	}
+	body, _ := newBody()
+	req, _ := http.NewRequest(http.MethodPut, server.URL, body)
+	req.GetBody = newBody
+	getBodyCalls = 0
+	rt := NewRoundTripper(nil, RetryPolicyBuilder().Build())
+
+	// When
+	resp, err := rt.RoundTrip(req)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 2, getBodyCalls)
+	assert.Equal(t, []string{"streamed body", "streamed body"}, bodies)
+}
+
 func TestSuccess(t *testing.T) {
 	// Given
 	server := testutil.MockResponse(200, "foo")
@@ -183,6 +222,46 @@ func TestRetryPolicyFallback(t *testing.T) {
 	}
 }
 
+// Asserts that responses can be retried based on their body, and that the body can still be read afterwards.
+func TestRetryPolicyWithResponseBody(t *testing.T) {
+	// Given
+	server, setup := testutil.MockFlakyServer(0, 200, 0, "retry")
+	rp := RetryPolicyBuilder().
+		HandleIf(HandleResponseIf(func(response *http.Response) bool {
+			body, _ := io.ReadAll(response.Body)
+			return string(body) == "retry"
+		})).
+		ReturnLastFailure().
+		Build()
+
+	// When / Then
+	test(t, server).
+		Setup(setup).
+		With(rp).
+		AssertFailureResult(3, 3, 200, "retry")
+}
+
+// Asserts that responses whose body is larger than the max buffered bytes are not handled, and that their body can still
+// be read afterwards.
+func TestRetryPolicyWithLargeResponseBody(t *testing.T) {
+	// Given
+	server := testutil.MockResponse(200, "retry")
+	var called atomic.Bool
+	rp := RetryPolicyBuilder().
+		HandleIf(HandleResponseIf(func(response *http.Response) bool {
+			called.Store(true)
+			return true
+		}, WithMaxBufferedBytes(3))).
+		Build()
+
+	// When / Then
+	test(t, server).
+		With(rp).
+		AssertSuccess(1, 1, 200, "retry", func() {
+			assert.False(t, called.Load())
+		})
+}
+
 // Asserts that an open circuit breaker prevents executions from occurring, even with outer retries.
 func TestCircuitBreaker(t *testing.T) {
 	// Given
@@ -197,11 +276,83 @@ func TestCircuitBreaker(t *testing.T) {
 		AssertFailure(3, 0, circuitbreaker.ErrOpen)
 }
 
+// Asserts that WithHostCircuitBreakers opens a separate circuit for each host.
+func TestHostCircuitBreakers(t *testing.T) {
+	// Given
+	failing := testutil.MockResponse(500, "failure")
+	defer failing.Close()
+	healthy := testutil.MockResponse(200, "success")
+	defer healthy.Close()
+	builder := circuitbreaker.Builder[*http.Response]().
+		HandleIf(func(response *http.Response, err error) bool {
+			return response != nil && response.StatusCode >= 500
+		})
+	rt := NewRoundTripperWithExecutor(nil, failsafe.NewExecutor[*http.Response](), WithHostCircuitBreakers(builder, 10))
+
+	// When / Then
+	resp, err := rt.RoundTrip(newGetRequest(failing.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+	resp, err = rt.RoundTrip(newGetRequest(failing.URL))
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	resp, err = rt.RoundTrip(newGetRequest(healthy.URL))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+// Asserts that the bodies of retried responses are drained and closed, so that their connections are reused.
+func TestResponseDraining(t *testing.T) {
+	tests := []struct {
+		name                string
+		opts                []Option
+		expectedConnections int
+	}{
+		{"with draining", nil, 1},
+		{"without draining", []Option{WithResponseDraining(false)}, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Given
+			var requests, connections atomic.Int32
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					fmt.Fprint(w, strings.Repeat("x", 1024))
+					return
+				}
+				fmt.Fprint(w, "success")
+			}))
+			server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					connections.Add(1)
+				}
+			}
+			server.Start()
+			defer server.Close()
+			transport := &http.Transport{}
+			defer transport.CloseIdleConnections()
+			executor := failsafe.NewExecutor[*http.Response](RetryPolicyBuilder().Build())
+			rt := NewRoundTripperWithExecutor(transport, executor, tc.opts...)
+
+			// When
+			resp, err := rt.RoundTrip(newGetRequest(server.URL))
+
+			// Then
+			assert.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+			resp.Body.Close()
+			assert.Equal(t, int32(tc.expectedConnections), connections.Load())
+		})
+	}
+}
+
 func TestHedgePolicy(t *testing.T) {
 	// Given
 	server := testutil.MockDelayedResponse(200, "foo", 100*time.Millisecond)
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[*http.Response](80*time.Millisecond), stats).Build()
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[*http.Response](80*time.Millisecond), stats).Build()
 
 	// When / Then
 	test(t, server).
@@ -212,6 +363,48 @@ func TestHedgePolicy(t *testing.T) {
 		})
 }
 
+// Asserts that hedges are sent with a hedge header, and that a file body is read separately by each hedge.
+func TestHedgePolicyWithHedgeHeaderAndFileBody(t *testing.T) {
+	// Given
+	var mtx sync.Mutex
+	headers := map[string]string{}
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mtx.Lock()
+		headers[string(body)+r.Header.Get("X-Hedge-Attempt")] = r.Header.Get("X-Hedge-Attempt")
+		mtx.Unlock()
+		if requests.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		fmt.Fprint(w, "foo")
+	}))
+	defer server.Close()
+	file, err := os.CreateTemp(t.TempDir(), "body")
+	assert.NoError(t, err)
+	_, err = file.WriteString("file body")
+	assert.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodPost, server.URL, nil)
+	req.Body = file
+	hp := hedgepolicy.BuilderWithDelay[*http.Response](20 * time.Millisecond).Build()
+	executor := failsafe.NewExecutor[*http.Response](hp)
+	rt := NewRoundTripperWithExecutor(nil, executor, WithHedgeHeader("X-Hedge-Attempt"), WithRetryNonIdempotent(true))
+
+	// When
+	resp, err := rt.RoundTrip(req)
+
+	// Then
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Eventually(t, func() bool {
+		return requests.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, map[string]string{"file body": "", "file body2": "2"}, headers)
+	assert.Empty(t, req.Header.Get("X-Hedge-Attempt"))
+}
+
 // Asserts that providing a context to either the executor or a request that is canceled results in the execution being canceled.
 func TestCancelWithContext(t *testing.T) {
 	slowCtxFn := testutil.SetupWithContextSleep(time.Second)
@@ -0,0 +1,66 @@
+package failsafehttp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header that carries a key which allows a server to safely deduplicate retried requests.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// isIdempotent returns whether a request with the method and header can be safely retried, which is the case for
+// idempotent methods and for requests that carry an Idempotency-Key.
+func isIdempotent(method string, header http.Header) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return header.Get(IdempotencyKeyHeader) != ""
+}
+
+// SetIdempotencyKey sets a random Idempotency-Key header on the request if its method is not idempotent and it does not
+// already have a key. Since the header is set on the request before it's executed, the same key is sent with each
+// attempt, allowing a RetryPolicy to retry the request.
+func SetIdempotencyKey(request *http.Request) {
+	if isIdempotent(request.Method, request.Header) {
+		return
+	}
+	if request.Header == nil {
+		request.Header = make(http.Header)
+	}
+	request.Header.Set(IdempotencyKeyHeader, newIdempotencyKey())
+}
+
+type idempotencyKeyRoundTripper struct {
+	next http.RoundTripper
+}
+
+// NewIdempotencyKeyRoundTripper returns a new http.RoundTripper that adds a random Idempotency-Key header to requests
+// whose method is not idempotent and that do not already have a key, before passing them to the innerRoundTripper. When
+// the innerRoundTripper is a failsafe RoundTripper, the same key is sent with each attempt. If innerRoundTripper is nil,
+// http.DefaultTransport will be used.
+func NewIdempotencyKeyRoundTripper(innerRoundTripper http.RoundTripper) http.RoundTripper {
+	if innerRoundTripper == nil {
+		innerRoundTripper = http.DefaultTransport
+	}
+	return &idempotencyKeyRoundTripper{next: innerRoundTripper}
+}
+
+func (r *idempotencyKeyRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !isIdempotent(request.Method, request.Header) {
+		// Clone the request since a RoundTripper should not modify it
+		request = request.Clone(request.Context())
+		SetIdempotencyKey(request)
+	}
+	return r.next.RoundTrip(request)
+}
+
+// newIdempotencyKey returns a random version 4 UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,86 @@
+package failsafehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+func TestRetryPolicyWithNonIdempotentRequests(t *testing.T) {
+	// Given
+	var mtx sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		mtx.Lock()
+		keys = append(keys, request.Header.Get(IdempotencyKeyHeader))
+		mtx.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	post := func(rt http.RoundTripper) []string {
+		keys = nil
+		client := http.Client{Transport: rt}
+		resp, err := client.Post(server.URL, "text/plain", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		resp.Body.Close()
+		return keys
+	}
+	rp := RetryPolicyBuilder().ReturnLastFailure().Build()
+
+	t.Run("should not retry non-idempotent requests", func(t *testing.T) {
+		assert.Equal(t, []string{""}, post(NewRoundTripper(nil, rp)))
+	})
+
+	t.Run("should retry requests with idempotency keys", func(t *testing.T) {
+		keys := post(NewIdempotencyKeyRoundTripper(NewRoundTripper(nil, rp)))
+		assert.Len(t, keys, 3)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1])
+		assert.Equal(t, keys[0], keys[2])
+	})
+
+	t.Run("should retry non-idempotent requests when configured", func(t *testing.T) {
+		executor := failsafe.NewExecutor[*http.Response](rp)
+		assert.Len(t, post(NewRoundTripperWithExecutor(nil, executor, WithRetryNonIdempotent(true))), 3)
+	})
+}
+
+func TestRetryPolicyWithNonIdempotentRequestError(t *testing.T) {
+	// Given
+	attempts := 0
+	executor := failsafe.NewExecutor[*http.Response](RetryPolicyBuilder().Build()).
+		OnDone(func(e failsafe.ExecutionDoneEvent[*http.Response]) {
+			attempts = e.Attempts()
+		})
+	request, _ := http.NewRequest(http.MethodPost, "http://localhost:55555", nil)
+
+	// When
+	resp, err := NewRequestWithExecutor(request, http.DefaultClient, executor).Do()
+
+	// Then
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.IsType(t, (*url.Error)(nil), err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestSetIdempotencyKey(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	SetIdempotencyKey(get)
+	assert.Empty(t, get.Header.Get(IdempotencyKeyHeader))
+
+	post, _ := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	SetIdempotencyKey(post)
+	key := post.Header.Get(IdempotencyKeyHeader)
+	assert.Len(t, key, 36)
+	SetIdempotencyKey(post)
+	assert.Equal(t, key, post.Header.Get(IdempotencyKeyHeader))
+}
@@ -3,10 +3,10 @@ package failsafehttp
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 	"net/http"
 	"net/url"
 	"regexp"
-	"strconv"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
@@ -22,16 +22,25 @@ var (
 // RetryPolicyBuilder returns a retrypolicy.RetryPolicyBuilder that will retry non-terminal HTTP errors and responses up
 // to 2 times, by default. If a Retry-After header is present in the response, it will be used as a delay between
 // retries. Additional handling and delay configuration can be added to the resulting builder.
+//
+// Failsafe RoundTrippers and Requests mark requests with non-idempotent methods, which do not have an Idempotency-Key
+// header, as not idempotent via failsafe.MarkIdempotent, so that they are not retried. See SetIdempotencyKey,
+// NewIdempotencyKeyRoundTripper, and WithRetryNonIdempotent.
 func RetryPolicyBuilder() retrypolicy.RetryPolicyBuilder[*http.Response] {
 	retryHandleFunc := func(resp *http.Response, err error) bool {
 		// Handle errors
 		if err != nil {
+			// Do not retry responses that are too large
+			if errors.Is(err, ErrResponseTooLarge) {
+				return false
+			}
 			// Do not retry unsupported protocol scheme error
 			// This will be a url.Error when using an http.Client, and an errorString when using a RoundTripper
 			if unsupportedScheme.MatchString(err.Error()) {
 				return false
 			}
-			if v, ok := err.(*url.Error); ok {
+			var v *url.Error
+			if errors.As(err, &v) {
 				// Do not retry when certain error messages are observed
 				if certNotTrusted.MatchString(v.Error()) ||
 					stoppedAfterRedirects.MatchString(v.Error()) {
@@ -67,14 +76,13 @@ func RetryPolicyBuilder() retrypolicy.RetryPolicyBuilder[*http.Response] {
 		WithDelayFunc(DelayFunc)
 }
 
-// DelayFunc delays according to an http.Response Retry-After header. This can be used as a delay in a RetryPolicy or a CircuitBreaker.
+// DelayFunc delays according to an http.Response Retry-After header, which can be either a number of seconds or an HTTP
+// date. This can be used as a delay in a RetryPolicy or a CircuitBreaker.
 func DelayFunc(exec failsafe.ExecutionAttempt[*http.Response]) time.Duration {
 	resp := exec.LastResult()
 	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
-		if header, ok := resp.Header["Retry-After"]; ok {
-			if seconds, err := strconv.Atoi(header[0]); err == nil {
-				return time.Second * time.Duration(seconds)
-			}
+		if delay, ok := RetryAfter(resp); ok {
+			return delay
 		}
 	}
 
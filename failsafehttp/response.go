@@ -0,0 +1,109 @@
+package failsafehttp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBufferedBytes is the default max size of a response body that HandleResponseIf buffers.
+const DefaultMaxBufferedBytes = 1 << 20
+
+// HandleResponseOption configures HandleResponseIf.
+type HandleResponseOption func(*handleResponseConfig)
+
+type handleResponseConfig struct {
+	maxBufferedBytes int64
+}
+
+// WithMaxBufferedBytes configures the max size of a response body that HandleResponseIf buffers. Defaults to
+// DefaultMaxBufferedBytes.
+func WithMaxBufferedBytes(maxBytes int64) HandleResponseOption {
+	return func(c *handleResponseConfig) {
+		c.maxBufferedBytes = maxBytes
+	}
+}
+
+// HandleResponseIf returns a predicate that calls the responsePredicate with execution responses, which can be used with
+// HandleIf on policy builders such as RetryPolicyBuilder. This allows policies to handle responses based on their body,
+// such as an error code in a JSON body. The response body is buffered in memory so that the responsePredicate can read
+// it, and is restored afterwards so that other predicates and the caller can read it again.
+//
+// Response bodies that are larger than the max buffered bytes, which defaults to DefaultMaxBufferedBytes, are not
+// handled and the responsePredicate is not called. The part of such a body that was read is restored, and the rest is
+// streamed when the body is read again. See WithMaxBufferedBytes.
+func HandleResponseIf(responsePredicate func(*http.Response) bool, options ...HandleResponseOption) func(*http.Response, error) bool {
+	config := &handleResponseConfig{maxBufferedBytes: DefaultMaxBufferedBytes}
+	for _, option := range options {
+		option(config)
+	}
+
+	return func(resp *http.Response, err error) bool {
+		if resp == nil {
+			return false
+		}
+		body, ok := bufferBody(resp, config.maxBufferedBytes)
+		if !ok {
+			return false
+		}
+		defer body.rewind()
+		return responsePredicate(resp)
+	}
+}
+
+// bufferedBody is a response body that has been read into memory and can be rewound to be read again.
+type bufferedBody struct {
+	*bytes.Reader
+	buf []byte
+	// The error, if any, that occurred when reading the original body, which is returned after the buf is read
+	err error
+}
+
+func (b *bufferedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF && b.err != nil {
+		return n, b.err
+	}
+	return n, err
+}
+
+func (b *bufferedBody) Close() error {
+	return nil
+}
+
+func (b *bufferedBody) rewind() {
+	b.Reader.Reset(b.buf)
+}
+
+// partialBody is a response body that was too large to buffer, whose read part is followed by the rest of the body.
+type partialBody struct {
+	io.Reader
+	io.Closer
+}
+
+// bufferBody replaces the resp body with a bufferedBody, if it's not one already, and returns it rewound. If the body is
+// larger than maxBytes, it's replaced with a partialBody instead, and false is returned.
+func bufferBody(resp *http.Response, maxBytes int64) (*bufferedBody, bool) {
+	switch body := resp.Body.(type) {
+	case *bufferedBody:
+		body.rewind()
+		return body, true
+	case *partialBody:
+		return nil, false
+	}
+	body := &bufferedBody{}
+	if resp.Body != nil {
+		body.buf, body.err = io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+		if int64(len(body.buf)) > maxBytes {
+			resp.Body = &partialBody{
+				Reader: io.MultiReader(bytes.NewReader(body.buf), resp.Body),
+				Closer: resp.Body,
+			}
+			return nil, false
+		}
+		resp.Body.Close()
+	}
+	body.Reader = bytes.NewReader(body.buf)
+	resp.Body = body
+	return body, true
+}
@@ -0,0 +1,127 @@
+package failsafehttp
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// RouterBuilder builds http.RoundTrippers that select policies for requests based on their method, host, and path. This
+// allows a single http.Client to apply different policies to different upstream routes.
+//
+// This type is not concurrency safe.
+type RouterBuilder interface {
+	// WithRoute configures the policies to use for requests that match the pattern. Patterns have the form
+	// "[METHOD ][HOST]/PATH", such as "GET /api/v1/users/*" or "api.example.com/orders". The method and host are optional,
+	// and match any method or host when omitted. The path is matched using path.Match, where * matches any sequence of
+	// characters within a path segment. Routes are matched in the order they're configured, and the first match is used.
+	// Panics if the pattern is malformed.
+	WithRoute(pattern string, policies ...failsafe.Policy[*http.Response]) RouterBuilder
+
+	// WithRouteExecutor configures the executor to use for requests that match the pattern. See WithRoute for the
+	// pattern syntax.
+	WithRouteExecutor(pattern string, executor failsafe.Executor[*http.Response]) RouterBuilder
+
+	// WithDefault configures the policies to use for requests that do not match any route. By default, requests that do
+	// not match any route are performed without any policies.
+	WithDefault(policies ...failsafe.Policy[*http.Response]) RouterBuilder
+
+	// Build returns a new http.RoundTripper using the builder's configuration.
+	Build() http.RoundTripper
+}
+
+type route struct {
+	method   string
+	host     string
+	path     string
+	executor failsafe.Executor[*http.Response]
+}
+
+func (r *route) matches(request *http.Request) bool {
+	if r.method != "" && r.method != request.Method {
+		return false
+	}
+	if r.host != "" && r.host != request.URL.Host {
+		return false
+	}
+	matched, _ := path.Match(r.path, request.URL.Path)
+	return matched
+}
+
+type routerConfig struct {
+	next            http.RoundTripper
+	routes          []*route
+	defaultExecutor failsafe.Executor[*http.Response]
+}
+
+var _ RouterBuilder = &routerConfig{}
+
+// NewRouterBuilder returns a new RouterBuilder that performs round trips via the innerRoundTripper. If innerRoundTripper
+// is nil, http.DefaultTransport will be used.
+func NewRouterBuilder(innerRoundTripper http.RoundTripper) RouterBuilder {
+	if innerRoundTripper == nil {
+		innerRoundTripper = http.DefaultTransport
+	}
+	return &routerConfig{
+		next: innerRoundTripper,
+	}
+}
+
+func (c *routerConfig) WithRoute(pattern string, policies ...failsafe.Policy[*http.Response]) RouterBuilder {
+	return c.WithRouteExecutor(pattern, failsafe.NewExecutor(policies...))
+}
+
+func (c *routerConfig) WithRouteExecutor(pattern string, executor failsafe.Executor[*http.Response]) RouterBuilder {
+	r := &route{executor: executor}
+	if method, rest, ok := strings.Cut(pattern, " "); ok {
+		r.method = method
+		pattern = strings.TrimSpace(rest)
+	}
+	slashIdx := strings.Index(pattern, "/")
+	if slashIdx == -1 {
+		panic(fmt.Sprintf("route pattern %q must contain a path", pattern))
+	}
+	r.host = pattern[:slashIdx]
+	r.path = pattern[slashIdx:]
+	if _, err := path.Match(r.path, ""); err != nil {
+		panic(fmt.Sprintf("route pattern %q is malformed: %v", pattern, err))
+	}
+	c.routes = append(c.routes, r)
+	return c
+}
+
+func (c *routerConfig) WithDefault(policies ...failsafe.Policy[*http.Response]) RouterBuilder {
+	c.defaultExecutor = failsafe.NewExecutor(policies...)
+	return c
+}
+
+func (c *routerConfig) Build() http.RoundTripper {
+	return &router{
+		next:            c.next,
+		routes:          append([]*route(nil), c.routes...),
+		defaultExecutor: c.defaultExecutor,
+	}
+}
+
+type router struct {
+	next            http.RoundTripper
+	routes          []*route
+	defaultExecutor failsafe.Executor[*http.Response]
+}
+
+func (r *router) RoundTrip(request *http.Request) (*http.Response, error) {
+	executor := r.defaultExecutor
+	for _, rt := range r.routes {
+		if rt.matches(request) {
+			executor = rt.executor
+			break
+		}
+	}
+	if executor == nil {
+		return r.next.RoundTrip(request)
+	}
+	return doRequest(request, executor, defaultOptions, r.next.RoundTrip)
+}
@@ -0,0 +1,86 @@
+package failsafehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+func TestRouter(t *testing.T) {
+	// Given
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	cb := circuitbreaker.WithDefaults[*http.Response]()
+	cb.Open()
+	client := http.Client{Transport: NewRouterBuilder(nil).
+		WithRoute("GET /users/*", RetryPolicyBuilder().ReturnLastFailure().Build()).
+		WithRoute("/orders", cb).
+		Build()}
+	do := func(method string, path string) (*http.Response, error) {
+		requests.Store(0)
+		req, _ := http.NewRequest(method, server.URL+path, nil)
+		resp, err := client.Do(req)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	// When / Then
+	_, err := do(http.MethodGet, "/users/1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+
+	_, err = do(http.MethodPost, "/users/1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	_, err = do(http.MethodGet, "/users/1/orders")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	_, err = do(http.MethodPost, "/orders")
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	assert.Equal(t, int32(0), requests.Load())
+}
+
+func TestRouterWithDefault(t *testing.T) {
+	// Given
+	requests := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client := http.Client{Transport: NewRouterBuilder(nil).
+		WithRoute("/none", retrypolicy.WithDefaults[*http.Response]()).
+		WithDefault(RetryPolicyBuilder().WithMaxRetries(1).ReturnLastFailure().Build()).
+		Build()}
+
+	// When
+	resp, err := client.Get(server.URL + "/other")
+
+	// Then
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestRouterWithMalformedPattern(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRouterBuilder(nil).WithRoute("GET users")
+	})
+	assert.Panics(t, func() {
+		NewRouterBuilder(nil).WithRoute("/users/[")
+	})
+}
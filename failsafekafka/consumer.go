@@ -0,0 +1,113 @@
+package failsafekafka
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+)
+
+// defaultPauseDelay is how long a Consumer pauses when a CircuitBreaker rejects an execution and its remaining delay is
+// not known.
+const defaultPauseDelay = time.Second
+
+// Reader fetches and commits messages of type M from Kafka. This is implemented by segmentio/kafka-go's *kafka.Reader
+// when used with a consumer group.
+type Reader[M any] interface {
+	FetchMessage(ctx context.Context) (M, error)
+	CommitMessages(ctx context.Context, msgs ...M) error
+}
+
+// delayer is implemented by policies, such as CircuitBreaker, that can report how long until they allow executions.
+type delayer interface {
+	RemainingDelay() time.Duration
+}
+
+// Consumer consumes messages from Kafka, handling each via failsafe policies. Messages are handled one at a time, and
+// each message is committed only after it has been successfully handled, so that its offset is not committed if
+// handling fails.
+//
+// When a CircuitBreaker, such as one that guards a downstream dependency, is open, the Consumer pauses consumption until
+// the breaker allows executions again, then handles the same message again. This avoids fetching and failing messages
+// while the downstream is unavailable.
+//
+// This type is concurrency safe, but a Reader should only be consumed by one Consumer.
+type Consumer[M any] struct {
+	reader   Reader[M]
+	executor failsafe.Executor[any]
+	delayers []delayer
+}
+
+// NewConsumer returns a new Consumer that fetches messages with the reader and handles them via the policies. The
+// policies are composed around message handling and will handle its results in reverse order. When a CircuitBreaker in
+// the policies is open, the Consumer pauses for its remaining delay, else for 1 second.
+func NewConsumer[M any](reader Reader[M], policies ...failsafe.Policy[any]) *Consumer[M] {
+	consumer := NewConsumerWithExecutor(reader, failsafe.NewExecutor(policies...))
+	for _, p := range policies {
+		if d, ok := p.(delayer); ok {
+			consumer.delayers = append(consumer.delayers, d)
+		}
+	}
+	return consumer
+}
+
+// NewConsumerWithExecutor returns a new Consumer that fetches messages with the reader and handles them via the
+// executor. Since the executor's policies are not known, the Consumer pauses for 1 second when a CircuitBreaker is open.
+func NewConsumerWithExecutor[M any](reader Reader[M], executor failsafe.Executor[any]) *Consumer[M] {
+	return &Consumer[M]{
+		reader:   reader,
+		executor: executor,
+	}
+}
+
+// Consume fetches messages and handles them with the handler until the ctx is done or an error occurs. Each message is
+// handled until successful or until the policies are exceeded, and then committed. If handling a message fails, Consume
+// returns the error without committing the message, so that it will be consumed again later. A Fallback can be used to
+// handle messages that cannot be processed, such as by writing them to a dead letter topic, so that consumption continues.
+func (c *Consumer[M]) Consume(ctx context.Context, handler func(ctx context.Context, msg M) error) error {
+	executor := c.executor.WithContext(ctx)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			return err
+		}
+		for {
+			err = executor.RunWithExecution(func(exec failsafe.Execution[any]) error {
+				return handler(exec.Context(), msg)
+			})
+			if !errors.Is(err, circuitbreaker.ErrOpen) {
+				break
+			}
+			if err = c.pause(ctx); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err = c.reader.CommitMessages(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// pause waits until the Consumer's circuit breakers allow executions, or until the ctx is done.
+func (c *Consumer[M]) pause(ctx context.Context) error {
+	delay := time.Duration(0)
+	for _, d := range c.delayers {
+		delay = max(delay, d.RemainingDelay())
+	}
+	if delay == 0 {
+		delay = defaultPauseDelay
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
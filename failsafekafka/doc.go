@@ -0,0 +1,4 @@
+// Package failsafekafka provides functions that can be used to integrate Failsafe-go with Kafka clients. Producers and
+// consumers are defined in terms of small Writer and Reader interfaces, which clients such as segmentio/kafka-go's
+// *kafka.Writer and *kafka.Reader implement, where M is the client's message type.
+package failsafekafka
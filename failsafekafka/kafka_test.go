@@ -0,0 +1,137 @@
+package failsafekafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+type mockWriter struct {
+	errs    []error
+	written []string
+}
+
+func (w *mockWriter) WriteMessages(_ context.Context, msgs ...string) error {
+	if len(w.errs) > 0 {
+		err := w.errs[0]
+		w.errs = w.errs[1:]
+		return err
+	}
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+// mockReader fetches its msgs in order, returning errNoMessages when there are none left.
+type mockReader struct {
+	mtx       sync.Mutex
+	msgs      []string
+	committed []string
+}
+
+var errNoMessages = errors.New("no messages")
+
+func (r *mockReader) FetchMessage(context.Context) (string, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if len(r.msgs) == 0 {
+		return "", errNoMessages
+	}
+	msg := r.msgs[0]
+	r.msgs = r.msgs[1:]
+	return msg, nil
+}
+
+func (r *mockReader) CommitMessages(_ context.Context, msgs ...string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func TestProduce(t *testing.T) {
+	// Given
+	writer := &mockWriter{errs: []error{testutil.ErrConnecting, testutil.ErrConnecting}}
+	producer := NewProducer[string](writer, retrypolicy.WithDefaults[any]())
+
+	// When
+	err := producer.Produce(context.Background(), "foo", "bar")
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar"}, writer.written)
+}
+
+func TestConsume(t *testing.T) {
+	// Given
+	reader := &mockReader{msgs: []string{"foo", "bar", "baz"}}
+	consumer := NewConsumer[string](reader, retrypolicy.WithDefaults[any]())
+	attempts := 0
+	var handled []string
+
+	// When
+	err := consumer.Consume(context.Background(), func(ctx context.Context, msg string) error {
+		attempts++
+		if msg == "bar" && attempts < 3 {
+			return testutil.ErrConnecting
+		}
+		if msg == "baz" {
+			return testutil.ErrInvalidArgument
+		}
+		handled = append(handled, msg)
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, retrypolicy.ErrExceeded)
+	assert.Equal(t, []string{"foo", "bar"}, handled)
+	assert.Equal(t, []string{"foo", "bar"}, reader.committed)
+}
+
+// Asserts that consumption pauses while a circuit breaker is open, and resumes with the same message.
+func TestConsumePausesWhileCircuitBreakerOpen(t *testing.T) {
+	// Given
+	reader := &mockReader{msgs: []string{"foo"}}
+	cb := circuitbreaker.Builder[any]().WithDelay(100 * time.Millisecond).Build()
+	cb.Open()
+	consumer := NewConsumer[string](reader, cb)
+	var handled []string
+
+	// When
+	start := time.Now()
+	err := consumer.Consume(context.Background(), func(ctx context.Context, msg string) error {
+		handled = append(handled, msg)
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, errNoMessages)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, []string{"foo"}, handled)
+	assert.Equal(t, []string{"foo"}, reader.committed)
+}
+
+func TestConsumeCancelWhilePaused(t *testing.T) {
+	// Given
+	reader := &mockReader{msgs: []string{"foo"}}
+	cb := circuitbreaker.Builder[any]().WithDelay(time.Minute).Build()
+	cb.Open()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// When
+	err := NewConsumer[string](reader, cb).Consume(ctx, func(ctx context.Context, msg string) error {
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, reader.committed)
+}
@@ -0,0 +1,42 @@
+package failsafekafka
+
+import (
+	"context"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// Writer writes messages of type M to Kafka. This is implemented by segmentio/kafka-go's *kafka.Writer.
+type Writer[M any] interface {
+	WriteMessages(ctx context.Context, msgs ...M) error
+}
+
+// Producer writes messages to Kafka via failsafe policies.
+//
+// This type is concurrency safe.
+type Producer[M any] struct {
+	writer   Writer[M]
+	executor failsafe.Executor[any]
+}
+
+// NewProducer returns a new Producer that writes messages with the writer via the policies. The policies are composed
+// around writes and will handle their results in reverse order.
+func NewProducer[M any](writer Writer[M], policies ...failsafe.Policy[any]) *Producer[M] {
+	return NewProducerWithExecutor(writer, failsafe.NewExecutor(policies...))
+}
+
+// NewProducerWithExecutor returns a new Producer that writes messages with the writer via the executor.
+func NewProducerWithExecutor[M any](writer Writer[M], executor failsafe.Executor[any]) *Producer[M] {
+	return &Producer[M]{
+		writer:   writer,
+		executor: executor,
+	}
+}
+
+// Produce writes the msgs until successful or until the policies are exceeded. Since each attempt writes all of the
+// msgs, producers should be idempotent if partially failed writes may be retried.
+func (p *Producer[M]) Produce(ctx context.Context, msgs ...M) error {
+	return p.executor.WithContext(ctx).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		return p.writer.WriteMessages(exec.Context(), msgs...)
+	})
+}
@@ -0,0 +1,91 @@
+package failsafenet
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// ContextDialer dials connections, such as a *net.Dialer.
+type ContextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// Dialer dials connections via failsafe policies. A Dialer's DialContext can be used anywhere connections are dialed,
+// such as with an http.Transport, a database driver, or a custom TCP client:
+//
+//	dialer := failsafenet.NewDialer(&net.Dialer{}, retryPolicy)
+//	transport := &http.Transport{DialContext: dialer.DialContext}
+//
+// This type is concurrency safe.
+type Dialer struct {
+	dialer     ContextDialer
+	executor   failsafe.Executor[net.Conn]
+	policiesFn func(address string) []failsafe.Policy[net.Conn]
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	executors map[string]failsafe.Executor[net.Conn]
+}
+
+// NewDialer returns a new Dialer that dials connections with the dialer via the policies. The policies are composed
+// around each dial and will handle their results in reverse order. The same policies are used for every address, so
+// stateful policies, such as a CircuitBreaker, are shared across addresses. Use NewPerAddressDialer to use separate
+// policies for each address.
+func NewDialer(dialer ContextDialer, policies ...failsafe.Policy[net.Conn]) *Dialer {
+	return &Dialer{
+		dialer:   dialer,
+		executor: failsafe.NewExecutor(policies...),
+	}
+}
+
+// NewPerAddressDialer returns a new Dialer that dials connections with the dialer via policies that are created for each
+// target address by the policiesFn. The policiesFn is called the first time an address is dialed, and the resulting
+// policies are reused for later dials to the address, so that stateful policies, such as a CircuitBreaker, track each
+// address separately:
+//
+//	dialer := failsafenet.NewPerAddressDialer(&net.Dialer{}, func(address string) []failsafe.Policy[net.Conn] {
+//	  return []failsafe.Policy[net.Conn]{retryPolicy, circuitbreaker.WithDefaults[net.Conn]()}
+//	})
+func NewPerAddressDialer(dialer ContextDialer, policiesFn func(address string) []failsafe.Policy[net.Conn]) *Dialer {
+	return &Dialer{
+		dialer:     dialer,
+		policiesFn: policiesFn,
+		executors:  make(map[string]failsafe.Executor[net.Conn]),
+	}
+}
+
+// Dial dials the address on the network until successful or until the policies are exceeded.
+func (d *Dialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext dials the address on the network until successful or until the policies are exceeded. Connections that
+// are dialed by attempts that were canceled, such as by a Timeout or HedgePolicy, are closed.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d.executorFor(address).WithContext(ctx).GetWithExecution(func(exec failsafe.Execution[net.Conn]) (net.Conn, error) {
+		conn, err := d.dialer.DialContext(exec.Context(), network, address)
+		if err == nil && exec.IsCanceled() {
+			// The attempt's result will be discarded
+			_ = conn.Close()
+			return nil, context.Canceled
+		}
+		return conn, err
+	})
+}
+
+func (d *Dialer) executorFor(address string) failsafe.Executor[net.Conn] {
+	if d.policiesFn == nil {
+		return d.executor
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	executor, ok := d.executors[address]
+	if !ok {
+		executor = failsafe.NewExecutor(d.policiesFn(address)...)
+		d.executors[address] = executor
+	}
+	return executor
+}
@@ -0,0 +1,145 @@
+package failsafenet
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+var errDial = errors.New("dial failed")
+
+// mockDialer fails dials to addresses in its failures until they're exhausted, and otherwise returns a mockConn after
+// its delay.
+type mockDialer struct {
+	delay    time.Duration
+	mtx      sync.Mutex
+	failures map[string]int
+	dials    map[string]int
+	conns    []*mockConn
+}
+
+func (d *mockDialer) DialContext(_ context.Context, _, address string) (net.Conn, error) {
+	d.mtx.Lock()
+	if d.dials == nil {
+		d.dials = make(map[string]int)
+	}
+	d.dials[address]++
+	if d.failures[address] > 0 {
+		d.failures[address]--
+		d.mtx.Unlock()
+		return nil, errDial
+	}
+	d.mtx.Unlock()
+
+	time.Sleep(d.delay)
+	conn, _ := net.Pipe()
+	mc := &mockConn{Conn: conn}
+	d.mtx.Lock()
+	d.conns = append(d.conns, mc)
+	d.mtx.Unlock()
+	return mc, nil
+}
+
+func (d *mockDialer) dialsTo(address string) int {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.dials[address]
+}
+
+type mockConn struct {
+	net.Conn
+	closed atomic.Bool
+}
+
+func (c *mockConn) Close() error {
+	c.closed.Store(true)
+	return c.Conn.Close()
+}
+
+func TestDialerWithRetries(t *testing.T) {
+	// Given
+	mock := &mockDialer{failures: map[string]int{"host:1": 2}}
+	dialer := NewDialer(mock, retrypolicy.WithDefaults[net.Conn]())
+
+	// When
+	conn, err := dialer.Dial("tcp", "host:1")
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotNil(t, conn)
+	assert.Equal(t, 3, mock.dialsTo("host:1"))
+}
+
+func TestDialerWithRealConnection(t *testing.T) {
+	// Given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+	dialer := NewDialer(&net.Dialer{}, retrypolicy.WithDefaults[net.Conn]())
+
+	// When
+	conn, err := dialer.DialContext(context.Background(), "tcp", listener.Addr().String())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+	conn.Close()
+}
+
+func TestPerAddressDialer(t *testing.T) {
+	// Given
+	mock := &mockDialer{failures: map[string]int{"host:1": 10}}
+	var created []string
+	dialer := NewPerAddressDialer(mock, func(address string) []failsafe.Policy[net.Conn] {
+		created = append(created, address)
+		return []failsafe.Policy[net.Conn]{
+			circuitbreaker.Builder[net.Conn]().WithDelay(time.Minute).Build(),
+		}
+	})
+
+	// When
+	_, err := dialer.Dial("tcp", "host:1")
+	assert.ErrorIs(t, err, errDial)
+	_, err = dialer.Dial("tcp", "host:1")
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+	conn, err := dialer.Dial("tcp", "host:2")
+
+	// Then
+	assert.NoError(t, err)
+	assert.NotNil(t, conn)
+	assert.Equal(t, 1, mock.dialsTo("host:1"))
+	assert.Equal(t, []string{"host:1", "host:2"}, created)
+}
+
+func TestDialerClosesCanceledConnections(t *testing.T) {
+	// Given
+	mock := &mockDialer{delay: 100 * time.Millisecond}
+	dialer := NewDialer(mock, timeout.With[net.Conn](10*time.Millisecond))
+
+	// When
+	_, err := dialer.Dial("tcp", "host:1")
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	assert.Eventually(t, func() bool {
+		mock.mtx.Lock()
+		defer mock.mtx.Unlock()
+		return len(mock.conns) == 1 && mock.conns[0].closed.Load()
+	}, time.Second, 10*time.Millisecond)
+}
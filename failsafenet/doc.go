@@ -0,0 +1,2 @@
+// Package failsafenet provides functions that can be used to integrate Failsafe-go with net connections.
+package failsafenet
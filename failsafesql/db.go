@@ -0,0 +1,83 @@
+package failsafesql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+type queryKey struct{}
+
+// QueryFromContext returns the query that an execution is performing, if any. This can be used with the Context of
+// policy and executor events to identify the query that an event is for. For transactions performed with DB.InTx, the
+// query is empty.
+func QueryFromContext(ctx context.Context) (string, bool) {
+	query, ok := ctx.Value(queryKey{}).(string)
+	return query, ok
+}
+
+func withQuery(ctx context.Context, query string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, queryKey{}, query)
+}
+
+// DB performs queries and transactions against a sql.DB via failsafe policies.
+//
+// This type is concurrency safe.
+type DB struct {
+	db       *sql.DB
+	executor failsafe.Executor[any]
+}
+
+// NewDB returns a new DB that performs queries and transactions against the db via the policies. The policies are
+// composed around queries and will handle their results in reverse order.
+func NewDB(db *sql.DB, policies ...failsafe.Policy[any]) *DB {
+	return NewDBWithExecutor(db, failsafe.NewExecutor(policies...))
+}
+
+// NewDBWithExecutor returns a new DB that performs queries and transactions against the db via the executor.
+func NewDBWithExecutor(db *sql.DB, executor failsafe.Executor[any]) *DB {
+	return &DB{
+		db:       db,
+		executor: executor,
+	}
+}
+
+// ExecContext executes the query until successful or until the policies are exceeded.
+func (d *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	result, err := d.executor.WithContext(withQuery(ctx, query)).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		return d.db.ExecContext(exec.Context(), query, args...)
+	})
+	r, _ := result.(sql.Result)
+	return r, err
+}
+
+// QueryContext executes the query until successful or until the policies are exceeded, returning the resulting rows.
+// Errors that occur while iterating over the rows are not handled by the policies.
+func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	result, err := d.executor.WithContext(withQuery(ctx, query)).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		return d.db.QueryContext(exec.Context(), query, args...)
+	})
+	rows, _ := result.(*sql.Rows)
+	return rows, err
+}
+
+// InTx performs the fn within a transaction until the transaction is committed or the policies are exceeded. Each
+// attempt begins a new transaction with the opts, which is committed if the fn succeeds, else rolled back, so that
+// errors such as serialization failures can be handled by retrying the entire transaction.
+func (d *DB) InTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	return d.executor.WithContext(withQuery(ctx, "")).RunWithExecution(func(exec failsafe.Execution[any]) error {
+		tx, err := d.db.BeginTx(exec.Context(), opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(tx); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
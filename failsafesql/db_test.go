@@ -0,0 +1,279 @@
+package failsafesql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string {
+	return "sql error " + string(e)
+}
+
+func (e sqlStateError) SQLState() string {
+	return string(e)
+}
+
+// mockDriver is a driver.Driver whose connections return the next of its errs for each statement or commit, and then
+// succeed.
+type mockDriver struct {
+	mtx     sync.Mutex
+	errs    []error
+	queries []string
+	commits int
+}
+
+func (d *mockDriver) Open(string) (driver.Conn, error) {
+	return &mockConn{d}, nil
+}
+
+func (d *mockDriver) next(query string) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.queries = append(d.queries, query)
+	if len(d.errs) == 0 {
+		return nil
+	}
+	err := d.errs[0]
+	d.errs = d.errs[1:]
+	return err
+}
+
+type mockConn struct {
+	d *mockDriver
+}
+
+func (c *mockConn) Prepare(query string) (driver.Stmt, error) {
+	return &mockStmt{c.d, query}, nil
+}
+
+func (c *mockConn) Close() error {
+	return nil
+}
+
+func (c *mockConn) Begin() (driver.Tx, error) {
+	return &mockTx{c.d}, nil
+}
+
+type mockTx struct {
+	d *mockDriver
+}
+
+func (t *mockTx) Commit() error {
+	if err := t.d.next("COMMIT"); err != nil {
+		return err
+	}
+	t.d.mtx.Lock()
+	defer t.d.mtx.Unlock()
+	t.d.commits++
+	return nil
+}
+
+func (t *mockTx) Rollback() error {
+	return nil
+}
+
+type mockStmt struct {
+	d     *mockDriver
+	query string
+}
+
+func (s *mockStmt) Close() error {
+	return nil
+}
+
+func (s *mockStmt) NumInput() int {
+	return -1
+}
+
+func (s *mockStmt) Exec([]driver.Value) (driver.Result, error) {
+	if err := s.d.next(s.query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *mockStmt) Query([]driver.Value) (driver.Rows, error) {
+	if err := s.d.next(s.query); err != nil {
+		return nil, err
+	}
+	return &mockRows{values: []string{"foo"}}, nil
+}
+
+type mockRows struct {
+	values []string
+}
+
+func (r *mockRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *mockRows) Close() error {
+	return nil
+}
+
+func (r *mockRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.values[0]
+	r.values = r.values[1:]
+	return nil
+}
+
+func openDB(errs ...error) (*sql.DB, *mockDriver) {
+	d := &mockDriver{errs: errs}
+	return sql.OpenDB(connector{d}), d
+}
+
+type connector struct {
+	d *mockDriver
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return c.d.Open("")
+}
+
+func (c connector) Driver() driver.Driver {
+	return c.d
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(sqlStateError("40001")))
+	assert.True(t, IsRetryable(sqlStateError("40P01")))
+	assert.True(t, IsRetryable(driver.ErrBadConn))
+	assert.False(t, IsRetryable(io.ErrUnexpectedEOF))
+	assert.False(t, IsRetryable(sqlStateError("08006")))
+	assert.False(t, IsRetryable(sqlStateError("23505")))
+	assert.False(t, IsRetryable(sql.ErrNoRows))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(nil))
+}
+
+func TestIsConnectionError(t *testing.T) {
+	assert.True(t, IsConnectionError(io.ErrUnexpectedEOF))
+	assert.True(t, IsConnectionError(syscall.ECONNRESET))
+	assert.True(t, IsConnectionError(sqlStateError("08006")))
+	assert.False(t, IsConnectionError(sqlStateError("40001")))
+	assert.False(t, IsConnectionError(context.Canceled))
+	assert.False(t, IsConnectionError(nil))
+}
+
+// Asserts that connection errors, whose outcome is unknown, are only retried when configured.
+func TestExecContextWithConnectionError(t *testing.T) {
+	t.Run("should not retry by default", func(t *testing.T) {
+		// Given
+		db, d := openDB(sqlStateError("08006"))
+
+		// When
+		_, err := NewDB(db, RetryPolicyBuilder[any]().Build()).ExecContext(context.Background(), "UPDATE foo")
+
+		// Then
+		assert.Equal(t, sqlStateError("08006"), err)
+		assert.Len(t, d.queries, 1)
+	})
+
+	t.Run("should retry when configured", func(t *testing.T) {
+		// Given
+		db, d := openDB(sqlStateError("08006"))
+
+		// When
+		_, err := NewDB(db, RetryPolicyBuilder[any](WithRetryConnectionErrors(true)).Build()).ExecContext(context.Background(), "SELECT 1")
+
+		// Then
+		assert.NoError(t, err)
+		assert.Len(t, d.queries, 2)
+	})
+}
+
+func TestExecContext(t *testing.T) {
+	// Given
+	db, d := openDB(sqlStateError("40P01"), sqlStateError("40001"))
+	var queries []string
+	rp := RetryPolicyBuilder[any]().
+		OnRetry(func(e failsafe.ExecutionEvent[any]) {
+			query, _ := QueryFromContext(e.Context())
+			queries = append(queries, query)
+		}).
+		Build()
+
+	// When
+	result, err := NewDB(db, rp).ExecContext(context.Background(), "UPDATE foo")
+
+	// Then
+	assert.NoError(t, err)
+	affected, _ := result.RowsAffected()
+	assert.Equal(t, int64(1), affected)
+	assert.Equal(t, []string{"UPDATE foo", "UPDATE foo", "UPDATE foo"}, d.queries)
+	assert.Equal(t, []string{"UPDATE foo", "UPDATE foo"}, queries)
+}
+
+func TestQueryContext(t *testing.T) {
+	// Given
+	db, _ := openDB(sqlStateError("40001"))
+
+	// When
+	rows, err := NewDB(db, RetryPolicyBuilder[any]().Build()).QueryContext(context.Background(), "SELECT value FROM foo")
+
+	// Then
+	assert.NoError(t, err)
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var value string
+		assert.NoError(t, rows.Scan(&value))
+		values = append(values, value)
+	}
+	assert.Equal(t, []string{"foo"}, values)
+}
+
+func TestQueryContextWithNonRetryableError(t *testing.T) {
+	// Given
+	db, d := openDB(sqlStateError("23505"))
+
+	// When
+	rows, err := NewDB(db, RetryPolicyBuilder[any]().Build()).QueryContext(context.Background(), "SELECT value FROM foo")
+
+	// Then
+	assert.Nil(t, rows)
+	assert.Equal(t, sqlStateError("23505"), err)
+	assert.Len(t, d.queries, 1)
+}
+
+// Asserts that transactions are retried in their entirety.
+func TestInTx(t *testing.T) {
+	// Given
+	db, d := openDB(nil, sqlStateError("40001"))
+	attempts := 0
+
+	// When
+	err := NewDB(db, RetryPolicyBuilder[any]().Build()).InTx(context.Background(), nil, func(tx *sql.Tx) error {
+		attempts++
+		_, err := tx.Exec("UPDATE foo")
+		return err
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, d.commits)
+}
+
+func TestInTxWithError(t *testing.T) {
+	db, _ := openDB()
+	err := NewDB(db, RetryPolicyBuilder[any]().Build()).InTx(context.Background(), nil, func(tx *sql.Tx) error {
+		return errors.New("test")
+	})
+	assert.EqualError(t, err, "test")
+}
@@ -0,0 +1,2 @@
+// Package failsafesql provides functions that can be used to integrate Failsafe-go with database/sql.
+package failsafesql
@@ -0,0 +1,103 @@
+package failsafesql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"syscall"
+
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+// retryableSQLStates contains SQLSTATE codes for errors that occur before a query or transaction has any effect, which
+// can be resolved by retrying it.
+var retryableSQLStates = map[string]struct{}{
+	"40001": {}, // serialization_failure
+	"40P01": {}, // deadlock_detected
+	"55P03": {}, // lock_not_available
+}
+
+// connectionSQLStates contains SQLSTATE codes for connection failures, after which the outcome of a query or transaction
+// is unknown.
+var connectionSQLStates = map[string]struct{}{
+	"57P01": {}, // admin_shutdown
+	"08000": {}, // connection_exception
+	"08003": {}, // connection_does_not_exist
+	"08006": {}, // connection_failure
+}
+
+// IsRetryable returns whether the err can be safely resolved by retrying a query or transaction. This is the case for bad
+// connections, which drivers report via driver.ErrBadConn before a query is sent, and for errors that provide a
+// SQLState() string method, as drivers such as pgx and lib/pq do, with a SQLSTATE for serialization failures, deadlocks,
+// and lock timeouts.
+//
+// Connection failures are not considered retryable, since a query may have been applied before the connection failed.
+// See IsConnectionError.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	return hasSQLState(err, retryableSQLStates)
+}
+
+// IsConnectionError returns whether the err is a connection failure, such as a reset or refused connection, an
+// unexpected EOF, or an error with a SQLSTATE for a connection failure. The outcome of a query or transaction that fails
+// with a connection error is unknown, so it's only safe to retry if it's read-only or idempotent.
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	return hasSQLState(err, connectionSQLStates)
+}
+
+// hasSQLState returns whether the err provides a SQLState() string method that returns one of the states.
+func hasSQLState(err error, states map[string]struct{}) bool {
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		_, ok := states[stateErr.SQLState()]
+		return ok
+	}
+	return false
+}
+
+// RetryPolicyOption configures a RetryPolicyBuilder.
+type RetryPolicyOption func(*retryPolicyConfig)
+
+type retryPolicyConfig struct {
+	retryConnectionErrors bool
+}
+
+// WithRetryConnectionErrors configures whether errors that IsConnectionError considers connection errors are retried.
+// Defaults to false, since a query or transaction that fails with a connection error may have already been applied, and
+// retrying it may cause it to be applied more than once. This should only be enabled for read-only or idempotent
+// queries and transactions.
+func WithRetryConnectionErrors(retryConnectionErrors bool) RetryPolicyOption {
+	return func(c *retryPolicyConfig) {
+		c.retryConnectionErrors = retryConnectionErrors
+	}
+}
+
+// RetryPolicyBuilder returns a retrypolicy.RetryPolicyBuilder that will retry errors which IsRetryable considers
+// retryable, up to 2 times by default, with no delay between attempts. Additional handling and delay configuration can
+// be added to the resulting builder.
+//
+// R is the execution result type.
+func RetryPolicyBuilder[R any](options ...RetryPolicyOption) retrypolicy.RetryPolicyBuilder[R] {
+	config := &retryPolicyConfig{}
+	for _, option := range options {
+		option(config)
+	}
+
+	return retrypolicy.Builder[R]().HandleIf(func(_ R, err error) bool {
+		return IsRetryable(err) || (config.retryConnectionErrors && IsConnectionError(err))
+	})
+}
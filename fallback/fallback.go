@@ -1,6 +1,8 @@
 package fallback
 
 import (
+	"time"
+
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
@@ -25,19 +27,45 @@ R is the execution result type. This type is not concurrency safe.
 */
 type FallbackBuilder[R any] interface {
 	failsafe.FailurePolicyBuilder[FallbackBuilder[R], R]
+	failsafe.NamedPolicyBuilder[FallbackBuilder[R]]
 
 	// OnFallbackExecuted registers the listener to be called when a Fallback has executed. The provided event will contain
 	// the execution result and error returned by the Fallback.
 	OnFallbackExecuted(listener func(event failsafe.ExecutionDoneEvent[R])) FallbackBuilder[R]
 
+	// WithBackgroundDelay configures the Fallback to be applied if an execution does not complete within the delay, in
+	// addition to when it fails. When the delay elapses, the fallback result is returned and the execution continues in
+	// the background, and its eventual result is provided to any OnBackgroundCompleted listener. This is useful for
+	// serving a cached or default result quickly while the execution fills a cache or reconciles state. Since the
+	// fallback is called before the execution completes, the execution's LastResult and LastError are not set for it.
+	//
+	// Once an execution continues in the background, its context is detached from the caller's, so that it's not canceled
+	// when the caller is done with the fallback result.
+	WithBackgroundDelay(delay time.Duration) FallbackBuilder[R]
+
+	// WithClock configures the clock that is used to time the WithBackgroundDelay, such as a failsafe.FakeClock when
+	// testing. By default, failsafe.SystemClock is used.
+	WithClock(clock failsafe.TimerClock) FallbackBuilder[R]
+
+	// OnBackgroundCompleted registers the listener to be called when an execution that continued in the background,
+	// because it exceeded the WithBackgroundDelay, completes. The provided event will contain the execution's result and
+	// error.
+	OnBackgroundCompleted(listener func(event failsafe.ExecutionDoneEvent[R])) FallbackBuilder[R]
+
 	// Build returns a new Fallback using the builder's configuration.
 	Build() Fallback[R]
 }
 
 type config[R any] struct {
 	*policy.BaseFailurePolicy[R]
+	name               string
 	fn                 func(failsafe.Execution[R]) (R, error)
 	onFallbackExecuted func(failsafe.ExecutionDoneEvent[R])
+
+	// Background config
+	backgroundDelay       time.Duration
+	clock                 failsafe.TimerClock
+	onBackgroundCompleted func(failsafe.ExecutionDoneEvent[R])
 }
 
 var _ FallbackBuilder[any] = &config[any]{}
@@ -83,6 +111,7 @@ func BuilderWithFunc[R any](fallbackFunc func(exec failsafe.Execution[R]) (R, er
 	return &config[R]{
 		BaseFailurePolicy: &policy.BaseFailurePolicy[R]{},
 		fn:                fallbackFunc,
+		clock:             failsafe.SystemClock(),
 	}
 }
 
@@ -106,6 +135,11 @@ func (c *config[R]) HandleIf(predicate func(R, error) bool) FallbackBuilder[R] {
 	return c
 }
 
+func (c *config[R]) HandleWith(classifier failsafe.Classifier[R]) FallbackBuilder[R] {
+	c.BaseFailurePolicy.HandleWith(classifier)
+	return c
+}
+
 func (c *config[R]) OnSuccess(listener func(event failsafe.ExecutionEvent[R])) FallbackBuilder[R] {
 	c.BaseFailurePolicy.OnSuccess(listener)
 	return c
@@ -121,6 +155,26 @@ func (c *config[R]) OnFallbackExecuted(listener func(event failsafe.ExecutionDon
 	return c
 }
 
+func (c *config[R]) WithBackgroundDelay(delay time.Duration) FallbackBuilder[R] {
+	c.backgroundDelay = delay
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.TimerClock) FallbackBuilder[R] {
+	c.clock = clock
+	return c
+}
+
+func (c *config[R]) OnBackgroundCompleted(listener func(event failsafe.ExecutionDoneEvent[R])) FallbackBuilder[R] {
+	c.onBackgroundCompleted = listener
+	return c
+}
+
+func (c *config[R]) WithName(name string) FallbackBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() Fallback[R] {
 	fbCopy := *c
 	return &fallback[R]{
@@ -128,6 +182,14 @@ func (c *config[R]) Build() Fallback[R] {
 	}
 }
 
+func (fb *fallback[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.FallbackKind
+}
+
+func (fb *fallback[R]) PolicyName() string {
+	return fb.name
+}
+
 func (fb *fallback[R]) ToExecutor(_ R) any {
 	fbe := &executor[R]{
 		BaseExecutor: &policy.BaseExecutor[R]{
@@ -1,8 +1,11 @@
 package fallback
 
 import (
+	"sync/atomic"
+
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
@@ -14,39 +17,126 @@ type executor[R any] struct {
 
 var _ policy.Executor[any] = &executor[any]{}
 
+// States for executions with a background delay.
+const (
+	stateRunning int32 = iota
+	stateDone
+	stateBackground
+)
+
 // Apply performs an execution by calling the innerFn, applying a fallback if it fails, and calling post-execute.
 func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	if e.backgroundDelay > 0 {
+		return e.applyWithBackgroundDelay(innerFn)
+	}
+
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
 		execInternal := exec.(policy.ExecutionInternal[R])
-		result := innerFn(exec)
-		result = e.PostExecute(execInternal, result)
-		if !result.Success {
-			if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled {
-				return cancelResult
-			}
+		return e.handleResult(execInternal, innerFn(exec))
+	}
+}
 
-			// Call fallback fn
-			fallbackResult, fallbackError := e.fn(execInternal.CopyWithResult(result))
-			if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled {
-				return cancelResult
-			}
-			if e.onFallbackExecuted != nil {
-				e.onFallbackExecuted(failsafe.ExecutionDoneEvent[R]{
-					ExecutionInfo: execInternal,
-					Result:        fallbackResult,
-					Error:         fallbackError,
+// applyWithBackgroundDelay returns a func that applies the fallback if the innerFn fails or does not return within the
+// backgroundDelay. If the delay elapses first, the innerFn continues in the background and its result is provided to
+// the onBackgroundCompleted listener.
+func (e *executor[R]) applyWithBackgroundDelay(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+
+		// Perform the innerFn with a detached execution, so that it's not canceled when the caller is done with the fallback
+		// result, but cancel it if the execution is canceled before continuing in the background
+		detachedExec := execInternal.CopyForDetached().(policy.ExecutionInternal[R])
+		var state atomic.Int32
+		resultChan := make(chan *common.PolicyResult[R], 1)
+		scheduled := execInternal.Schedule(func() *common.PolicyResult[R] {
+			return innerFn(detachedExec)
+		}, func(result *common.PolicyResult[R]) {
+			if state.CompareAndSwap(stateRunning, stateDone) {
+				resultChan <- result
+			} else if state.Load() == stateBackground && e.onBackgroundCompleted != nil {
+				e.onBackgroundCompleted(failsafe.ExecutionDoneEvent[R]{
+					ExecutionInfo: detachedExec,
+					Result:        result.Result,
+					Error:         result.Error,
 				})
 			}
+		})
+		if !scheduled {
+			return e.handleResult(execInternal, internal.FailureResult[R](failsafe.ErrSchedulerRejected))
+		}
 
-			success := !e.IsFailure(fallbackResult, fallbackError)
-			result = &common.PolicyResult[R]{
-				Result:     fallbackResult,
-				Error:      fallbackError,
-				Done:       true,
-				Success:    success,
-				SuccessAll: success,
+		delayed := make(chan struct{})
+		timer := e.clock.AfterFunc(e.backgroundDelay, func() {
+			close(delayed)
+		})
+		defer timer.Stop()
+		select {
+		case result := <-resultChan:
+			return e.handleResult(execInternal, result)
+		case <-delayed:
+			if !state.CompareAndSwap(stateRunning, stateBackground) {
+				// The innerFn returned as the delay elapsed
+				return e.handleResult(execInternal, <-resultChan)
+			}
+			if logger := internal.NamedLogger(execInternal.Logger(), e.name); logger != nil {
+				logger.Debug("execution continuing in background", "policy", failsafe.FallbackKind, "attempts", execInternal.Attempts())
 			}
+			return e.applyFallback(execInternal, nil)
+		case <-exec.Canceled():
+			state.CompareAndSwap(stateRunning, stateDone)
+			_, cancelResult := execInternal.IsCanceledWithResult()
+			detachedExec.Cancel(cancelResult)
+			return cancelResult
 		}
-		return result
+	}
+}
+
+// handleResult calls post-execute for the result, applying the fallback if it failed.
+func (e *executor[R]) handleResult(execInternal policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	result = e.PostExecute(execInternal, result)
+	if !result.Success {
+		return e.applyFallback(execInternal, result)
+	}
+	return result
+}
+
+// applyFallback calls the fallback fn for the failed result, which is nil if the execution is continuing in the
+// background, and returns the fallback's result.
+func (e *executor[R]) applyFallback(execInternal policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled {
+		return cancelResult
+	}
+
+	// Call fallback fn
+	fallbackResult, fallbackError := e.fn(execInternal.CopyWithResult(result))
+	if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled {
+		return cancelResult
+	}
+	if logger := internal.NamedLogger(execInternal.Logger(), e.name); logger != nil {
+		var err error
+		if result != nil {
+			err = result.Error
+		}
+		logger.Debug("fallback executed",
+			"policy", failsafe.FallbackKind,
+			"attempts", execInternal.Attempts(),
+			"error", err,
+			"fallbackError", fallbackError)
+	}
+	if e.onFallbackExecuted != nil {
+		e.onFallbackExecuted(failsafe.ExecutionDoneEvent[R]{
+			ExecutionInfo: execInternal,
+			Result:        fallbackResult,
+			Error:         fallbackError,
+		})
+	}
+
+	success := !e.IsFailure(fallbackResult, fallbackError)
+	return &common.PolicyResult[R]{
+		Result:     fallbackResult,
+		Error:      fallbackError,
+		Done:       true,
+		Success:    success,
+		SuccessAll: success,
 	}
 }
@@ -0,0 +1,66 @@
+package hedgepolicy
+
+import (
+	"sync"
+)
+
+// Budget limits the hedges that are performed by one or more HedgePolicies to a ratio of their executions. This caps
+// the aggregate load that hedging can add to a system, such as during a slowdown where every execution would otherwise
+// be hedged. A Budget behaves like a token bucket: each execution adds ratio tokens to the budget, up to maxTokens, and
+// each hedge consumes one token. When the budget is exhausted, hedges are not performed until more executions occur.
+//
+// A Budget can be shared across HedgePolicies via HedgePolicyBuilder.WithBudget.
+//
+// This type is concurrency safe.
+type Budget interface {
+	// RecordExecution records an execution, adding to the budget that is available for hedges.
+	RecordExecution()
+
+	// TryAcquireHedge tries to acquire budget for a hedge, returning whether it was acquired.
+	TryAcquireHedge() bool
+
+	// AvailableHedges returns the number of hedges that the budget currently allows.
+	AvailableHedges() int
+}
+
+type budget struct {
+	ratio     float64
+	maxTokens float64
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	tokens float64
+}
+
+// NewBudget returns a new Budget that allows hedges up to the ratio of executions, such as .05 for 5% of executions, and
+// allows up to maxTokens hedges to accumulate, which also bounds bursts of hedges. The Budget initially allows
+// maxTokens hedges.
+func NewBudget(ratio float64, maxTokens uint) Budget {
+	return &budget{
+		ratio:     ratio,
+		maxTokens: float64(maxTokens),
+		tokens:    float64(maxTokens),
+	}
+}
+
+func (b *budget) RecordExecution() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.tokens = min(b.tokens+b.ratio, b.maxTokens)
+}
+
+func (b *budget) TryAcquireHedge() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *budget) AvailableHedges() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return int(b.tokens)
+}
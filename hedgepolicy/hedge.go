@@ -5,6 +5,7 @@ import (
 
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/policy"
+	"github.com/failsafe-go/failsafe-go/timeout"
 )
 
 // HedgePolicy is a policy that performes additional executions if the initial execution is slow to complete. This policy
@@ -26,6 +27,8 @@ type HedgePolicy[R any] interface {
 //
 // R is the execution result type. This type is not concurrency safe.
 type HedgePolicyBuilder[R any] interface {
+	failsafe.NamedPolicyBuilder[HedgePolicyBuilder[R]]
+
 	// CancelOnResult specifies that any outstanding hedges should be canceled if the execution result matches the result using
 	// reflect.DeepEqual.
 	CancelOnResult(result R) HedgePolicyBuilder[R]
@@ -45,20 +48,58 @@ type HedgePolicyBuilder[R any] interface {
 	// OnHedge registers the listener to be called when a hedge is about to be attempted.
 	OnHedge(listener func(failsafe.ExecutionEvent[R])) HedgePolicyBuilder[R]
 
+	// OnHedgeDiscarded registers the listener to be called when an attempt completes but its result is not used, such as
+	// when another attempt's result was used first. The listener is called after the attempt completes, which may be after
+	// the execution is done.
+	OnHedgeDiscarded(listener func(failsafe.ExecutionEvent[R])) HedgePolicyBuilder[R]
+
+	// WithCleanup configures a func that is called with the result of each attempt whose result is not used. This can be
+	// used to release per-attempt resources, such as by closing the bodies of discarded HTTP responses. The cleanup func
+	// is called after the attempt completes, which may be after the execution is done.
+	WithCleanup(cleanup func(R)) HedgePolicyBuilder[R]
+
 	// WithMaxHedges sets the max number of hedges to perform when an execution attempt doesn't complete in time, which is 1
 	// by default.
 	WithMaxHedges(maxHedges int) HedgePolicyBuilder[R]
 
+	// WithAttemptTimeout configures a time limit for each attempt, including the initial attempt and hedges. An attempt
+	// that exceeds the time limit is canceled and fails with timeout.ErrExceeded. Each attempt's context gets its own
+	// deadline, independent of the time remaining for the overall execution. An attempt that times out does not cancel
+	// other outstanding attempts, but its result is used if no other attempt provides one.
+	WithAttemptTimeout(attemptTimeout time.Duration) HedgePolicyBuilder[R]
+
+	// WithResultSelector configures a selector that chooses the final result from the attempts that have completed so far.
+	// This allows a better result that completes shortly after another to be selected, rather than always selecting the
+	// first cancellable result. When a selector is configured, it takes precedence over any CancelOn or CancelIf
+	// conditions. See FirstSuccess and Quorum for built-in selectors.
+	WithResultSelector(selector ResultSelector[R]) HedgePolicyBuilder[R]
+
+	// WithBudget configures a Budget that limits the hedges the policy performs. A Budget can be shared across
+	// HedgePolicies to cap the aggregate hedging load. When the budget is exhausted, the policy waits for outstanding
+	// attempts to complete rather than hedging.
+	WithBudget(budget Budget) HedgePolicyBuilder[R]
+
+	// WithClock configures the clock that is used for hedge delays, such as a failsafe.FakeClock when testing. By default,
+	// failsafe.SystemClock is used.
+	WithClock(clock failsafe.TimerClock) HedgePolicyBuilder[R]
+
 	// Build returns a new HedgePolicy using the builder's configuration.
 	Build() HedgePolicy[R]
 }
 
 type config[R any] struct {
 	*policy.BaseAbortablePolicy[R]
+	name string
 
-	delayFunc failsafe.DelayFunc[R]
-	maxHedges int
-	onHedge   func(failsafe.ExecutionEvent[R])
+	delayFunc        failsafe.DelayFunc[R]
+	maxHedges        int
+	attemptTimeout   time.Duration
+	resultSelector   ResultSelector[R]
+	budget           Budget
+	clock            failsafe.TimerClock
+	cleanup          func(R)
+	onHedge          func(failsafe.ExecutionEvent[R])
+	onHedgeDiscarded func(failsafe.ExecutionEvent[R])
 }
 
 var _ HedgePolicyBuilder[any] = &config[any]{}
@@ -106,6 +147,7 @@ func BuilderWithDelayFunc[R any](delayFunc failsafe.DelayFunc[R]) HedgePolicyBui
 		BaseAbortablePolicy: &policy.BaseAbortablePolicy[R]{},
 		delayFunc:           delayFunc,
 		maxHedges:           1,
+		clock:               failsafe.SystemClock(),
 	}
 }
 
@@ -140,11 +182,46 @@ func (c *config[R]) OnHedge(listener func(failsafe.ExecutionEvent[R])) HedgePoli
 	return c
 }
 
+func (c *config[R]) OnHedgeDiscarded(listener func(failsafe.ExecutionEvent[R])) HedgePolicyBuilder[R] {
+	c.onHedgeDiscarded = listener
+	return c
+}
+
+func (c *config[R]) WithCleanup(cleanup func(R)) HedgePolicyBuilder[R] {
+	c.cleanup = cleanup
+	return c
+}
+
 func (c *config[R]) WithMaxHedges(maxHedges int) HedgePolicyBuilder[R] {
 	c.maxHedges = maxHedges
 	return c
 }
 
+func (c *config[R]) WithAttemptTimeout(attemptTimeout time.Duration) HedgePolicyBuilder[R] {
+	c.attemptTimeout = attemptTimeout
+	return c
+}
+
+func (c *config[R]) WithResultSelector(selector ResultSelector[R]) HedgePolicyBuilder[R] {
+	c.resultSelector = selector
+	return c
+}
+
+func (c *config[R]) WithBudget(budget Budget) HedgePolicyBuilder[R] {
+	c.budget = budget
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.TimerClock) HedgePolicyBuilder[R] {
+	c.clock = clock
+	return c
+}
+
+func (c *config[R]) WithName(name string) HedgePolicyBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() HedgePolicy[R] {
 	hCopy := *c
 	if !c.BaseAbortablePolicy.IsConfigured() {
@@ -158,11 +235,31 @@ func (c *config[R]) Build() HedgePolicy[R] {
 	}
 }
 
+func (h *hedgePolicy[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.HedgeKind
+}
+
+func (h *hedgePolicy[R]) PolicyName() string {
+	return h.name
+}
+
+func (h *hedgePolicy[R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"maxHedges":      h.maxHedges,
+		"attemptTimeout": h.attemptTimeout,
+		"budget":         h.budget != nil,
+	}
+}
+
 func (h *hedgePolicy[R]) ToExecutor(_ R) any {
 	he := &executor[R]{
 		BaseExecutor: &policy.BaseExecutor[R]{},
 		hedgePolicy:  h,
 	}
 	he.Executor = he
+	if h.attemptTimeout > 0 {
+		to := timeout.Builder[R](h.attemptTimeout).WithClock(h.clock).Build()
+		he.timeoutExecutor = to.ToExecutor(*new(R)).(policy.Executor[R])
+	}
 	return he
 }
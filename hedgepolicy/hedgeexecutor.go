@@ -1,63 +1,163 @@
 package hedgepolicy
 
 import (
+	"errors"
+	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
+	"github.com/failsafe-go/failsafe-go/timeout"
 )
 
 // executor is a policy.Executor that handles failures according to a HedgePolicy.
 type executor[R any] struct {
 	*policy.BaseExecutor[R]
 	*hedgePolicy[R]
+	// Applies the attemptTimeout to each attempt, else nil
+	timeoutExecutor policy.Executor[R]
 }
 
 var _ policy.Executor[any] = &executor[any]{}
 
+// execResult is the result of an attempt along with its index.
+type execResult[R any] struct {
+	result *common.PolicyResult[R]
+	index  int
+}
+
 func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	if e.timeoutExecutor != nil {
+		innerFn = e.timeoutExecutor.Apply(innerFn)
+	}
+
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
-		type execResult struct {
-			result *common.PolicyResult[R]
-			index  int
+		if !failsafe.IsIdempotent(exec.Context()) {
+			// Executions that are not idempotent are not hedged
+			return innerFn(exec)
 		}
+
 		parentExecution := exec.(policy.ExecutionInternal[R])
 		executions := make([]policy.ExecutionInternal[R], e.maxHedges+1)
+		if e.budget != nil {
+			e.budget.RecordExecution()
+		}
 
 		// Guard against a race between execution results
 		resultCount := atomic.Int32{}
 		resultSent := atomic.Bool{}
-		resultChan := make(chan *execResult, 1) // Only one result is sent
+		resultChan := make(chan *execResult[R], 1) // Only one result is sent
+
+		// Tracks the number of attempts started when hedging is stopped early by the budget, else 0, and the last result,
+		// which is sent if all started attempts complete without a result being sent
+		stoppedAttempts := atomic.Int32{}
+		var lastResult atomic.Pointer[execResult[R]]
+
+		// Completed results, which are only tracked when a resultSelector is configured
+		var mtx sync.Mutex
+		var attemptResults []AttemptResult[R]
+		var execResults []*execResult[R]
+
+		// Discards the results of attempts that are not used, when configured
+		var d *discarder[R]
+		if e.onHedgeDiscarded != nil || e.cleanup != nil {
+			d = &discarder[R]{
+				hedgePolicy: e.hedgePolicy,
+				attempts:    make([]policy.ExecutionInternal[R], e.maxHedges+1),
+				results:     make([]*common.PolicyResult[R], e.maxHedges+1),
+			}
+		}
+
+		// Stops hedging after the startedAttempts and returns their result, sending the last result if all started attempts
+		// already completed
+		stopHedging := func(startedAttempts int) *common.PolicyResult[R] {
+			stoppedAttempts.Store(int32(startedAttempts))
+			if int(resultCount.Load()) == startedAttempts && resultSent.CompareAndSwap(false, true) {
+				resultChan <- lastResult.Load()
+			}
+			return e.complete(parentExecution, executions, <-resultChan, d)
+		}
 
 		for execIdx := 0; ; execIdx++ {
 			// Prepare execution
 			if execIdx == 0 {
 				executions[execIdx] = parentExecution.CopyForCancellable().(policy.ExecutionInternal[R])
+			} else if e.budget != nil && !e.budget.TryAcquireHedge() {
+				return stopHedging(execIdx)
 			} else {
 				executions[execIdx] = parentExecution.CopyForHedge().(policy.ExecutionInternal[R])
+				if logger := internal.NamedLogger(parentExecution.Logger(), e.name); logger != nil {
+					logger.Debug("hedge started", "policy", failsafe.HedgeKind, "attempts", executions[execIdx].Attempts())
+				}
 				if e.onHedge != nil {
 					e.onHedge(failsafe.ExecutionEvent[R]{ExecutionAttempt: executions[execIdx].CopyWithResult(nil)})
 				}
 			}
 
 			// Perform execution
-			go func(hedgeExec policy.ExecutionInternal[R], execIdx int) {
-				result := innerFn(hedgeExec)
-				isFinalResult := int(resultCount.Add(1)) == e.maxHedges+1
-				isCancellable := e.IsAbortable(result.Result, result.Error)
-				if (isFinalResult || isCancellable) && resultSent.CompareAndSwap(false, true) {
-					resultChan <- &execResult{result, execIdx}
+			releaseGoroutine := executions[execIdx].TrackResource(failsafe.GoroutineResource)
+			// Copy the loop variables for the async func
+			hedgeExec, execIdx := executions[execIdx], execIdx
+			scheduled := parentExecution.Schedule(func() *common.PolicyResult[R] {
+				return innerFn(hedgeExec)
+			}, func(result *common.PolicyResult[R]) {
+				releaseGoroutine()
+				if d != nil {
+					d.record(hedgeExec, execIdx, result)
 				}
-			}(executions[execIdx], execIdx)
+				lastResult.Store(&execResult[R]{result, execIdx})
+				count := resultCount.Add(1)
+				isFinalResult := int(count) == e.maxHedges+1 || count == stoppedAttempts.Load()
+				if e.resultSelector == nil {
+					isCancellable := e.IsAbortable(result.Result, result.Error) && !e.isAttemptTimeout(result)
+					if (isFinalResult || isCancellable) && resultSent.CompareAndSwap(false, true) {
+						resultChan <- &execResult[R]{result, execIdx}
+					}
+					return
+				}
+
+				mtx.Lock()
+				defer mtx.Unlock()
+				if resultSent.Load() {
+					return
+				}
+				attemptResults = append(attemptResults, AttemptResult[R]{
+					Result:      result.Result,
+					Error:       result.Error,
+					Attempt:     execIdx + 1,
+					ElapsedTime: hedgeExec.ElapsedAttemptTime(),
+				})
+				execResults = append(execResults, &execResult[R]{result, execIdx})
+				selectedIdx := e.resultSelector(attemptResults)
+				if selectedIdx == -1 && isFinalResult {
+					selectedIdx = len(execResults) - 1
+				}
+				if selectedIdx != -1 && resultSent.CompareAndSwap(false, true) {
+					resultChan <- execResults[selectedIdx]
+				}
+			})
+			if !scheduled {
+				releaseGoroutine()
+				hedgeExec.Cancel(nil)
+				executions[execIdx] = nil
+				if execIdx == 0 {
+					return internal.FailureResult[R](failsafe.ErrSchedulerRejected)
+				}
+				// Stop hedging when the scheduler rejects a hedge
+				return stopHedging(execIdx)
+			}
 
 			// Wait for result or hedge delay
-			var result *execResult
+			var result *execResult[R]
 			if execIdx < e.maxHedges {
-				timer := time.NewTimer(e.delayFunc(exec))
+				delayed := make(chan struct{})
+				timer := e.clock.AfterFunc(e.delayFunc(exec), func() {
+					close(delayed)
+				})
 				select {
-				case <-timer.C:
+				case <-delayed:
 				case result = <-resultChan:
 					timer.Stop()
 				}
@@ -67,20 +167,107 @@ func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyRe
 				}
 			}
 
+			if result != nil {
+				return e.complete(parentExecution, executions, result, d)
+			}
+
 			// Return if parent execution is canceled
 			if canceled, cancelResult := parentExecution.IsCanceledWithResult(); canceled {
+				if d != nil {
+					d.selectResult(-1)
+				}
 				return cancelResult
 			}
+		}
+	}
+}
 
-			// Return result and cancel any outstanding attempts
-			if result != nil {
-				for i, execution := range executions {
-					if i != result.index && execution != nil {
-						execution.Cancel(nil)
-					}
-				}
-				return result.result
-			}
+// complete cancels any outstanding attempts other than the result's, and returns the result, else the cancel result if
+// the parent execution was canceled. The result's attempt is not canceled, since the result may still depend on its
+// context, such as when reading an HTTP response body.
+func (e *executor[R]) complete(parentExecution policy.ExecutionInternal[R], executions []policy.ExecutionInternal[R], result *execResult[R], d *discarder[R]) *common.PolicyResult[R] {
+	if canceled, cancelResult := parentExecution.IsCanceledWithResult(); canceled {
+		if d != nil {
+			d.selectResult(-1)
+		}
+		return cancelResult
+	}
+	if d != nil {
+		d.selectResult(result.index)
+	}
+	for i, execution := range executions {
+		if i != result.index && execution != nil {
+			execution.Cancel(nil)
+		}
+	}
+	return result.result
+}
+
+// discarder discards the results of attempts that are not used, calling the OnHedgeDiscarded listener and the cleanup
+// func for each. An attempt's result is discarded once the attempt has completed and another attempt's result has been
+// selected, which may occur in either order.
+//
+// This type is concurrency safe.
+type discarder[R any] struct {
+	*hedgePolicy[R]
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	selected bool
+	// Guarded by mtx. The index of the selected attempt, else -1 if no attempt's result is used.
+	selectedIdx int
+	// Guarded by mtx. Completed attempts and their results, which are held until a result is selected.
+	attempts []policy.ExecutionInternal[R]
+	results  []*common.PolicyResult[R]
+}
+
+// record records the result of an attempt, discarding it if a different attempt's result was already selected.
+func (d *discarder[R]) record(attempt policy.ExecutionInternal[R], index int, result *common.PolicyResult[R]) {
+	d.mtx.Lock()
+	if !d.selected {
+		d.attempts[index] = attempt
+		d.results[index] = result
+		d.mtx.Unlock()
+		return
+	}
+	discard := d.selectedIdx != index
+	d.mtx.Unlock()
+	if discard {
+		d.discard(attempt, result)
+	}
+}
+
+// selectResult records the index of the attempt whose result is used, else -1 if no result is used, and discards the
+// results of any other attempts that have already completed.
+func (d *discarder[R]) selectResult(index int) {
+	d.mtx.Lock()
+	if d.selected {
+		d.mtx.Unlock()
+		return
+	}
+	d.selected = true
+	d.selectedIdx = index
+	attempts, results := d.attempts, d.results
+	d.attempts, d.results = nil, nil
+	d.mtx.Unlock()
+
+	for i, result := range results {
+		if i != index && result != nil {
+			d.discard(attempts[i], result)
 		}
 	}
 }
+
+func (d *discarder[R]) discard(attempt policy.ExecutionInternal[R], result *common.PolicyResult[R]) {
+	if d.cleanup != nil {
+		d.cleanup(result.Result)
+	}
+	if d.onHedgeDiscarded != nil {
+		d.onHedgeDiscarded(failsafe.ExecutionEvent[R]{ExecutionAttempt: attempt.CopyWithResult(result)})
+	}
+}
+
+// isAttemptTimeout returns whether the result is from an attempt that exceeded the attemptTimeout.
+func (e *executor[R]) isAttemptTimeout(result *common.PolicyResult[R]) bool {
+	return e.timeoutExecutor != nil && errors.Is(result.Error, timeout.ErrExceeded)
+}
@@ -0,0 +1,57 @@
+package hedgepolicy
+
+import (
+	"reflect"
+	"time"
+)
+
+// AttemptResult is the result of a completed execution attempt, which is either the initial attempt or a hedge.
+type AttemptResult[R any] struct {
+	// The execution result, else the zero value for R
+	Result R
+	// The execution error, else nil
+	Error error
+	// The attempt number, where 1 is the initial attempt and greater numbers are hedges
+	Attempt int
+	// The elapsed time for the attempt
+	ElapsedTime time.Duration
+}
+
+// ResultSelector selects the final result from the attempt results that have completed so far, which are provided in the
+// order they completed. It's called each time an attempt completes, and returns the index of the selected result, else
+// -1 to wait for more attempts to complete. If all attempts complete without a result being selected, the last result
+// is used.
+type ResultSelector[R any] func(results []AttemptResult[R]) int
+
+// FirstSuccess returns a ResultSelector that selects the first result that completes without an error.
+func FirstSuccess[R any]() ResultSelector[R] {
+	return func(results []AttemptResult[R]) int {
+		last := len(results) - 1
+		if results[last].Error == nil {
+			return last
+		}
+		return -1
+	}
+}
+
+// Quorum returns a ResultSelector that selects a result once quorum successful results, which complete without an error,
+// are equal according to reflect.DeepEqual. This can be used to check the consistency of results across attempts. The
+// max hedges should be at least quorum-1 for a quorum to be reached.
+func Quorum[R any](quorum int) ResultSelector[R] {
+	return func(results []AttemptResult[R]) int {
+		last := len(results) - 1
+		if results[last].Error != nil {
+			return -1
+		}
+		matches := 0
+		for _, r := range results {
+			if r.Error == nil && reflect.DeepEqual(r.Result, results[last].Result) {
+				matches++
+			}
+		}
+		if matches >= quorum {
+			return last
+		}
+		return -1
+	}
+}
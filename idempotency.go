@@ -0,0 +1,25 @@
+package failsafe
+
+import "context"
+
+type idempotencyKey struct{}
+
+// MarkIdempotent returns a child of the ctx that marks executions performed with it as idempotent or not. Policies that
+// re-execute, such as RetryPolicy and HedgePolicy, do not retry or hedge executions that are marked as not idempotent,
+// which allows a single Executor to be shared across mutating and non-mutating operations:
+//
+//	ctx = failsafe.MarkIdempotent(ctx, false)
+//	err := executor.RunWithContext(ctx, createOrder)
+func MarkIdempotent(ctx context.Context, idempotent bool) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, idempotent)
+}
+
+// IsIdempotent returns whether executions performed with the ctx are idempotent. Executions are considered idempotent
+// unless they're marked otherwise via MarkIdempotent.
+func IsIdempotent(ctx context.Context) bool {
+	if ctx == nil {
+		return true
+	}
+	idempotent, ok := ctx.Value(idempotencyKey{}).(bool)
+	return !ok || idempotent
+}
@@ -0,0 +1,15 @@
+package failsafe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, IsIdempotent(ctx))
+	assert.False(t, IsIdempotent(MarkIdempotent(ctx, false)))
+	assert.True(t, IsIdempotent(MarkIdempotent(MarkIdempotent(ctx, false), true)))
+}
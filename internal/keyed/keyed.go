@@ -0,0 +1,147 @@
+// Package keyed provides support for policies that maintain separate instances per key.
+package keyed
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// Policies holds policies by key, creating them as needed and evicting the least recently used policies that are not in
+// use when there are more than maxKeys. Policies that are in use by executions are not evicted, so that executions for a
+// key always share a policy, though this may allow more than maxKeys policies to be held while they're in use.
+//
+// This type is concurrency safe.
+type Policies[P any] struct {
+	maxKeys int
+	newFn   func() P
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	entries map[string]*list.Element
+	// Guarded by mtx. Ordered from most to least recently used.
+	lru *list.List
+}
+
+type entry[P any] struct {
+	key    string
+	policy P
+
+	// Guarded by Policies.mtx. The number of executions using the policy.
+	inUse int
+	// Guarded by Policies.mtx. The policy's executor, which is created when the policy is first used by an execution.
+	executor any
+}
+
+// New returns a new Policies that creates policies via the newFn and holds up to maxKeys policies. If maxKeys is 0, the
+// number of policies is unbounded.
+func New[P any](maxKeys int, newFn func() P) *Policies[P] {
+	return &Policies[P]{
+		maxKeys: maxKeys,
+		newFn:   newFn,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the policy for the key, creating it if needed.
+func (p *Policies[P]) Get(key string) P {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	e := p.get(key)
+	p.evictIdle()
+	return e.policy
+}
+
+// Len returns the number of policies.
+func (p *Policies[P]) Len() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.lru.Len()
+}
+
+// acquire returns the entry for the key, creating it if needed, and marks it as in use until it's released. The entry's
+// executor is created via the newExecutorFn if needed.
+func (p *Policies[P]) acquire(key string, newExecutorFn func(P) any) *entry[P] {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	e := p.get(key)
+	e.inUse++
+	if e.executor == nil {
+		e.executor = newExecutorFn(e.policy)
+	}
+	p.evictIdle()
+	return e
+}
+
+// release marks the entry as no longer in use by an execution.
+func (p *Policies[P]) release(e *entry[P]) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	e.inUse--
+	p.evictIdle()
+}
+
+// get returns the entry for the key, creating it if needed. Must be locked externally.
+func (p *Policies[P]) get(key string) *entry[P] {
+	if elem, ok := p.entries[key]; ok {
+		p.lru.MoveToFront(elem)
+		return elem.Value.(*entry[P])
+	}
+	e := &entry[P]{key: key, policy: p.newFn()}
+	p.entries[key] = p.lru.PushFront(e)
+	return e
+}
+
+// evictIdle evicts the least recently used entries that are not in use while there are more than maxKeys. Must be locked
+// externally.
+func (p *Policies[P]) evictIdle() {
+	if p.maxKeys <= 0 {
+		return
+	}
+	for elem := p.lru.Back(); elem != nil && p.lru.Len() > p.maxKeys; {
+		prev := elem.Prev()
+		if e := elem.Value.(*entry[P]); e.inUse == 0 {
+			p.lru.Remove(elem)
+			delete(p.entries, e.key)
+		}
+		elem = prev
+	}
+}
+
+// Executor is a policy.Executor that handles each execution via the policy for the execution's key.
+type Executor[R any, P failsafe.Policy[R]] struct {
+	*policy.BaseExecutor[R]
+	keyFunc  func(ctx context.Context) string
+	policies *Policies[P]
+}
+
+var _ policy.Executor[any] = &Executor[any, failsafe.Policy[any]]{}
+
+// NewExecutor returns a new Executor that handles executions via the policies for keys returned by the keyFunc.
+func NewExecutor[R any, P failsafe.Policy[R]](keyFunc func(ctx context.Context) string, policies *Policies[P]) *Executor[R, P] {
+	e := &Executor[R, P]{
+		BaseExecutor: &policy.BaseExecutor[R]{},
+		keyFunc:      keyFunc,
+		policies:     policies,
+	}
+	e.Executor = e
+	return e
+}
+
+func (e *Executor[R, P]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		entry := e.policies.acquire(e.keyFunc(exec.Context()), newExecutor[R, P])
+		defer e.policies.release(entry)
+		return entry.executor.(policy.Executor[R]).Apply(innerFn)(exec)
+	}
+}
+
+// newExecutor returns a new policy.Executor for the policy.
+func newExecutor[R any, P failsafe.Policy[R]](p P) any {
+	return p.ToExecutor(*new(R))
+}
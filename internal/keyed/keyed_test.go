@@ -0,0 +1,51 @@
+package keyed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoliciesEvictLeastRecentlyUsed(t *testing.T) {
+	created := 0
+	policies := New[int](2, func() int {
+		created++
+		return created
+	})
+
+	assert.Equal(t, 1, policies.Get("a"))
+	assert.Equal(t, 2, policies.Get("b"))
+	assert.Equal(t, 1, policies.Get("a"))
+	assert.Equal(t, 3, policies.Get("c")) // evicts b
+	assert.Equal(t, 2, policies.Len())
+	assert.Equal(t, 1, policies.Get("a"))
+	assert.Equal(t, 4, policies.Get("b"))
+}
+
+func TestPoliciesUnbounded(t *testing.T) {
+	policies := New[int](0, func() int { return 0 })
+	for _, key := range []string{"a", "b", "c"} {
+		policies.Get(key)
+	}
+	assert.Equal(t, 3, policies.Len())
+}
+
+func TestPoliciesDoNotEvictInUse(t *testing.T) {
+	created := 0
+	policies := New[int](1, func() int {
+		created++
+		return created
+	})
+	newExecutor := func(p int) any { return p }
+
+	a := policies.acquire("a", newExecutor)
+	assert.Equal(t, 2, policies.Get("b")) // evicts b, since a is in use
+	assert.Equal(t, 1, policies.Len())
+	assert.Equal(t, 1, policies.Get("a"))
+	assert.Equal(t, 1, a.executor)
+
+	policies.release(a)
+	assert.Equal(t, 3, policies.Get("c")) // evicts a, since it's idle
+	assert.Equal(t, 1, policies.Len())
+	assert.Equal(t, 4, policies.Get("a"))
+}
@@ -0,0 +1,23 @@
+package internal
+
+import (
+	"log/slog"
+)
+
+// NamedLogger returns the logger with a name attribute for a named policy, else the logger if the policy is not named or
+// the logger is nil.
+func NamedLogger(logger *slog.Logger, name string) *slog.Logger {
+	if logger == nil || name == "" {
+		return logger
+	}
+	return logger.With("name", name)
+}
+
+// ErrorName returns a description of a policy's name for an error message, else an empty string if the policy is not
+// named.
+func ErrorName(name string) string {
+	if name == "" {
+		return ""
+	}
+	return "name: " + name + ", "
+}
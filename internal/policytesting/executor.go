@@ -3,7 +3,6 @@ package policytesting
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 	"sync/atomic"
 
@@ -31,99 +30,46 @@ func ResetCircuitBreaker[R any](cb circuitbreaker.CircuitBreaker[R]) {
 }
 
 func WithRetryStats[R any](rp retrypolicy.RetryPolicyBuilder[R], stats *Stats) retrypolicy.RetryPolicyBuilder[R] {
-	return withRetryStatsAndLogs(rp, stats, false)
-}
-
-func WithRetryLogs[R any](rp retrypolicy.RetryPolicyBuilder[R]) retrypolicy.RetryPolicyBuilder[R] {
-	return withRetryStatsAndLogs(rp, &Stats{}, true)
-}
-
-func WithRetryStatsAndLogs[R any](rp retrypolicy.RetryPolicyBuilder[R], stats *Stats) retrypolicy.RetryPolicyBuilder[R] {
-	return withRetryStatsAndLogs(rp, stats, true)
-}
-
-func withRetryStatsAndLogs[R any](rp retrypolicy.RetryPolicyBuilder[R], stats *Stats, withLogging bool) retrypolicy.RetryPolicyBuilder[R] {
 	rp.OnRetry(func(e failsafe.ExecutionEvent[R]) {
 		stats.retries.Add(1)
-		if withLogging {
-			fmt.Printf("retrypolicy %p retrying [result: %v, error: %s]\n", rp, e.LastResult(), e.LastError())
-		}
 	}).OnRetriesExceeded(func(e failsafe.ExecutionEvent[R]) {
 		stats.retriesExceeded.Add(1)
-		if withLogging {
-			fmt.Printf("retrypolicy %p exceeded\n", rp)
-		}
 	}).OnAbort(func(e failsafe.ExecutionEvent[R]) {
 		stats.aborts.Add(1)
-		if withLogging {
-			fmt.Printf("retrypolicy %p aborted\n", rp)
-		}
 	})
-	withStatsAndLogs[retrypolicy.RetryPolicyBuilder[R], R](rp, stats, withLogging)
+	withStats[retrypolicy.RetryPolicyBuilder[R], R](rp, stats)
 	return rp
 }
 
 func WithBreakerStats[R any](cb circuitbreaker.CircuitBreakerBuilder[R], stats *Stats) circuitbreaker.CircuitBreakerBuilder[R] {
-	withBreakerStatsAndLogs(cb, stats, false)
-	return cb
-}
-
-func WithBreakerLogs[R any](cb circuitbreaker.CircuitBreakerBuilder[R]) circuitbreaker.CircuitBreakerBuilder[R] {
-	withBreakerStatsAndLogs(cb, &Stats{}, true)
-	return cb
-}
-
-func withBreakerStatsAndLogs[R any](cb circuitbreaker.CircuitBreakerBuilder[R], stats *Stats, withLogging bool) circuitbreaker.CircuitBreakerBuilder[R] {
-	cb.OnOpen(func(event circuitbreaker.StateChangedEvent) {
-		if withLogging {
-			fmt.Printf("circuitbreaker %p opened\n", cb)
-		}
-	})
-	cb.OnHalfOpen(func(event circuitbreaker.StateChangedEvent) {
-		if withLogging {
-			fmt.Printf("circuitbreaker %p half-opened\n", cb)
-		}
-	})
-	cb.OnClose(func(event circuitbreaker.StateChangedEvent) {
-		if withLogging {
-			fmt.Printf("circuitbreaker %p closed\n", cb)
-		}
-	})
-	withStatsAndLogs[circuitbreaker.CircuitBreakerBuilder[R], R](cb, stats, withLogging)
+	withStats[circuitbreaker.CircuitBreakerBuilder[R], R](cb, stats)
 	return cb
 }
 
-func WithTimeoutStatsAndLogs[R any](to timeout.TimeoutBuilder[R], stats *Stats) timeout.TimeoutBuilder[R] {
+func WithTimeoutStats[R any](to timeout.TimeoutBuilder[R], stats *Stats) timeout.TimeoutBuilder[R] {
 	to.OnTimeoutExceeded(func(e failsafe.ExecutionDoneEvent[R]) {
 		stats.executions.Add(1)
-		fmt.Printf("timeout %p exceeded [attempts: %d, executions: %d]\n", to, e.Attempts(), e.Executions())
 	})
 	return to
 }
 
-func WithFallbackStatsAndLogs[R any](fb fallback.FallbackBuilder[R], stats *Stats) fallback.FallbackBuilder[R] {
+func WithFallbackStats[R any](fb fallback.FallbackBuilder[R], stats *Stats) fallback.FallbackBuilder[R] {
 	fb.OnFallbackExecuted(func(e failsafe.ExecutionDoneEvent[R]) {
 		stats.executions.Add(1)
-		fmt.Printf("fallback %p executed [result: %v, error: %s, attempts: %d, executions: %d]\n",
-			fb, e.Result, e.Error, e.Attempts(), e.Executions())
 	})
 	return fb
 }
 
-func WithHedgeStatsAndLogs[R any](hp hedgepolicy.HedgePolicyBuilder[R], stats *Stats) hedgepolicy.HedgePolicyBuilder[R] {
+func WithHedgeStats[R any](hp hedgepolicy.HedgePolicyBuilder[R], stats *Stats) hedgepolicy.HedgePolicyBuilder[R] {
 	hp.OnHedge(func(e failsafe.ExecutionEvent[R]) {
 		stats.hedges.Add(1)
-		fmt.Printf("hedge %p starting [attempts: %v]\n", hp, e.Attempts())
 	})
 	return hp
 }
 
-func WithBulkheadStatsAndLogs[R any](bh bulkhead.BulkheadBuilder[R], stats *Stats, withLogging bool) bulkhead.BulkheadBuilder[R] {
+func WithBulkheadStats[R any](bh bulkhead.BulkheadBuilder[R], stats *Stats) bulkhead.BulkheadBuilder[R] {
 	bh.OnFull(func(event failsafe.ExecutionEvent[R]) {
-		if withLogging {
-			stats.fulls.Add(1)
-			fmt.Printf("bulkhead %p full\n", bh)
-		}
+		stats.fulls.Add(1)
 	})
 	return bh
 }
@@ -139,22 +85,14 @@ func WithCacheStats[R any](cp cachepolicy.CachePolicyBuilder[R], stats *Stats) c
 	return cp
 }
 
-func withStatsAndLogs[P any, R any](policy failsafe.FailurePolicyBuilder[P, R], stats *Stats, withLogging bool) {
+func withStats[P any, R any](policy failsafe.FailurePolicyBuilder[P, R], stats *Stats) {
 	policy.OnSuccess(func(e failsafe.ExecutionEvent[R]) {
 		stats.executions.Add(1)
 		stats.successes.Add(1)
-		if withLogging {
-			fmt.Printf("policy %p success [result: %v, attempts: %d, executions: %d]\n",
-				policy, e.LastResult(), e.Attempts(), e.Executions())
-		}
 	})
 	policy.OnFailure(func(e failsafe.ExecutionEvent[R]) {
 		stats.executions.Add(1)
 		stats.failures.Add(1)
-		if withLogging {
-			fmt.Printf("policy %p failure [result: %v, error: %s, attempts: %d, executions: %d]\n",
-				policy, e.LastResult(), e.LastError(), e.Attempts(), e.Executions())
-		}
 	})
 }
 
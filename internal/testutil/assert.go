@@ -51,3 +51,12 @@ func SetupWithContextSleep(sleepTime time.Duration) func() context.Context {
 		return ctx
 	}
 }
+
+// SetupWithContextTimeout returns a setup function that provides a context with a deadline after the timeout.
+func SetupWithContextTimeout(timeout time.Duration) func() context.Context {
+	return func() context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		time.AfterFunc(timeout, cancel)
+		return ctx
+	}
+}
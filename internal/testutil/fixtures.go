@@ -136,6 +136,18 @@ func (e TestExecution[R]) ElapsedTime() time.Duration {
 	panic("unimplemented stub")
 }
 
+func (e TestExecution[R]) Value(key any) any {
+	panic("unimplemented stub")
+}
+
+func (e TestExecution[R]) SetValue(key any, value any) {
+	panic("unimplemented stub")
+}
+
+func (e TestExecution[R]) Tags() map[string]string {
+	return nil
+}
+
 func (e TestExecution[R]) IsHedge() bool {
 	panic("unimplemented stub")
 }
@@ -0,0 +1,57 @@
+// Package timelimit provides support for policies that limit the time an execution may take, such as timeouts and
+// budgets.
+package timelimit
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// Apply sets up a race between the timeLimit, as measured by the clock, and the innerFn returning. The innerFn is
+// performed with a copy of the exec whose context has the deadline, so that clients can see the remaining time. If the
+// timeLimit is exceeded first, the exceededFn is called with the execution copy to create a result, and if it still wins
+// the race, the onExceeded func is called and the execution is canceled with that result.
+//
+// Returns the execution copy, the winning result, and whether the winning result came from the innerFn.
+func Apply[R any](
+	exec policy.ExecutionInternal[R],
+	clock failsafe.TimerClock,
+	deadline time.Time,
+	timeLimit time.Duration,
+	innerFn func(failsafe.Execution[R]) *common.PolicyResult[R],
+	exceededFn func(exec policy.ExecutionInternal[R]) *common.PolicyResult[R],
+	onExceeded func(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]),
+) (policy.ExecutionInternal[R], *common.PolicyResult[R], bool) {
+	exec = exec.CopyForDeadline(deadline).(policy.ExecutionInternal[R])
+	releaseContext := exec.TrackResource(failsafe.ContextResource)
+	var result atomic.Pointer[common.PolicyResult[R]]
+	releaseTimer := exec.TrackResource(failsafe.TimerResource)
+	timer := clock.AfterFunc(timeLimit, func() {
+		defer releaseTimer()
+		exceededResult := exceededFn(exec)
+		if result.CompareAndSwap(nil, exceededResult) {
+			onExceeded(exec, exceededResult)
+
+			// Sets the exceededResult, overwriting any previously set result for the execution. This is correct, because while
+			// an execution may have completed, inner policies such as fallbacks may still be processing that result, in which
+			// case it's still important to interrupt them.
+			exec.Cancel(exceededResult)
+		}
+	})
+
+	// Store result and stop the timer if needed. The child context is not canceled, since the result may still depend on
+	// it, such as when reading an HTTP response body.
+	innerResult := innerFn(exec)
+	releaseContext()
+	if result.CompareAndSwap(nil, innerResult) {
+		if timer.Stop() {
+			releaseTimer()
+		}
+		return exec, innerResult, true
+	}
+	return exec, result.Load(), false
+}
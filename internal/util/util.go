@@ -139,20 +139,23 @@ type Stopwatch interface {
 	Reset()
 }
 
-type wallClockStopwatch struct {
+type stopwatch struct {
+	now       func() time.Time
 	startTime time.Time
 }
 
-func NewStopwatch() Stopwatch {
-	return &wallClockStopwatch{
-		startTime: time.Now(),
+// NewStopwatch returns a Stopwatch that measures elapsed time via the now func.
+func NewStopwatch(now func() time.Time) Stopwatch {
+	return &stopwatch{
+		now:       now,
+		startTime: now(),
 	}
 }
 
-func (s *wallClockStopwatch) ElapsedTime() time.Duration {
-	return time.Since(s.startTime)
+func (s *stopwatch) ElapsedTime() time.Duration {
+	return s.now().Sub(s.startTime)
 }
 
-func (s *wallClockStopwatch) Reset() {
-	s.startTime = time.Now()
+func (s *stopwatch) Reset() {
+	s.startTime = s.now()
 }
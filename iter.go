@@ -0,0 +1,79 @@
+//go:build go1.23
+
+package failsafe
+
+import (
+	"context"
+	"iter"
+)
+
+// Attempt is an execution attempt that is yielded by an AttemptIterator.
+type Attempt[R any] interface {
+	Execution[R]
+
+	// Record records the result and error for the attempt, which are then handled by the Executor's policies. If Record
+	// is not called, the attempt is treated as having returned the zero value for R and a nil error.
+	Record(result R, err error)
+}
+
+// AttemptIterator iterates over execution attempts for an Executor, for loop style control flow rather than callbacks.
+//
+// R is the execution result type. This type is not concurrency safe.
+type AttemptIterator[R any] struct {
+	executor *executor[R]
+	result   R
+	err      error
+}
+
+// Attempts returns an AttemptIterator for the Executor. Attempts are yielded synchronously, with any delays and policy
+// checks, such as circuit breaker or rate limiter permits, applied between iterations. Example usage:
+//
+//	attempts := failsafe.Attempts(executor)
+//	for attempt := range attempts.All() {
+//	  attempt.Record(sendRequest())
+//	}
+//	response, err := attempts.Result()
+//
+// Since attempts are yielded on the calling goroutine, Attempts does not support policies that perform concurrent
+// attempts, such as a HedgePolicy.
+func Attempts[R any](e Executor[R]) *AttemptIterator[R] {
+	return &AttemptIterator[R]{executor: e.(*executor[R])}
+}
+
+// All returns an iterator that yields attempts until an attempt succeeds or the Executor's policies are exceeded.
+// Breaking out of the loop cancels the execution. Each call to All performs a new execution.
+func (a *AttemptIterator[R]) All() iter.Seq[Attempt[R]] {
+	return func(yield func(Attempt[R]) bool) {
+		ctx, cancel := context.WithCancel(a.executor.ctx)
+		defer cancel()
+		stopped := false
+		a.result, a.err = a.executor.executeSync(ctx, func(exec Execution[R]) (R, error) {
+			if stopped {
+				return *new(R), context.Canceled
+			}
+			at := &attempt[R]{Execution: exec}
+			if !yield(at) {
+				stopped = true
+				cancel()
+			}
+			return at.result, at.err
+		})
+	}
+}
+
+// Result returns the result and error of the most recent execution performed by All, after the Executor's policies
+// have handled them.
+func (a *AttemptIterator[R]) Result() (R, error) {
+	return a.result, a.err
+}
+
+type attempt[R any] struct {
+	Execution[R]
+	result R
+	err    error
+}
+
+func (a *attempt[R]) Record(result R, err error) {
+	a.result = result
+	a.err = err
+}
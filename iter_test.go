@@ -0,0 +1,67 @@
+//go:build go1.23
+
+package failsafe_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+func TestAttempts(t *testing.T) {
+	executor := failsafe.NewExecutor[string](retrypolicy.Builder[string]().WithMaxRetries(3).ReturnLastFailure().Build())
+	attempts := failsafe.Attempts(executor)
+
+	var attemptNumbers []int
+	for attempt := range attempts.All() {
+		attemptNumbers = append(attemptNumbers, attempt.Attempts())
+		if attempt.Attempts() < 3 {
+			attempt.Record("", testutil.ErrInvalidState)
+		} else {
+			attempt.Record("success", nil)
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, attemptNumbers)
+	result, err := attempts.Result()
+	assert.Equal(t, "success", result)
+	assert.NoError(t, err)
+}
+
+func TestAttemptsWithBreak(t *testing.T) {
+	executor := failsafe.NewExecutor[string](retrypolicy.Builder[string]().WithMaxRetries(-1).Build())
+	attempts := failsafe.Attempts(executor)
+
+	count := 0
+	for attempt := range attempts.All() {
+		count++
+		attempt.Record("", testutil.ErrInvalidState)
+		if count == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, count)
+	_, err := attempts.Result()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// Asserts that no attempts are yielded when a policy rejects the execution.
+func TestAttemptsWithOpenCircuitBreaker(t *testing.T) {
+	cb := circuitbreaker.WithDefaults[string]()
+	cb.Open()
+	attempts := failsafe.Attempts(failsafe.NewExecutor[string](cb))
+
+	for range attempts.All() {
+		assert.Fail(t, "should not yield an attempt")
+	}
+
+	_, err := attempts.Result()
+	assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+}
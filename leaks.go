@@ -0,0 +1,105 @@
+package failsafe
+
+import (
+	"runtime/debug"
+	"sort"
+	"sync"
+)
+
+// ResourceKind is a kind of resource that a policy creates for an execution, which is tracked when leak detection is
+// enabled via Executor.WithLeakDetection.
+type ResourceKind int
+
+const (
+	// PermitResource is a permit, such as from a Bulkhead, that is held while an execution attempt runs.
+	PermitResource ResourceKind = iota
+
+	// TimerResource is a timer, such as for a Timeout, that is pending while an execution attempt runs.
+	TimerResource
+
+	// ContextResource is a child context, such as for a Timeout, that is in use while an execution attempt runs.
+	ContextResource
+
+	// GoroutineResource is a goroutine, such as for a hedge attempt, that runs an execution attempt.
+	GoroutineResource
+)
+
+func (k ResourceKind) String() string {
+	switch k {
+	case PermitResource:
+		return "permit"
+	case TimerResource:
+		return "timer"
+	case ContextResource:
+		return "context"
+	case GoroutineResource:
+		return "goroutine"
+	default:
+		return "unknown"
+	}
+}
+
+// Leak is a resource that was created for an execution and was not released by the time the execution was checked for
+// leaks.
+type Leak struct {
+	Kind ResourceKind
+	// The stack trace of where the resource was created.
+	Stack []byte
+}
+
+// LeakEvent reports the resources that an execution leaked.
+type LeakEvent struct {
+	ExecutionInfo
+	// The leaked resources, in the order they were created.
+	Leaks []Leak
+}
+
+// noopRelease is returned when resources are not tracked, to avoid allocating a release func.
+var noopRelease = func() {}
+
+// leakTracker tracks the resources that are outstanding for an execution.
+type leakTracker struct {
+	mtx sync.Mutex
+	// Guarded by mtx
+	nextID uint64
+	// Guarded by mtx
+	resources map[uint64]Leak
+}
+
+func newLeakTracker() *leakTracker {
+	return &leakTracker{resources: make(map[uint64]Leak)}
+}
+
+// track tracks a resource of the kind, returning a func that releases it. The func may be called more than once.
+func (t *leakTracker) track(kind ResourceKind) func() {
+	stack := debug.Stack()
+	t.mtx.Lock()
+	id := t.nextID
+	t.nextID++
+	t.resources[id] = Leak{Kind: kind, Stack: stack}
+	t.mtx.Unlock()
+	return func() {
+		t.mtx.Lock()
+		delete(t.resources, id)
+		t.mtx.Unlock()
+	}
+}
+
+// leaks returns the outstanding resources in the order they were created.
+func (t *leakTracker) leaks() []Leak {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if len(t.resources) == 0 {
+		return nil
+	}
+	ids := make([]uint64, 0, len(t.resources))
+	for id := range t.resources {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	leaks := make([]Leak, len(ids))
+	for i, id := range ids {
+		leaks[i] = t.resources[id]
+	}
+	return leaks
+}
@@ -4,7 +4,7 @@ import (
 	"time"
 )
 
-// Policy handles execution failures.
+// Policy handles execution failures. Custom policies can be implemented using the types in the policy package.
 type Policy[R any] interface {
 	// ToExecutor returns a policy.Executor capable of handling an execution for the Policy.
 	// The typeToken parameter helps catch mismatches between R types when composing policies.
@@ -38,6 +38,10 @@ type FailurePolicyBuilder[S any, R any] interface {
 	// HandleIf specifies that a failure has occurred if the predicate matches the execution result or error.
 	HandleIf(predicate func(R, error) bool) S
 
+	// HandleWith specifies that a failure has occurred if the classifier determines the execution result or error is a
+	// failure. A Classifier can be shared across policies so that they use consistent failure conditions.
+	HandleWith(classifier Classifier[R]) S
+
 	// OnSuccess registers the listener to be called when the policy determines an execution attempt was a success.
 	OnSuccess(listener func(ExecutionEvent[R])) S
 
@@ -46,6 +50,13 @@ type FailurePolicyBuilder[S any, R any] interface {
 	OnFailure(listener func(ExecutionEvent[R])) S
 }
 
+// NamedPolicyBuilder builds policies that can be named.
+type NamedPolicyBuilder[S any] interface {
+	// WithName configures a name for the policy, which identifies the policy in logs, metrics, and errors, such as when
+	// several policies of the same kind are composed. By default, policies are not named.
+	WithName(name string) S
+}
+
 // DelayFunc returns a duration to delay for given the ExecutionAttempt.
 type DelayFunc[R any] func(exec ExecutionAttempt[R]) time.Duration
 
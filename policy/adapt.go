@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+)
+
+// Adapt returns a failsafe.Policy[R] that delegates to the policy, which handles results of type any. This allows a
+// policy that is built once for results of type any, such as a CircuitBreaker that is shared across many different
+// operations, to be explicitly composed into an Executor for a specific result type R.
+//
+// Results that the policy produces, such as fallback results, which are not of type R are converted to the zero value
+// for R.
+func Adapt[R any](policy failsafe.Policy[any]) failsafe.Policy[R] {
+	return &adaptedPolicy[R]{policy: policy}
+}
+
+type adaptedPolicy[R any] struct {
+	policy failsafe.Policy[any]
+}
+
+func (p *adaptedPolicy[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.KindOf(p.policy)
+}
+
+func (p *adaptedPolicy[R]) PolicyConfig() map[string]any {
+	return failsafe.ConfigOf(p.policy)
+}
+
+func (p *adaptedPolicy[R]) PolicyMetrics() []failsafe.Metric {
+	return failsafe.MetricsOf(p.policy)
+}
+
+func (p *adaptedPolicy[R]) ToExecutor(_ R) any {
+	return &adaptedExecutor[R]{Executor: p.policy.ToExecutor(nil).(Executor[any])}
+}
+
+// adaptedExecutor adapts an Executor[any] to an Executor[R].
+type adaptedExecutor[R any] struct {
+	Executor[any]
+}
+
+func (e *adaptedExecutor[R]) PreExecute(exec ExecutionInternal[R]) *common.PolicyResult[R] {
+	return fromAny[R](e.Executor.PreExecute(&anyExecution[R]{exec}))
+}
+
+func (e *adaptedExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	anyFn := e.Executor.Apply(func(exec failsafe.Execution[any]) *common.PolicyResult[any] {
+		return toAny(innerFn(exec.(*anyExecution[R]).ExecutionInternal))
+	})
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		return fromAny[R](anyFn(&anyExecution[R]{exec.(ExecutionInternal[R])}))
+	}
+}
+
+func (e *adaptedExecutor[R]) PostExecute(exec ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	return fromAny[R](e.Executor.PostExecute(&anyExecution[R]{exec}, toAny(result)))
+}
+
+func (e *adaptedExecutor[R]) IsFailure(result R, err error) bool {
+	return e.Executor.IsFailure(result, err)
+}
+
+func (e *adaptedExecutor[R]) OnSuccess(exec ExecutionInternal[R], result *common.PolicyResult[R]) {
+	e.Executor.OnSuccess(&anyExecution[R]{exec}, toAny(result))
+}
+
+func (e *adaptedExecutor[R]) OnFailure(exec ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	return fromAny[R](e.Executor.OnFailure(&anyExecution[R]{exec}, toAny(result)))
+}
+
+// anyExecution adapts an ExecutionInternal[R] to an ExecutionInternal[any].
+type anyExecution[R any] struct {
+	ExecutionInternal[R]
+}
+
+func (e *anyExecution[R]) LastResult() any {
+	return e.ExecutionInternal.LastResult()
+}
+
+func (e *anyExecution[R]) RecordResult(result *common.PolicyResult[any]) *common.PolicyResult[any] {
+	return toAny(e.ExecutionInternal.RecordResult(fromAny[R](result)))
+}
+
+func (e *anyExecution[R]) InitializeRetry() *common.PolicyResult[any] {
+	return toAny(e.ExecutionInternal.InitializeRetry())
+}
+
+func (e *anyExecution[R]) CallListener(success bool, result *common.PolicyResult[any], listener func()) {
+	e.ExecutionInternal.CallListener(success, fromAny[R](result), listener)
+}
+
+func (e *anyExecution[R]) Cancel(result *common.PolicyResult[any]) {
+	e.ExecutionInternal.Cancel(fromAny[R](result))
+}
+
+func (e *anyExecution[R]) Schedule(fn func() *common.PolicyResult[any], resultFn func(*common.PolicyResult[any])) bool {
+	return e.ExecutionInternal.Schedule(func() *common.PolicyResult[R] {
+		return fromAny[R](fn())
+	}, func(result *common.PolicyResult[R]) {
+		resultFn(toAny(result))
+	})
+}
+
+func (e *anyExecution[R]) IsCanceledWithResult() (bool, *common.PolicyResult[any]) {
+	canceled, result := e.ExecutionInternal.IsCanceledWithResult()
+	return canceled, toAny(result)
+}
+
+func (e *anyExecution[R]) CopyWithResult(result *common.PolicyResult[any]) failsafe.Execution[any] {
+	return &anyExecution[R]{e.ExecutionInternal.CopyWithResult(fromAny[R](result)).(ExecutionInternal[R])}
+}
+
+func (e *anyExecution[R]) CopyForCancellable() failsafe.Execution[any] {
+	return &anyExecution[R]{e.ExecutionInternal.CopyForCancellable().(ExecutionInternal[R])}
+}
+
+func (e *anyExecution[R]) CopyForDeadline(deadline time.Time) failsafe.Execution[any] {
+	return &anyExecution[R]{e.ExecutionInternal.CopyForDeadline(deadline).(ExecutionInternal[R])}
+}
+
+func (e *anyExecution[R]) CopyForDetached() failsafe.Execution[any] {
+	return &anyExecution[R]{e.ExecutionInternal.CopyForDetached().(ExecutionInternal[R])}
+}
+
+func (e *anyExecution[R]) CopyForHedge() failsafe.Execution[any] {
+	return &anyExecution[R]{e.ExecutionInternal.CopyForHedge().(ExecutionInternal[R])}
+}
+
+func (e *anyExecution[R]) CopyWithContextValue(key any, value any) failsafe.Execution[any] {
+	return &anyExecution[R]{e.ExecutionInternal.CopyWithContextValue(key, value).(ExecutionInternal[R])}
+}
+
+func toAny[R any](result *common.PolicyResult[R]) *common.PolicyResult[any] {
+	if result == nil {
+		return nil
+	}
+	return &common.PolicyResult[any]{
+		Result:     result.Result,
+		Error:      result.Error,
+		Done:       result.Done,
+		Success:    result.Success,
+		SuccessAll: result.SuccessAll,
+	}
+}
+
+func fromAny[R any](result *common.PolicyResult[any]) *common.PolicyResult[R] {
+	if result == nil {
+		return nil
+	}
+	r, _ := result.Result.(R)
+	return &common.PolicyResult[R]{
+		Result:     r,
+		Error:      result.Error,
+		Done:       result.Done,
+		Success:    result.Success,
+		SuccessAll: result.SuccessAll,
+	}
+}
@@ -0,0 +1,48 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/policy"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+func TestAdapt(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[any]().WithMaxRetries(2).Build()
+	cb := circuitbreaker.Builder[any]().WithFailureThreshold(3).Build()
+	executor := failsafe.NewExecutor[string](policy.Adapt[string](rp), policy.Adapt[string](cb))
+
+	// When
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		if exec.Attempts() < 3 {
+			return "", testutil.ErrInvalidState
+		}
+		return "test", nil
+	})
+
+	// Then
+	assert.Equal(t, "test", result)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), cb.Metrics().Successes())
+	assert.Equal(t, uint(2), cb.Metrics().Failures())
+	assert.Equal(t, failsafe.RetryKind, failsafe.KindOf(policy.Adapt[string](rp)))
+}
+
+func TestAdaptWithMismatchedResult(t *testing.T) {
+	fb := fallback.WithResult[any](10)
+	executor := failsafe.NewExecutor[string](policy.Adapt[string](fb))
+
+	result, err := executor.Get(func() (string, error) {
+		return "", testutil.ErrInvalidState
+	})
+
+	assert.Equal(t, "", result)
+	assert.NoError(t, err)
+}
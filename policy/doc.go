@@ -1,2 +1,30 @@
-// Package policy provides types that are used for implementing a failsafe.Policy.
+/*
+Package policy provides types that are used for implementing a failsafe.Policy.
+
+A custom policy is made of two parts: a failsafe.Policy, which holds the policy's configuration and any state that is
+shared across executions, and an Executor, which handles executions for the policy. The failsafe.Policy's ToExecutor
+method returns an Executor for the policy:
+
+	func (q *quota[R]) ToExecutor(_ R) any {
+		e := &quotaExecutor[R]{quota: q}
+		e.BaseExecutor = &policy.BaseExecutor[R]{
+			Executor:          e,
+			BaseFailurePolicy: q.BaseFailurePolicy,
+		}
+		return e
+	}
+
+An Executor typically embeds a BaseExecutor, which implements Apply by calling PreExecute before an execution and
+PostExecute after, and which determines whether results are failures via a BaseFailurePolicy. Embedding types only need
+to override the methods they care about. For example, to reject executions before they occur, a PreExecute method can
+return FailureResult, and to react to failures, an OnFailure method can record them. Executors that need to perform
+work around an execution, such as delays or retries, can override Apply, as long as they check whether the execution
+was canceled via ExecutionInternal.IsCanceledWithResult after any delay.
+
+Executors are created once per failsafe.Executor and reused across executions, so mutable per-execution state must be
+stored via ExecutionInternal.ExecutorState rather than in the Executor.
+
+Policy builders can embed BaseFailurePolicy, BaseDelayablePolicy, and BaseAbortablePolicy to provide the same failure
+handling, delay, and abort configuration as the built-in policies.
+*/
 package policy
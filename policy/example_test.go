@@ -0,0 +1,59 @@
+package policy_test
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// quota is a custom policy that allows a limited number of executions.
+type quota[R any] struct {
+	*policy.BaseFailurePolicy[R]
+	remaining atomic.Int32
+}
+
+func (q *quota[R]) ToExecutor(_ R) any {
+	e := &quotaExecutor[R]{quota: q}
+	e.BaseExecutor = &policy.BaseExecutor[R]{
+		Executor:          e,
+		BaseFailurePolicy: q.BaseFailurePolicy,
+	}
+	return e
+}
+
+// quotaExecutor is a policy.Executor that rejects executions when the quota is exceeded.
+type quotaExecutor[R any] struct {
+	*policy.BaseExecutor[R]
+	*quota[R]
+}
+
+func (e *quotaExecutor[R]) PreExecute(_ policy.ExecutionInternal[R]) *common.PolicyResult[R] {
+	if e.remaining.Add(-1) < 0 {
+		return policy.FailureResult[R](ErrQuotaExceeded)
+	}
+	return nil
+}
+
+// This example implements a custom policy that rejects executions once a quota is exceeded.
+func Example() {
+	q := &quota[string]{BaseFailurePolicy: &policy.BaseFailurePolicy[string]{}}
+	q.remaining.Store(1)
+	executor := failsafe.NewExecutor[string](q)
+	fn := func() (string, error) {
+		return "success", nil
+	}
+
+	result, _ := executor.Get(fn)
+	fmt.Println(result)
+	_, err := executor.Get(fn)
+	fmt.Println(err)
+	// Output:
+	// success
+	// quota exceeded
+}
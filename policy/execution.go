@@ -1,10 +1,16 @@
 package policy
 
 import (
+	"log/slog"
+	"time"
+
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
 )
 
+// ExecutionInternal is the internal view of a failsafe.Execution that is provided to an Executor. It allows an Executor
+// to record results, prepare retries, cancel executions, store per-execution state, and create copies of the execution
+// for event listeners, cancellable attempts, and hedges.
 type ExecutionInternal[R any] interface {
 	failsafe.Execution[R]
 
@@ -16,12 +22,45 @@ type ExecutionInternal[R any] interface {
 	// cancellation result is returned.
 	InitializeRetry() *common.PolicyResult[R]
 
+	// CallListener calls the listener, which handles a policy's success or failure result, either immediately or when the
+	// execution is done, according to how the failsafe.Executor orders and dedupes listeners. Executors should call
+	// OnSuccess and OnFailure listeners through this.
+	CallListener(success bool, result *common.PolicyResult[R], listener func())
+
 	// Cancel cancels the execution with the result.
 	Cancel(result *common.PolicyResult[R])
 
 	// IsCanceledWithResult returns whether the execution is canceled, along with the cancellation result, if any.
 	IsCanceledWithResult() (bool, *common.PolicyResult[R])
 
+	// RecordWaitTime records time that the execution spent waiting for permits, such as from a RateLimiter or Bulkhead.
+	RecordWaitTime(waitTime time.Duration)
+
+	// RecordDelayTime records time that the execution spent in a policy delay, such as between retries.
+	RecordDelayTime(delayTime time.Duration)
+
+	// TrackResource tracks a resource of the kind that was created for the execution, such as a permit or goroutine, for
+	// leak detection, returning a func that releases it. Executors should call the func once the resource is released. When
+	// leak detection is not enabled via failsafe.Executor.WithLeakDetection, this does not track anything.
+	TrackResource(kind failsafe.ResourceKind) (release func())
+
+	// Schedule performs the fn asynchronously via the Scheduler that is configured via failsafe.Executor.WithScheduler,
+	// else in a new goroutine if no Scheduler is configured, and then calls the resultFn with its result. A panic in the fn
+	// is recovered and provided to the resultFn as a *failsafe.PanicError result, unless
+	// failsafe.Executor.WithRepanicAsync is configured. Returns false if the Scheduler rejected the fn, in which case
+	// neither the fn nor the resultFn is called. Executors should run async work, such as hedge attempts, through this.
+	Schedule(fn func() *common.PolicyResult[R], resultFn func(*common.PolicyResult[R])) bool
+
+	// Logger returns the logger for debug events that is configured via failsafe.Executor.WithLogger, else nil if logging
+	// is not enabled. Executors should log events such as rejections and state changes through this, and should check for
+	// nil before building log attributes.
+	Logger() *slog.Logger
+
+	// ExecutorState returns state for the key that is shared across all attempts and copies of the execution, creating it
+	// via newStateFn if it does not exist yet. If newStateFn is nil and no state exists, nil is returned. This allows
+	// policy executors, which may be reused across executions, to store mutable per-execution state.
+	ExecutorState(key any, newStateFn func() any) any
+
 	// CopyWithResult returns a copy of the failsafe.Execution with the result. If the result is nil, this will preserve a
 	// copy of the lastResult and lastError. This is useful before passing the execution to an event listener, otherwise
 	// these may be changed if the execution is canceled.
@@ -30,6 +69,19 @@ type ExecutionInternal[R any] interface {
 	// CopyForCancellable creates a cancellable child copy of the execution based on the current execution's context.
 	CopyForCancellable() failsafe.Execution[R]
 
+	// CopyForDeadline creates a cancellable child copy of the execution whose context reports the deadline, or the parent
+	// context's deadline if it's earlier. The context is not canceled when the deadline passes, so the caller is responsible
+	// for canceling the execution.
+	CopyForDeadline(deadline time.Time) failsafe.Execution[R]
+
+	// CopyForDetached creates a cancellable copy of the execution whose context carries the current context's values, but
+	// is not canceled when the current context is, so that the copy can continue after the execution is done.
+	CopyForDetached() failsafe.Execution[R]
+
 	// CopyForHedge creates a copy of the execution marked as a hedge.
 	CopyForHedge() failsafe.Execution[R]
+
+	// CopyWithContextValue creates a copy of the execution whose context carries the value for the key, such as a value
+	// that's specific to an attempt.
+	CopyWithContextValue(key any, value any) failsafe.Execution[R]
 }
@@ -50,6 +50,11 @@ func (p *BaseFailurePolicy[R]) HandleIf(predicate func(R, error) bool) {
 	p.errorsChecked = true
 }
 
+func (p *BaseFailurePolicy[R]) HandleWith(classifier failsafe.Classifier[R]) {
+	p.failureConditions = append(p.failureConditions, classifier.IsFailure)
+	p.errorsChecked = true
+}
+
 func (p *BaseFailurePolicy[R]) OnSuccess(listener func(event failsafe.ExecutionEvent[R])) {
 	p.onSuccess = listener
 }
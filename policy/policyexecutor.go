@@ -17,6 +17,9 @@ type Executor[R any] interface {
 	//
 	// If an Executor delays or blocks during execution, it must check that the execution was not canceled in the
 	// meantime, else return the ExecutionInternal.Result if it was.
+	//
+	// The returned func may be reused across many executions, so any mutable per-execution state should be stored via
+	// ExecutionInternal.ExecutorState rather than in the Executor.
 	Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R]
 
 	// PostExecute performs synchronous post-execution handling for an execution result.
@@ -77,17 +80,32 @@ func (e *BaseExecutor[R]) IsFailure(result R, err error) bool {
 
 func (e *BaseExecutor[R]) OnSuccess(exec ExecutionInternal[R], result *common.PolicyResult[R]) {
 	if e.BaseFailurePolicy != nil && e.onSuccess != nil {
-		e.onSuccess(failsafe.ExecutionEvent[R]{
+		event := failsafe.ExecutionEvent[R]{
 			ExecutionAttempt: exec.CopyWithResult(result),
+		}
+		exec.CallListener(true, result, func() {
+			e.onSuccess(event)
 		})
 	}
 }
 
 func (e *BaseExecutor[R]) OnFailure(exec ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
 	if e.BaseFailurePolicy != nil && e.onFailure != nil {
-		e.onFailure(failsafe.ExecutionEvent[R]{
+		event := failsafe.ExecutionEvent[R]{
 			ExecutionAttempt: exec.CopyWithResult(result),
+		}
+		exec.CallListener(false, result, func() {
+			e.onFailure(event)
 		})
 	}
 	return result
 }
+
+// FailureResult returns a PolicyResult that is done with the err, such as for an Executor to return from PreExecute
+// when an execution is not allowed.
+func FailureResult[R any](err error) *common.PolicyResult[R] {
+	return &common.PolicyResult[R]{
+		Error: err,
+		Done:  true,
+	}
+}
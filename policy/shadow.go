@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+)
+
+// ShadowEvent indicates that a shadowed policy would have affected an execution, such as by rejecting it or replacing
+// its result.
+type ShadowEvent[R any] struct {
+	failsafe.ExecutionAttempt[R]
+	// The result that the policy would have produced, else the zero value for R
+	Result R
+	// The error that the policy would have produced, such as circuitbreaker.ErrOpen, else nil
+	Error error
+	// Whether the policy would have rejected the execution, rather than allowing it to be performed
+	Rejected bool
+}
+
+// Shadow returns a failsafe.Policy that applies the policy in shadow mode, where the policy makes its decisions and
+// emits its events and metrics as usual, but never affects executions. Executions that the policy would reject are still
+// performed, and their actual results are returned rather than any result the policy would produce. When the policy
+// would have affected an execution, the listener, if any, is called. This allows a policy, such as a CircuitBreaker, to
+// be evaluated on a critical path before it's enforced:
+//
+//	cb := circuitbreaker.WithDefaults[*http.Response]()
+//	shadowed := policy.Shadow(cb, func(e policy.ShadowEvent[*http.Response]) {
+//	  logger.Info("circuit breaker would have rejected request", "error", e.Error)
+//	})
+//
+// Shadow mode is intended for policies that reject executions, such as CircuitBreakers, RateLimiters, and Bulkheads.
+// Policies that wait, such as RateLimiters and Bulkheads that are configured with a max wait time, still wait, and
+// policies that perform additional attempts, such as RetryPolicies, should not be shadowed. Timeouts and HedgePolicies
+// cannot be shadowed, since they cancel executions and perform them in other goroutines, and Shadow panics if the policy
+// is one of them.
+//
+// R is the execution result type.
+func Shadow[R any](policy failsafe.Policy[R], listener func(ShadowEvent[R])) failsafe.Policy[R] {
+	if kind := failsafe.KindOf(policy); kind == failsafe.TimeoutKind || kind == failsafe.HedgeKind {
+		panic("shadow mode does not support timeouts or hedge policies")
+	}
+	return &shadowPolicy[R]{
+		policy:   policy,
+		listener: listener,
+	}
+}
+
+type shadowPolicy[R any] struct {
+	policy   failsafe.Policy[R]
+	listener func(ShadowEvent[R])
+}
+
+func (p *shadowPolicy[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.KindOf(p.policy)
+}
+
+func (p *shadowPolicy[R]) PolicyConfig() map[string]any {
+	return failsafe.ConfigOf(p.policy)
+}
+
+func (p *shadowPolicy[R]) PolicyMetrics() []failsafe.Metric {
+	return failsafe.MetricsOf(p.policy)
+}
+
+func (p *shadowPolicy[R]) ToExecutor(typeToken R) any {
+	return &shadowExecutor[R]{
+		Executor:     p.policy.ToExecutor(typeToken).(Executor[R]),
+		shadowPolicy: p,
+	}
+}
+
+// shadowExecutor is an Executor that applies a policy's Executor without allowing it to affect executions.
+type shadowExecutor[R any] struct {
+	Executor[R]
+	*shadowPolicy[R]
+}
+
+func (e *shadowExecutor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		// The policy's Apply is created for each execution so that the actual result can be captured. The innerFn is only
+		// performed once, and any other calls wait for its result.
+		var once sync.Once
+		var actualResult *common.PolicyResult[R]
+		perform := func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+			once.Do(func() {
+				actualResult = innerFn(exec)
+			})
+			return actualResult
+		}
+		var attempted atomic.Bool
+		policyResult := e.Executor.Apply(func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+			attempted.Store(true)
+			return perform(exec)
+		})(exec)
+
+		rejected := !attempted.Load()
+		result := perform(exec)
+		if e.listener != nil && (rejected || !errors.Is(policyResult.Error, result.Error)) {
+			e.listener(ShadowEvent[R]{
+				ExecutionAttempt: exec,
+				Result:           policyResult.Result,
+				Error:            policyResult.Error,
+				Rejected:         rejected,
+			})
+		}
+		return result
+	}
+}
@@ -0,0 +1,80 @@
+package policy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+	"github.com/failsafe-go/failsafe-go/policy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// Asserts that a shadowed CircuitBreaker records results and changes state, but does not reject executions.
+func TestShadowCircuitBreaker(t *testing.T) {
+	// Given
+	cb := circuitbreaker.Builder[string]().WithFailureThreshold(1).Build()
+	var events []policy.ShadowEvent[string]
+	executor := failsafe.NewExecutor[string](policy.Shadow[string](cb, func(e policy.ShadowEvent[string]) {
+		events = append(events, e)
+	}))
+
+	// When
+	_, err := executor.Get(func() (string, error) {
+		return "", errors.New("failed")
+	})
+
+	// Then
+	assert.Error(t, err)
+	assert.True(t, cb.IsOpen())
+	assert.Empty(t, events)
+
+	// When
+	result, err := executor.Get(func() (string, error) {
+		return "success", nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "success", result)
+	assert.Len(t, events, 1)
+	assert.True(t, events[0].Rejected)
+	assert.ErrorIs(t, events[0].Error, circuitbreaker.ErrOpen)
+	assert.Equal(t, failsafe.CircuitBreakerKind, failsafe.KindOf(policy.Shadow[string](cb, nil)))
+}
+
+// Asserts that a shadowed Fallback does not replace results.
+func TestShadowFallback(t *testing.T) {
+	// Given
+	var events []policy.ShadowEvent[string]
+	executor := failsafe.NewExecutor[string](policy.Shadow[string](fallback.WithResult("fallback"), func(e policy.ShadowEvent[string]) {
+		events = append(events, e)
+	}))
+
+	// When
+	_, err := executor.Get(func() (string, error) {
+		return "", errors.New("failed")
+	})
+
+	// Then
+	assert.Error(t, err)
+	assert.Len(t, events, 1)
+	assert.False(t, events[0].Rejected)
+	assert.Equal(t, "fallback", events[0].Result)
+	assert.NoError(t, events[0].Error)
+}
+
+// Asserts that policies that cancel executions or perform them in other goroutines cannot be shadowed.
+func TestShadowShouldPanicForTimeoutsAndHedges(t *testing.T) {
+	assert.Panics(t, func() {
+		policy.Shadow[string](timeout.With[string](time.Second), nil)
+	})
+	assert.Panics(t, func() {
+		policy.Shadow[string](hedgepolicy.WithDelay[string](time.Second), nil)
+	})
+}
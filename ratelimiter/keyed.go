@@ -0,0 +1,56 @@
+package ratelimiter
+
+import (
+	"context"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/keyed"
+)
+
+// KeyedRateLimiter is a Policy that maintains a separate RateLimiter for each key, such as a tenant, host, or API key,
+// and rate limits each execution via the RateLimiter for the execution's key. RateLimiters are created as needed, and
+// the least recently used RateLimiters that have no executions in progress are evicted when there are more than the
+// configured max keys. An evicted key gets a new RateLimiter, with full permits, when it's used again.
+//
+// R is the execution result type. This type is concurrency safe.
+type KeyedRateLimiter[R any] interface {
+	failsafe.Policy[R]
+
+	// Get returns the RateLimiter for the key, creating it if needed.
+	Get(key string) RateLimiter[R]
+
+	// Keys returns the number of keys that currently have a RateLimiter.
+	Keys() int
+}
+
+// Keyed returns a new KeyedRateLimiter that rate limits executions via a separate RateLimiter for each key returned by
+// the keyFunc, which is given the execution's context. RateLimiters are created via the builder, and up to maxKeys
+// RateLimiters are kept, evicting the least recently used that are idle. If maxKeys is 0, the number of RateLimiters is
+// unbounded.
+func Keyed[R any](keyFunc func(ctx context.Context) string, builder RateLimiterBuilder[R], maxKeys uint) KeyedRateLimiter[R] {
+	return &keyedRateLimiter[R]{
+		keyFunc:  keyFunc,
+		limiters: keyed.New[RateLimiter[R]](int(maxKeys), builder.Build),
+	}
+}
+
+type keyedRateLimiter[R any] struct {
+	keyFunc  func(ctx context.Context) string
+	limiters *keyed.Policies[RateLimiter[R]]
+}
+
+func (k *keyedRateLimiter[R]) Get(key string) RateLimiter[R] {
+	return k.limiters.Get(key)
+}
+
+func (k *keyedRateLimiter[R]) Keys() int {
+	return k.limiters.Len()
+}
+
+func (k *keyedRateLimiter[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.RateLimiterKind
+}
+
+func (k *keyedRateLimiter[R]) ToExecutor(_ R) any {
+	return keyed.NewExecutor[R](k.keyFunc, k.limiters)
+}
@@ -3,16 +3,72 @@ package ratelimiter
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/internal/util"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
-// ErrExceeded is returned when an execution exceeds a configured rate limit.
+// ErrExceeded is a convenience error sentinel that can be used to build policies that handle ExceededError, such as via
+// HandleErrors(ratelimiter.ErrExceeded). It can also be used with Errors.Is to determine whether an error is a
+// ratelimiter.ExceededError.
 var ErrExceeded = errors.New("rate limit exceeded")
 
+// ErrInvalidRate is returned when a rate with no max executions, or a period that is too short for the max executions, is
+// set.
+var ErrInvalidRate = errors.New("invalid rate")
+
+// ExceededError is returned when an execution exceeds a configured rate limit. This type can be used with
+// HandleErrorTypes(ratelimiter.ExceededError{}), or with errors.As to determine which of several RateLimiters rejected an
+// execution.
+type ExceededError struct {
+	// RateLimiter is the RateLimiter that rejected the execution, which can be compared with a RateLimiter instance.
+	RateLimiter any
+	// Name is the name of the RateLimiter, else an empty string if it's not named.
+	Name string
+	// MaxWaitTime is the max time that would have been waited for a permit.
+	MaxWaitTime time.Duration
+}
+
+func (e ExceededError) Error() string {
+	return fmt.Sprintf("rate limit exceeded. %smax wait time: %v", internal.ErrorName(e.Name), e.MaxWaitTime)
+}
+
+func (e ExceededError) Is(err error) bool {
+	if err == ErrExceeded {
+		return true
+	}
+	return err == e
+}
+
+// ErrWaitThresholdExceeded is a convenience error sentinel that can be used to build policies that handle
+// WaitThresholdExceededError, such as via HandleErrors(ratelimiter.ErrWaitThresholdExceeded).
+var ErrWaitThresholdExceeded = errors.New("rate limiter wait threshold exceeded")
+
+// WaitThresholdExceededError is returned when an execution is rejected because it would wait longer than a RateLimiter's
+// configured wait threshold for a permit. This allows outer policies, such as a CircuitBreaker, to treat sustained
+// throttling as a failure. This type can be used with HandleErrorTypes(ratelimiter.WaitThresholdExceededError{}).
+type WaitThresholdExceededError struct {
+	// Name is the name of the RateLimiter, else an empty string if it's not named.
+	Name string
+	// WaitThreshold is the wait threshold that the execution would have exceeded.
+	WaitThreshold time.Duration
+}
+
+func (e WaitThresholdExceededError) Error() string {
+	return fmt.Sprintf("rate limiter wait threshold exceeded. %swait threshold: %v", internal.ErrorName(e.Name), e.WaitThreshold)
+}
+
+func (e WaitThresholdExceededError) Is(err error) bool {
+	if err == ErrWaitThresholdExceeded {
+		return true
+	}
+	return err == e
+}
+
 /*
 RateLimiter is a Policy that can control the rate of executions as a way of preventing system overload.
 
@@ -111,6 +167,16 @@ type RateLimiter[R any] interface {
 	//  - Returns 0 if the permit was successfully reserved and no waiting is needed.
 	//  - Returns -1 if the permit was not reserved because the wait time would be greater than the maxWaitTime.
 	TryReservePermits(requestedPermits uint, maxWaitTime time.Duration) time.Duration
+
+	// SetRate changes the rate limiter's rate to the maxExecutions per period, without affecting permits that were already
+	// acquired or reserved. For smooth rate limiters, the individual execution rate is computed as period / maxExecutions.
+	// For bursty rate limiters, if the period changes, a new period begins immediately. Returns ErrInvalidRate, without
+	// changing the rate, if maxExecutions is 0 or the period is too short to allow maxExecutions.
+	SetRate(maxExecutions uint, period time.Duration) error
+
+	// SetMaxRate changes the rate limiter's rate to one execution per maxRate, without affecting permits that were already
+	// acquired or reserved. This is equivalent to SetRate(1, maxRate).
+	SetMaxRate(maxRate time.Duration) error
 }
 
 /*
@@ -119,6 +185,8 @@ RateLimiterBuilder builds RateLimiter instances.
 R is the execution result type. This type is not concurrency safe.
 */
 type RateLimiterBuilder[R any] interface {
+	failsafe.NamedPolicyBuilder[RateLimiterBuilder[R]]
+
 	// WithMaxWaitTime configures the maxWaitTime to wait for permits to be available. If permits cannot be acquired before
 	// the maxWaitTime is exceeded, then the rate limiter will return ErrExceeded.
 	//
@@ -126,24 +194,92 @@ type RateLimiterBuilder[R any] interface {
 	// apply when the RateLimiter is used in a standalone way.
 	WithMaxWaitTime(maxWaitTime time.Duration) RateLimiterBuilder[R]
 
+	// WithWaitThreshold configures a threshold for the time an execution waits for a permit, beyond which the execution is
+	// rejected with a WaitThresholdExceededError rather than ErrExceeded, without being performed. Outer policies, such as
+	// a CircuitBreaker, can handle the error to treat sustained throttling as a failure. The threshold only applies when
+	// it's less than the maxWaitTime.
+	//
+	// This setting only applies when the resulting RateLimiter is used with the failsafe.Run or related APIs. It does not
+	// apply when the RateLimiter is used in a standalone way.
+	WithWaitThreshold(waitThreshold time.Duration) RateLimiterBuilder[R]
+
+	// WithWarmup configures the rate to ramp up from the coldRate to the max rate over the warmupPeriod, so that a cold
+	// resource, such as an empty cache or idle connection pool, is not immediately used at the max rate. The rate ramps up
+	// while permits are in use, and cools back down towards the coldRate while the RateLimiter is idle, so the full
+	// warmupPeriod applies again after the RateLimiter has been idle for at least the warmupPeriod. For example, a coldRate
+	// of 100*time.Millisecond would allow one execution every 100 milliseconds when cold.
+	//
+	// This setting only applies to smooth rate limiters.
+	WithWarmup(coldRate time.Duration, warmupPeriod time.Duration) RateLimiterBuilder[R]
+
+	// WithClock configures the clock that is used to track the rate and wait for permits, such as a failsafe.FakeClock
+	// when testing. By default, failsafe.SystemClock is used. The clock does not apply to permits acquired from a store.
+	WithClock(clock failsafe.TimerClock) RateLimiterBuilder[R]
+
 	// OnRateLimitExceeded registers the listener to be called when the rate limit is exceeded.
 	OnRateLimitExceeded(listener func(failsafe.ExecutionEvent[R])) RateLimiterBuilder[R]
 
+	// OnPeriodRollover registers the listener to be called with the permit usage for a period after it ends. Since periods
+	// are tracked lazily, the listener is called when permits are first requested after a period ends, and periods in
+	// which no permits were requested are not reported. This setting only applies to bursty rate limiters.
+	OnPeriodRollover(listener func(PeriodStats)) RateLimiterBuilder[R]
+
+	// WithStore configures the RateLimiter to acquire permits from a token bucket with the key in the store, which can be
+	// shared by many RateLimiter instances, such as across a fleet of servers, so that they collectively respect one rate
+	// limit. The token bucket holds up to 1 permit for smooth rate limiters, and up to maxExecutions permits for bursty
+	// rate limiters, and is refilled at the configured rate. If the store fails, permits are acquired locally instead,
+	// and the failure is reported to any OnStoreError listener.
+	//
+	// OnPeriodRollover listeners are not called for permits acquired from a store.
+	WithStore(store Store, key string) RateLimiterBuilder[R]
+
+	// OnStoreError registers the listener to be called when a store configured via WithStore fails.
+	OnStoreError(listener func(err error)) RateLimiterBuilder[R]
+
+	// FailOpen configures the RateLimiter to permit executions when a store configured via WithStore fails, rather than
+	// acquiring permits locally. This avoids rejecting or delaying executions while a store is unavailable, at the cost of
+	// not limiting them. Store failures are still reported to any OnStoreError listener. Bulkheads have no equivalent
+	// option, since they only track permits in memory.
+	FailOpen() RateLimiterBuilder[R]
+
 	// Build returns a new RateLimiter using the builder's configuration.
 	Build() RateLimiter[R]
 }
 
+// PeriodStats contains the permit usage for a bursty RateLimiter period.
+type PeriodStats struct {
+	// MaxPermits is the max number of permits for the period.
+	MaxPermits int
+	// UsedPermits is the number of permits that were acquired or reserved during the period, which can exceed the
+	// MaxPermits when permits are reserved from future periods.
+	UsedPermits int
+	// RejectedPermits is the number of permits that were requested but rejected during the period.
+	RejectedPermits int
+}
+
 type config[R any] struct {
+	name string
 	// Common
 	maxWaitTime         time.Duration
+	waitThreshold       time.Duration
+	clock               failsafe.TimerClock
 	onRateLimitExceeded func(failsafe.ExecutionEvent[R])
 
 	// Smooth
-	interval time.Duration
+	interval     time.Duration
+	coldInterval time.Duration
+	warmupPeriod time.Duration
 
 	// Bursty
-	periodPermits int
-	period        time.Duration
+	periodPermits    int
+	period           time.Duration
+	onPeriodRollover func(PeriodStats)
+
+	// Distributed
+	store        Store
+	storeKey     string
+	onStoreError func(error)
+	failOpen     bool
 }
 
 /*
@@ -183,6 +319,7 @@ will block and wait until the max wait time is exceeded.
 func SmoothBuilder[R any](maxExecutions uint, period time.Duration) RateLimiterBuilder[R] {
 	return &config[R]{
 		interval: period / time.Duration(maxExecutions),
+		clock:    failsafe.SystemClock(),
 	}
 }
 
@@ -199,6 +336,7 @@ and wait until the max wait time is exceeded.
 func SmoothBuilderWithMaxRate[R any](maxRate time.Duration) RateLimiterBuilder[R] {
 	return &config[R]{
 		interval: maxRate,
+		clock:    failsafe.SystemClock(),
 	}
 }
 
@@ -226,6 +364,7 @@ func BurstyBuilder[R any](maxExecutions uint, period time.Duration) RateLimiterB
 	return &config[R]{
 		periodPermits: int(maxExecutions),
 		period:        period,
+		clock:         failsafe.SystemClock(),
 	}
 }
 
@@ -234,31 +373,94 @@ func (c *config[R]) WithMaxWaitTime(maxWaitTime time.Duration) RateLimiterBuilde
 	return c
 }
 
+func (c *config[R]) WithWaitThreshold(waitThreshold time.Duration) RateLimiterBuilder[R] {
+	c.waitThreshold = waitThreshold
+	return c
+}
+
+func (c *config[R]) WithWarmup(coldRate time.Duration, warmupPeriod time.Duration) RateLimiterBuilder[R] {
+	c.coldInterval = coldRate
+	c.warmupPeriod = warmupPeriod
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.TimerClock) RateLimiterBuilder[R] {
+	c.clock = clock
+	return c
+}
+
 func (c *config[R]) OnRateLimitExceeded(listener func(event failsafe.ExecutionEvent[R])) RateLimiterBuilder[R] {
 	c.onRateLimitExceeded = listener
 	return c
 }
 
+func (c *config[R]) OnPeriodRollover(listener func(PeriodStats)) RateLimiterBuilder[R] {
+	c.onPeriodRollover = listener
+	return c
+}
+
+func (c *config[R]) WithStore(store Store, key string) RateLimiterBuilder[R] {
+	c.store = store
+	c.storeKey = key
+	return c
+}
+
+func (c *config[R]) OnStoreError(listener func(err error)) RateLimiterBuilder[R] {
+	c.onStoreError = listener
+	return c
+}
+
+func (c *config[R]) FailOpen() RateLimiterBuilder[R] {
+	c.failOpen = true
+	return c
+}
+
+func (c *config[R]) WithName(name string) RateLimiterBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() RateLimiter[R] {
+	var localStats stats
 	if c.interval != 0 {
+		localStats = &smoothStats[R]{
+			config:    c, // TODO copy base fields
+			stopwatch: util.NewStopwatch(c.clock.Now),
+			interval:  c.interval,
+		}
+	} else {
+		localStats = &burstyStats[R]{
+			config:           c, // TODO copy base fields
+			stopwatch:        util.NewStopwatch(c.clock.Now),
+			periodPermits:    c.periodPermits,
+			period:           c.period,
+			availablePermits: c.periodPermits,
+		}
+	}
+	if c.store != nil {
 		return &rateLimiter[R]{
 			config: c,
-			stats: &smoothStats[R]{
-				config:    c, // TODO copy base fields
-				stopwatch: util.NewStopwatch(),
+			stats: &storeStats[R]{
+				config:   c,
+				bucket:   c.bucket(),
+				fallback: localStats,
 			},
 		}
 	}
 	return &rateLimiter[R]{
 		config: c,
-		stats: &burstyStats[R]{
-			config:           c, // TODO copy base fields
-			stopwatch:        util.NewStopwatch(),
-			availablePermits: c.periodPermits,
-		},
+		stats:  localStats,
 	}
 }
 
+// bucket returns a Bucket for the config's rate.
+func (c *config[R]) bucket() Bucket {
+	if c.interval != 0 {
+		return Bucket{Capacity: 1, Interval: c.interval}
+	}
+	return Bucket{Capacity: c.periodPermits, Interval: c.period / time.Duration(c.periodPermits)}
+}
+
 type rateLimiter[R any] struct {
 	*config[R]
 	stats stats
@@ -270,53 +472,71 @@ func (r *rateLimiter[R]) AcquirePermit(ctx context.Context) error {
 
 func (r *rateLimiter[R]) AcquirePermits(ctx context.Context, permits uint) error {
 	waitTime := r.ReservePermits(permits)
+	waited, timer := r.newTimer(waitTime)
 	if ctx != nil {
-		timer := time.NewTimer(waitTime)
 		select {
-		case <-timer.C:
+		case <-waited:
 		case <-ctx.Done():
 			timer.Stop()
 			return ctx.Err()
 		}
 	} else {
-		time.Sleep(waitTime)
+		<-waited
 	}
 	return nil
 }
 
 func (r *rateLimiter[R]) AcquirePermitWithMaxWait(ctx context.Context, maxWaitTime time.Duration) error {
-	return r.acquirePermitsWithMaxWait(ctx, nil, 1, maxWaitTime)
+	_, err := r.acquirePermitsWithMaxWait(ctx, nil, 1, maxWaitTime)
+	return err
 }
 
 func (r *rateLimiter[R]) AcquirePermitsWithMaxWait(ctx context.Context, requestedPermits uint, maxWaitTime time.Duration) error {
-	return r.acquirePermitsWithMaxWait(ctx, nil, requestedPermits, maxWaitTime)
+	_, err := r.acquirePermitsWithMaxWait(ctx, nil, requestedPermits, maxWaitTime)
+	return err
 }
 
-func (r *rateLimiter[R]) acquirePermitsWithMaxWait(ctx context.Context, exec failsafe.Execution[R], requestedPermits uint, maxWaitTime time.Duration) error {
-	waitTime := r.stats.acquirePermits(int(requestedPermits), maxWaitTime)
-	if waitTime == -1 {
-		return ErrExceeded
-	}
+// acquirePermitsWithMaxWait acquires the requestedPermits and returns the time that was waited for them.
+func (r *rateLimiter[R]) acquirePermitsWithMaxWait(ctx context.Context, exec failsafe.Execution[R], requestedPermits uint, maxWaitTime time.Duration) (time.Duration, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	timer := time.NewTimer(waitTime)
+	waitTime := r.stats.acquirePermits(ctx, int(requestedPermits), maxWaitTime)
+	if waitTime == -1 {
+		return 0, ExceededError{RateLimiter: r, Name: r.name, MaxWaitTime: maxWaitTime}
+	}
+	if waitTime == 0 {
+		// Avoid creating a timer when a permit is immediately available
+		return 0, nil
+	}
+	waited, timer := r.newTimer(waitTime)
 	if exec == nil {
 		select {
-		case <-timer.C:
+		case <-waited:
 		case <-ctx.Done():
 			timer.Stop()
-			return ctx.Err()
+			return 0, ctx.Err()
 		}
 	} else {
 		select {
-		case <-timer.C:
+		case <-waited:
+			exec.(policy.ExecutionInternal[R]).RecordWaitTime(waitTime)
 		case <-exec.Canceled():
 			timer.Stop()
-			return exec.LastError()
+			return 0, exec.LastError()
 		}
 	}
-	return nil
+	return waitTime, nil
+}
+
+// newTimer returns a channel that is closed once the waitTime elapses on the rate limiter's clock, along with a Timer that
+// can stop it.
+func (r *rateLimiter[R]) newTimer(waitTime time.Duration) (<-chan struct{}, failsafe.Timer) {
+	waited := make(chan struct{})
+	timer := r.clock.AfterFunc(waitTime, func() {
+		close(waited)
+	})
+	return waited, timer
 }
 
 func (r *rateLimiter[R]) ReservePermit() time.Duration {
@@ -324,7 +544,7 @@ func (r *rateLimiter[R]) ReservePermit() time.Duration {
 }
 
 func (r *rateLimiter[R]) ReservePermits(permits uint) time.Duration {
-	return r.stats.acquirePermits(int(permits), -1)
+	return r.stats.acquirePermits(context.Background(), int(permits), -1)
 }
 
 func (r *rateLimiter[R]) TryAcquirePermit() bool {
@@ -340,7 +560,49 @@ func (r *rateLimiter[R]) TryReservePermit(maxWaitTime time.Duration) time.Durati
 }
 
 func (r *rateLimiter[R]) TryReservePermits(requestedPermits uint, maxWaitTime time.Duration) time.Duration {
-	return r.stats.acquirePermits(int(requestedPermits), maxWaitTime)
+	return r.stats.acquirePermits(context.Background(), int(requestedPermits), maxWaitTime)
+}
+
+func (r *rateLimiter[R]) SetRate(maxExecutions uint, period time.Duration) error {
+	// Reject rates that would result in a zero interval between permits
+	if maxExecutions == 0 || period < time.Duration(maxExecutions) {
+		return ErrInvalidRate
+	}
+	r.stats.setRate(int(maxExecutions), period)
+	return nil
+}
+
+func (r *rateLimiter[R]) SetMaxRate(maxRate time.Duration) error {
+	return r.SetRate(1, maxRate)
+}
+
+func (r *rateLimiter[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.RateLimiterKind
+}
+
+func (r *rateLimiter[R]) PolicyName() string {
+	return r.name
+}
+
+func (r *rateLimiter[R]) PolicyConfig() map[string]any {
+	config := map[string]any{
+		"maxWaitTime":   r.maxWaitTime,
+		"waitThreshold": r.waitThreshold,
+		"distributed":   r.store != nil,
+	}
+	if r.store != nil {
+		config["failOpen"] = r.failOpen
+	}
+	// Report the current rate, which may have been changed via SetRate
+	periodPermits, period := r.stats.rate()
+	if r.interval != 0 {
+		config["interval"] = period
+		config["warmupPeriod"] = r.warmupPeriod
+	} else {
+		config["periodPermits"] = periodPermits
+		config["period"] = period
+	}
+	return config
 }
 
 func (r *rateLimiter[R]) ToExecutor(_ R) any {
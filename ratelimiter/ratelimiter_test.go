@@ -30,7 +30,10 @@ func TestAcquirePermitWithMaxWaitTime(t *testing.T) {
 	assert.Nil(t, limiter.AcquirePermitWithMaxWait(nil, 100*time.Millisecond))  // waits 0
 	assert.Nil(t, limiter.AcquirePermitWithMaxWait(nil, 1000*time.Millisecond)) // waits 100
 	err := limiter.AcquirePermitWithMaxWait(nil, 100*time.Millisecond)          // waits 200
-	assert.ErrorIs(t, ErrExceeded, err)
+	assert.ErrorIs(t, err, ErrExceeded)
+	var exceededErr ExceededError
+	assert.ErrorAs(t, err, &exceededErr)
+	assert.Equal(t, ExceededError{RateLimiter: limiter, MaxWaitTime: 100 * time.Millisecond}, exceededErr)
 }
 
 func TestTryAcquirePermit(t *testing.T) {
@@ -71,6 +74,40 @@ func TestTryReservePermit(t *testing.T) {
 	assert.Equal(t, time.Duration(-1), limiter.TryReservePermit(100*time.Millisecond))
 }
 
+func TestSetRateWithInvalidRate(t *testing.T) {
+	// Given
+	smooth := SmoothBuilderWithMaxRate[any](100 * time.Millisecond).Build()
+	bursty := BurstyBuilder[any](10, time.Second).Build()
+
+	// When / Then
+	for _, limiter := range []RateLimiter[any]{smooth, bursty} {
+		assert.ErrorIs(t, limiter.SetRate(0, time.Second), ErrInvalidRate)
+		assert.ErrorIs(t, limiter.SetRate(10, 0), ErrInvalidRate)
+		assert.ErrorIs(t, limiter.SetRate(10, -time.Second), ErrInvalidRate)
+		assert.ErrorIs(t, limiter.SetRate(10, 5*time.Nanosecond), ErrInvalidRate)
+		assert.ErrorIs(t, limiter.SetMaxRate(0), ErrInvalidRate)
+		assert.True(t, limiter.TryAcquirePermit())
+	}
+	assert.NoError(t, smooth.SetRate(20, time.Second))
+	assert.NoError(t, bursty.SetMaxRate(time.Second))
+}
+
+// Asserts that the policy config reports the current rate after it's changed.
+func TestPolicyConfigAfterSetRate(t *testing.T) {
+	// Given
+	smooth := SmoothBuilderWithMaxRate[any](100 * time.Millisecond).Build()
+	bursty := BurstyBuilder[any](10, time.Second).Build()
+
+	// When
+	assert.NoError(t, smooth.SetRate(20, time.Second))
+	assert.NoError(t, bursty.SetRate(5, time.Minute))
+
+	// Then
+	assert.Equal(t, 50*time.Millisecond, smooth.(*rateLimiter[any]).PolicyConfig()["interval"])
+	assert.Equal(t, 5, bursty.(*rateLimiter[any]).PolicyConfig()["periodPermits"])
+	assert.Equal(t, time.Minute, bursty.(*rateLimiter[any]).PolicyConfig()["period"])
+}
+
 func setTestStopwatch[R any](limiter RateLimiter[R]) *testutil.TestStopwatch {
 	stopwatch := &testutil.TestStopwatch{}
 	limiter.(*rateLimiter[R]).stats.(*smoothStats[R]).stopwatch = stopwatch
@@ -18,8 +18,21 @@ type executor[R any] struct {
 var _ policy.Executor[any] = &executor[any]{}
 
 func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	// Wait up to the waitThreshold, if it's less than the maxWaitTime
+	maxWaitTime := e.maxWaitTime
+	thresholded := e.waitThreshold > 0 && (maxWaitTime == -1 || e.waitThreshold < maxWaitTime)
+	if thresholded {
+		maxWaitTime = e.waitThreshold
+	}
+
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
-		if err := e.acquirePermitsWithMaxWait(exec.Context(), exec, 1, e.maxWaitTime); err != nil {
+		if _, err := e.acquirePermitsWithMaxWait(exec.Context(), exec, 1, maxWaitTime); err != nil {
+			if thresholded && errors.Is(err, ErrExceeded) {
+				err = WaitThresholdExceededError{Name: e.name, WaitThreshold: e.waitThreshold}
+			}
+			if logger := internal.NamedLogger(exec.(policy.ExecutionInternal[R]).Logger(), e.name); logger != nil {
+				logger.Debug("rate limiter rejected execution", "policy", failsafe.RateLimiterKind, "attempts", exec.Attempts(), "error", err)
+			}
 			if e.onRateLimitExceeded != nil && errors.Is(err, ErrExceeded) {
 				e.onRateLimitExceeded(failsafe.ExecutionEvent[R]{
 					ExecutionAttempt: exec,
@@ -1,6 +1,7 @@
 package ratelimiter
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -9,8 +10,15 @@ import (
 
 type stats interface {
 	// acquirePermits eagerly acquires requestedPermits and returns the time that must be waited in order to use the permits,
-	// else returns -1 if the wait time would exceed the maxWaitTime. A maxWaitTime of -1 indicates no max wait.
-	acquirePermits(requestedPermits int, maxWaitTime time.Duration) time.Duration
+	// else returns -1 if the wait time would exceed the maxWaitTime. A maxWaitTime of -1 indicates no max wait. The ctx
+	// bounds any calls to a Store.
+	acquirePermits(ctx context.Context, requestedPermits int, maxWaitTime time.Duration) time.Duration
+
+	// setRate sets the rate to the periodPermits per period, preserving permits that were already acquired.
+	setRate(periodPermits int, period time.Duration)
+
+	// rate returns the current permits per period. For smooth stats, this is 1 permit per interval.
+	rate() (periodPermits int, period time.Duration)
 
 	reset()
 }
@@ -22,30 +30,43 @@ type smoothStats[R any] struct {
 	stopwatch util.Stopwatch
 	mtx       sync.Mutex
 
+	// The interval between permits, which shadows the config's interval since it can be changed.
+	// Guarded by mtx
+	interval time.Duration
 	// The amount of time, relative to the start time, that the next permit will be free.
-	// Will be a multiple of the config.interval.
+	// Will be a multiple of the interval, unless the interval was changed.
 	// Guarded by mtx
 	nextFreePermitTime time.Duration
+	// The amount of warmup that has accumulated, from 0 to the warmupPeriod, when a warmup is configured.
+	// Guarded by mtx
+	warmth time.Duration
+	// The time, relative to the start time, that permits were last acquired, when a warmup is configured.
+	// Guarded by mtx
+	lastAcquireTime time.Duration
 }
 
-func (s *smoothStats[R]) acquirePermits(requestedPermits int, maxWaitTime time.Duration) time.Duration {
+func (s *smoothStats[R]) acquirePermits(_ context.Context, requestedPermits int, maxWaitTime time.Duration) time.Duration {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
 	currentTime := s.stopwatch.ElapsedTime()
-	requestedPermitTime := s.interval * time.Duration(requestedPermits)
+	interval := s.interval
+	if s.warmupPeriod > 0 {
+		interval = s.warmupInterval(currentTime)
+	}
+	requestedPermitTime := interval * time.Duration(requestedPermits)
 	var newNextFreePermitTime time.Duration
 
 	// If a permit is currently available
 	if currentTime >= s.nextFreePermitTime {
 		// Time at the start of the current interval
-		currentIntervalTime := util.RoundDown(currentTime, s.interval)
+		currentIntervalTime := util.RoundDown(currentTime, interval)
 		newNextFreePermitTime = currentIntervalTime + requestedPermitTime
 	} else {
 		newNextFreePermitTime = s.nextFreePermitTime + requestedPermitTime
 	}
 
-	waitTime := max(newNextFreePermitTime-currentTime-s.interval, time.Duration(0))
+	waitTime := max(newNextFreePermitTime-currentTime-interval, time.Duration(0))
 	if exceedsMaxWaitTime(waitTime, maxWaitTime) {
 		return -1
 	}
@@ -54,11 +75,41 @@ func (s *smoothStats[R]) acquirePermits(requestedPermits int, maxWaitTime time.D
 	return waitTime
 }
 
+// warmupInterval updates the warmth as of the currentTime and returns the interval between permits for it, which
+// decreases linearly from the coldInterval to the interval as the warmth increases. Warmth increases while permits are
+// in use, and decreases while the rate limiter is idle.
+//
+// Requires external locking.
+func (s *smoothStats[R]) warmupInterval(currentTime time.Duration) time.Duration {
+	idleTime := max(currentTime-max(s.nextFreePermitTime, s.lastAcquireTime), 0)
+	busyTime := currentTime - s.lastAcquireTime - idleTime
+	s.warmth = min(max(s.warmth-idleTime, 0)+busyTime, s.warmupPeriod)
+	s.lastAcquireTime = currentTime
+
+	coldInterval := max(s.coldInterval, s.interval)
+	warmupRatio := float64(s.warmth) / float64(s.warmupPeriod)
+	return coldInterval - time.Duration(float64(coldInterval-s.interval)*warmupRatio)
+}
+
+func (s *smoothStats[R]) setRate(periodPermits int, period time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.interval = period / time.Duration(periodPermits)
+}
+
+func (s *smoothStats[R]) rate() (int, time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return 1, s.interval
+}
+
 func (s *smoothStats[R]) reset() {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 	s.stopwatch.Reset()
 	s.nextFreePermitTime = 0
+	s.warmth = 0
+	s.lastAcquireTime = 0
 }
 
 // A rate limiter implementation that allows bursts of executions, up to the max permits per period. This implementation
@@ -70,20 +121,41 @@ type burstyStats[R any] struct {
 	stopwatch util.Stopwatch
 	mtx       sync.Mutex
 
+	// The permits per period, which shadow the config's since they can be changed.
+	// Guarded by mtx
+	periodPermits int
+	period        time.Duration
 	// Available permits. Can be negative during a deficit.
 	// Guarded by mtx
 	availablePermits int
 	currentPeriod    int
+	// Permit usage for the current period
+	usedPermits     int
+	rejectedPermits int
 }
 
-func (s *burstyStats[R]) acquirePermits(requestedPermits int, maxWaitTime time.Duration) time.Duration {
+func (s *burstyStats[R]) acquirePermits(_ context.Context, requestedPermits int, maxWaitTime time.Duration) time.Duration {
 	s.mtx.Lock()
-	defer s.mtx.Unlock()
+	waitTime, endedPeriod := s.acquirePermitsLocked(requestedPermits, maxWaitTime)
+	s.mtx.Unlock()
+
+	// Call the listener outside the lock
+	if endedPeriod != nil && s.onPeriodRollover != nil {
+		s.onPeriodRollover(*endedPeriod)
+	}
+	return waitTime
+}
 
+// acquirePermitsLocked acquires permits, returning the wait time along with the stats for the previous period if it
+// ended and had any permit requests.
+//
+// Requires external locking.
+func (s *burstyStats[R]) acquirePermitsLocked(requestedPermits int, maxWaitTime time.Duration) (time.Duration, *PeriodStats) {
 	currentTime := s.stopwatch.ElapsedTime()
 	newCurrentPeriod := int(currentTime / s.period)
 
 	// Update current period and available permits
+	var endedPeriod *PeriodStats
 	if s.currentPeriod < newCurrentPeriod {
 		elapsedPeriods := newCurrentPeriod - s.currentPeriod
 		elapsedPermits := elapsedPeriods * s.periodPermits
@@ -93,6 +165,15 @@ func (s *burstyStats[R]) acquirePermits(requestedPermits int, maxWaitTime time.D
 		} else {
 			s.availablePermits = s.periodPermits
 		}
+		if s.usedPermits != 0 || s.rejectedPermits != 0 {
+			endedPeriod = &PeriodStats{
+				MaxPermits:      s.periodPermits,
+				UsedPermits:     s.usedPermits,
+				RejectedPermits: s.rejectedPermits,
+			}
+			s.usedPermits = 0
+			s.rejectedPermits = 0
+		}
 	}
 
 	waitTime := 0 * time.Second
@@ -111,12 +192,36 @@ func (s *burstyStats[R]) acquirePermits(requestedPermits int, maxWaitTime time.D
 		// The time to wait until the beginning of the next period that will have free permits
 		waitTime = timeToNextPeriod + (time.Duration(additionalPeriods) * s.period)
 		if exceedsMaxWaitTime(waitTime, maxWaitTime) {
-			return -1
+			s.rejectedPermits += requestedPermits
+			return -1, endedPeriod
 		}
 	}
 
 	s.availablePermits -= requestedPermits
-	return waitTime
+	s.usedPermits += requestedPermits
+	return waitTime, endedPeriod
+}
+
+func (s *burstyStats[R]) setRate(periodPermits int, period time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if period == s.period {
+		// Adjust the permits that are available in the current period
+		s.availablePermits += periodPermits - s.periodPermits
+	} else {
+		// Start a new period, carrying over any deficit so that permits which were reserved are still accounted for
+		s.stopwatch.Reset()
+		s.currentPeriod = 0
+		s.availablePermits = periodPermits + min(s.availablePermits, 0)
+	}
+	s.periodPermits = periodPermits
+	s.period = period
+}
+
+func (s *burstyStats[R]) rate() (int, time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.periodPermits, s.period
 }
 
 func (s *burstyStats[R]) reset() {
@@ -125,6 +230,8 @@ func (s *burstyStats[R]) reset() {
 	s.stopwatch.Reset()
 	s.availablePermits = s.periodPermits
 	s.currentPeriod = 0
+	s.usedPermits = 0
+	s.rejectedPermits = 0
 }
 
 // exceedsMaxWaitTime returns whether the waitTime would exceed the maxWaitTime, else false if maxWaitTime is -1.
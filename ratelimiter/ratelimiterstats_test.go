@@ -1,6 +1,7 @@
 package ratelimiter
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -59,20 +60,20 @@ func TestSmoothAcquireInitialStats(t *testing.T) {
 	// Given 1 permit every 100ns
 	s, stopwatch := newSmoothLimiterStats(100 * time.Nanosecond)
 
-	testutil.AssertDuration(t, 0, s.acquirePermits(1, -1))
-	testutil.AssertDuration(t, 100, s.acquirePermits(1, -1))
+	testutil.AssertDuration(t, 0, s.acquirePermits(context.Background(), 1, -1))
+	testutil.AssertDuration(t, 100, s.acquirePermits(context.Background(), 1, -1))
 	stopwatch.CurrentTime = 100
-	testutil.AssertDuration(t, 100, s.acquirePermits(1, -1))
-	testutil.AssertDuration(t, 200, s.acquirePermits(1, -1))
+	testutil.AssertDuration(t, 100, s.acquirePermits(context.Background(), 1, -1))
+	testutil.AssertDuration(t, 200, s.acquirePermits(context.Background(), 1, -1))
 
 	// Given 1 permit every 100ns
 	s, stopwatch = newSmoothLimiterStats(100 * time.Nanosecond)
 
-	testutil.AssertDuration(t, 0, s.acquirePermits(1, -1))
+	testutil.AssertDuration(t, 0, s.acquirePermits(context.Background(), 1, -1))
 	stopwatch.CurrentTime = 150
-	testutil.AssertDuration(t, 0, s.acquirePermits(1, -1))
+	testutil.AssertDuration(t, 0, s.acquirePermits(context.Background(), 1, -1))
 	stopwatch.CurrentTime = 250
-	testutil.AssertDuration(t, 50, s.acquirePermits(2, -1))
+	testutil.AssertDuration(t, 50, s.acquirePermits(context.Background(), 2, -1))
 }
 
 // Asserts that wait times and available permits are expected, over time, when calling acquirePermits.
@@ -185,6 +186,102 @@ func newSmoothLimiterStats(maxRate time.Duration) (*smoothStats[any], *testutil.
 	return s, stopwatch
 }
 
+// Asserts that a smooth rate limiter with a warmup ramps up its rate while in use and cools down while idle.
+func TestSmoothWarmup(t *testing.T) {
+	// Given 1 permit every 100ms, which is 1 permit every 400ms when cold, with a 1s warmup
+	s := SmoothBuilderWithMaxRate[any](100*time.Millisecond).
+		WithWarmup(400*time.Millisecond, time.Second).
+		Build().(*rateLimiter[any]).stats.(*smoothStats[any])
+	stopwatch := &testutil.TestStopwatch{}
+	s.stopwatch = stopwatch
+
+	// When cold
+	assert.Equal(t, 0, acquire(s, 1))
+	assert.Equal(t, 400, acquire(s, 1))
+
+	// When half warm
+	stopwatch.CurrentTime = testutil.MillisToNanos(500)
+	assert.Equal(t, 300, acquire(s, 1))
+
+	// When warm
+	stopwatch.CurrentTime = testutil.MillisToNanos(1000)
+	assert.Equal(t, 50, acquire(s, 1))
+	assert.Equal(t, time.Second, s.warmth)
+
+	// When cooled down after being idle
+	stopwatch.CurrentTime = testutil.MillisToNanos(3000)
+	assert.Equal(t, 0, acquire(s, 1))
+	assert.Equal(t, 150*time.Millisecond, s.warmth)
+}
+
+// Asserts that permit usage is reported when a period rolls over.
+func TestBurstyPeriodRollover(t *testing.T) {
+	// Given 2 permits every 1s
+	s, stopwatch := newBurstyLimiterStats(2, time.Second)
+	var events []PeriodStats
+	s.onPeriodRollover = func(e PeriodStats) {
+		events = append(events, e)
+	}
+
+	// When
+	acquireNTimes(s, 1, 2)
+	assert.Equal(t, time.Duration(-1), s.acquirePermits(context.Background(), 1, 0))
+	stopwatch.CurrentTime = testutil.MillisToNanos(1100)
+	acquire(s, 1)
+
+	// Then
+	assert.Equal(t, []PeriodStats{{MaxPermits: 2, UsedPermits: 2, RejectedPermits: 1}}, events)
+
+	// When periods elapse without activity
+	stopwatch.CurrentTime = testutil.MillisToNanos(5100)
+	acquire(s, 1)
+
+	// Then
+	assert.Equal(t, PeriodStats{MaxPermits: 2, UsedPermits: 1}, events[1])
+	assert.Len(t, events, 2)
+}
+
+// Asserts that changing a smooth rate preserves reserved permits.
+func TestSmoothSetRate(t *testing.T) {
+	// Given 1 permit every 100ms, with 3 permits reserved
+	s, stopwatch := newSmoothLimiterStats(100 * time.Millisecond)
+	waitTime := acquire(s, 3)
+	assertSmoothRateLimiterStats(t, s, waitTime, 200, 300)
+
+	// When the rate changes to 1 permit every 500ms
+	s.setRate(2, time.Second)
+
+	// Then the next permit is available after the reserved permits
+	stopwatch.CurrentTime = testutil.MillisToNanos(100)
+	waitTime = acquire(s, 1)
+	assertSmoothRateLimiterStats(t, s, waitTime, 200, 800)
+}
+
+// Asserts that changing a bursty rate preserves acquired permits and deficits.
+func TestBurstySetRate(t *testing.T) {
+	// Given 2 permits every 1s, with 1 permit acquired
+	s, stopwatch := newBurstyLimiterStats(2, time.Second)
+	acquire(s, 1)
+
+	// When the permits per period increase
+	s.setRate(5, time.Second)
+
+	// Then the acquired permit still counts against the period
+	assert.Equal(t, 4, s.availablePermits)
+	assert.Equal(t, time.Duration(-1), s.acquirePermits(context.Background(), 5, 0))
+
+	// When the period changes with a deficit
+	stopwatch.CurrentTime = testutil.MillisToNanos(500)
+	acquire(s, 6)
+	s.setRate(3, 2*time.Second)
+
+	// Then a new period begins that carries over the deficit
+	assert.Equal(t, int64(0), stopwatch.CurrentTime)
+	assert.Equal(t, 1, s.availablePermits)
+	assert.Equal(t, 0, acquire(s, 1))
+	assert.Equal(t, 2000, acquire(s, 1))
+}
+
 func newBurstyLimiterStats(maxPermits uint, period time.Duration) (*burstyStats[any], *testutil.TestStopwatch) {
 	s := BurstyBuilder[any](maxPermits, period).Build().(*rateLimiter[any]).stats.(*burstyStats[any])
 	stopwatch := &testutil.TestStopwatch{}
@@ -199,7 +296,7 @@ func acquire(stats stats, permits int) (waitTime int) {
 func acquireNTimes(stats stats, permits int, numberOfCalls int) (waitTime int) {
 	waitTime = 0
 	for i := 0; i < numberOfCalls; i++ {
-		waitTime = int(stats.acquirePermits(permits, -1).Milliseconds())
+		waitTime = int(stats.acquirePermits(context.Background(), permits, -1).Milliseconds())
 	}
 	return waitTime
 }
@@ -0,0 +1,164 @@
+package ratelimiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Bucket describes a token bucket, which holds up to Capacity permits and is refilled with one permit every Interval.
+type Bucket struct {
+	// Capacity is the max number of permits the bucket holds.
+	Capacity int
+	// Interval is the time it takes to refill one permit.
+	Interval time.Duration
+}
+
+// Store stores token buckets that can be shared by many RateLimiters, such as across a fleet of servers, so that they
+// collectively respect one rate limit. Stores are typically backed by a shared database, such as Redis.
+//
+// Token buckets begin full, and permits that are acquired beyond what a bucket holds are reserved from future refills,
+// putting the bucket into a deficit that callers must wait for.
+//
+// Implementations must be concurrency safe.
+type Store interface {
+	// AcquirePermits eagerly acquires the requestedPermits from the token bucket with the key, creating the bucket if it
+	// doesn't exist, and returns the time that must be waited in order to use the permits. If the wait time would exceed
+	// the maxWaitTime, no permits are acquired and -1 is returned. A maxWaitTime of -1 indicates no max wait. The ctx is
+	// the context of the execution or permit request, and should be used to bound any calls to the store.
+	AcquirePermits(ctx context.Context, key string, bucket Bucket, requestedPermits int, maxWaitTime time.Duration) (time.Duration, error)
+}
+
+/*
+TokenBucketScript is a Redis Lua script that implements the Store token bucket semantics, which a Store for Redis can
+run via EVAL or EVALSHA with one key, the bucket's key, and the following arguments:
+
+ 1. The bucket capacity
+ 2. The bucket interval, in microseconds
+ 3. The requested permits
+ 4. The max wait time, in microseconds, or -1 for no max wait
+
+The script returns the wait time in microseconds, or -1 if the wait time would exceed the max wait time. Time is
+measured with the Redis server's clock so that clients do not need synchronized clocks. Since TIME is not deterministic,
+the script enables effects replication before writing, which Redis 5 and later do by default. Requires Redis 4 or later.
+*/
+const TokenBucketScript = `
+redis.replicate_commands()
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local permits = tonumber(ARGV[3])
+local max_wait = tonumber(ARGV[4])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(state[1]) or capacity
+local last = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + (now - last) / interval)
+local wait = 0
+if tokens < permits then
+  wait = math.ceil((permits - tokens) * interval)
+end
+if max_wait >= 0 and wait > max_wait then
+  wait = -1
+else
+  tokens = tokens - permits
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) * interval / 1000) + 1000)
+return wait
+`
+
+// NewMemoryStore returns a Store that keeps token buckets in memory. This is useful for sharing a rate limit across
+// RateLimiters in a single process, and for testing.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+type memoryStore struct {
+	now func() time.Time
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	buckets map[string]*memoryBucket
+}
+
+type memoryBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (s *memoryStore) AcquirePermits(_ context.Context, key string, bucket Bucket, requestedPermits int, maxWaitTime time.Duration) (time.Duration, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(bucket.Capacity), last: now}
+		s.buckets[key] = b
+	}
+
+	// Refill the bucket
+	b.tokens = math.Min(float64(bucket.Capacity), b.tokens+float64(now.Sub(b.last))/float64(bucket.Interval))
+	b.last = now
+
+	waitTime := time.Duration(0)
+	if b.tokens < float64(requestedPermits) {
+		waitTime = time.Duration(math.Ceil((float64(requestedPermits) - b.tokens) * float64(bucket.Interval)))
+	}
+	if exceedsMaxWaitTime(waitTime, maxWaitTime) {
+		return -1, nil
+	}
+	b.tokens -= float64(requestedPermits)
+	return waitTime, nil
+}
+
+// storeStats acquires permits from a Store, falling back to local stats if the store fails, unless failing open.
+type storeStats[R any] struct {
+	*config[R]
+	fallback stats
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	bucket Bucket
+}
+
+func (s *storeStats[R]) acquirePermits(ctx context.Context, requestedPermits int, maxWaitTime time.Duration) time.Duration {
+	s.mtx.Lock()
+	bucket := s.bucket
+	s.mtx.Unlock()
+
+	waitTime, err := s.store.AcquirePermits(ctx, s.storeKey, bucket, requestedPermits, maxWaitTime)
+	if err != nil {
+		if s.onStoreError != nil {
+			s.onStoreError(err)
+		}
+		if s.failOpen {
+			return 0
+		}
+		return s.fallback.acquirePermits(ctx, requestedPermits, maxWaitTime)
+	}
+	return waitTime
+}
+
+func (s *storeStats[R]) setRate(periodPermits int, period time.Duration) {
+	s.mtx.Lock()
+	if s.interval != 0 {
+		s.bucket = Bucket{Capacity: 1, Interval: period / time.Duration(periodPermits)}
+	} else {
+		s.bucket = Bucket{Capacity: periodPermits, Interval: period / time.Duration(periodPermits)}
+	}
+	s.mtx.Unlock()
+	s.fallback.setRate(periodPermits, period)
+}
+
+func (s *storeStats[R]) rate() (int, time.Duration) {
+	return s.fallback.rate()
+}
+
+func (s *storeStats[R]) reset() {
+	s.fallback.reset()
+}
@@ -0,0 +1,111 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ stats = &storeStats[any]{}
+
+// Asserts that permits are acquired from a token bucket, including when it's in a deficit.
+func TestMemoryStoreAcquirePermits(t *testing.T) {
+	// Given a bucket of 2 permits that refills 1 permit every 100ms
+	store := NewMemoryStore().(*memoryStore)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	bucket := Bucket{Capacity: 2, Interval: 100 * time.Millisecond}
+	acquire := func(permits int, maxWaitTime time.Duration) time.Duration {
+		waitTime, err := store.AcquirePermits(context.Background(), "key", bucket, permits, maxWaitTime)
+		assert.NoError(t, err)
+		return waitTime
+	}
+
+	// When / Then
+	assert.Equal(t, time.Duration(0), acquire(2, -1))
+	assert.Equal(t, 100*time.Millisecond, acquire(1, -1))
+	assert.Equal(t, time.Duration(-1), acquire(1, 100*time.Millisecond))
+	now = now.Add(150 * time.Millisecond)
+	assert.Equal(t, 50*time.Millisecond, acquire(1, -1))
+
+	// When refilled
+	now = now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), acquire(2, 0))
+	assert.Equal(t, time.Duration(-1), acquire(1, 0))
+}
+
+// Asserts that RateLimiters with the same store share a rate limit.
+func TestRateLimiterWithStore(t *testing.T) {
+	store := NewMemoryStore()
+	limiter1 := BurstyBuilder[any](2, time.Minute).WithStore(store, "key").Build()
+	limiter2 := BurstyBuilder[any](2, time.Minute).WithStore(store, "key").Build()
+
+	assert.True(t, limiter1.TryAcquirePermit())
+	assert.True(t, limiter2.TryAcquirePermit())
+	assert.False(t, limiter1.TryAcquirePermit())
+	assert.False(t, limiter2.TryAcquirePermit())
+}
+
+type failingStore struct{}
+
+func (failingStore) AcquirePermits(context.Context, string, Bucket, int, time.Duration) (time.Duration, error) {
+	return 0, errors.New("unavailable")
+}
+
+// Asserts that permits are acquired locally when a store fails.
+func TestRateLimiterWithFailingStore(t *testing.T) {
+	var storeErr error
+	limiter := BurstyBuilder[any](1, time.Minute).
+		WithStore(failingStore{}, "key").
+		OnStoreError(func(err error) {
+			storeErr = err
+		}).
+		Build()
+
+	assert.True(t, limiter.TryAcquirePermit())
+	assert.False(t, limiter.TryAcquirePermit())
+	assert.EqualError(t, storeErr, "unavailable")
+}
+
+// Asserts that permits are not limited when a store fails and the rate limiter fails open.
+func TestRateLimiterWithFailingStoreFailOpen(t *testing.T) {
+	var storeErrs int
+	limiter := BurstyBuilder[any](1, time.Minute).
+		WithStore(failingStore{}, "key").
+		OnStoreError(func(err error) {
+			storeErrs++
+		}).
+		FailOpen().
+		Build()
+
+	assert.True(t, limiter.TryAcquirePermit())
+	assert.True(t, limiter.TryAcquirePermit())
+	assert.Equal(t, 2, storeErrs)
+	assert.Equal(t, true, limiter.(*rateLimiter[any]).PolicyConfig()["failOpen"])
+}
+
+type blockingStore struct{}
+
+func (blockingStore) AcquirePermits(ctx context.Context, _ string, _ Bucket, _ int, _ time.Duration) (time.Duration, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+// Asserts that store calls are bounded by the context of the permit request.
+func TestRateLimiterWithBlockingStore(t *testing.T) {
+	var storeErr error
+	limiter := BurstyBuilder[any](1, time.Minute).
+		WithStore(blockingStore{}, "key").
+		OnStoreError(func(err error) {
+			storeErr = err
+		}).
+		Build()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, limiter.AcquirePermitWithMaxWait(ctx, time.Second))
+	assert.ErrorIs(t, storeErr, context.DeadlineExceeded)
+}
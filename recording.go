@@ -0,0 +1,158 @@
+package failsafe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordedEvent is an event that was recorded for an execution, such as an attempt completing or a policy decision.
+type RecordedEvent struct {
+	// The time the event occurred
+	Time time.Time
+	// The event message, such as "retry scheduled" or "circuit breaker rejected execution"
+	Message string
+	// The event's attributes, such as the execution's attempts, the policy kind, and any error
+	Attrs map[string]any
+}
+
+func (e RecordedEvent) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Time.Format(time.RFC3339Nano))
+	sb.WriteString(" ")
+	sb.WriteString(e.Message)
+	keys := make([]string, 0, len(e.Attrs))
+	for key := range e.Attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&sb, " %s=%v", key, e.Attrs[key])
+	}
+	return sb.String()
+}
+
+// ExecutionRecording is a record of an execution's attempts, policy decisions, and outcome, which can be logged when an
+// execution fails to provide the full context of what happened. Recordings contain the same events that are logged via
+// Executor.WithLogger. See Executor.WithExecutionRecording.
+//
+// This type is concurrency safe.
+type ExecutionRecording struct {
+	maxEvents int
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	events []RecordedEvent
+	// Guarded by mtx
+	dropped int
+}
+
+// RecordingOf returns the ExecutionRecording for the execution, else nil if execution recording is not enabled.
+func RecordingOf(exec ExecutionInfo) *ExecutionRecording {
+	if e, ok := exec.(interface{ recording() *ExecutionRecording }); ok {
+		return e.recording()
+	}
+	return nil
+}
+
+// Events returns the recorded events, in the order they occurred.
+func (r *ExecutionRecording) Events() []RecordedEvent {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	events := make([]RecordedEvent, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+// Dropped returns the number of events that were dropped, from the start of the execution, since more than the max
+// events occurred.
+func (r *ExecutionRecording) Dropped() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.dropped
+}
+
+func (r *ExecutionRecording) String() string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	var sb strings.Builder
+	if r.dropped > 0 {
+		fmt.Fprintf(&sb, "(%d events dropped)\n", r.dropped)
+	}
+	for i, event := range r.events {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(event.String())
+	}
+	return sb.String()
+}
+
+func (r *ExecutionRecording) add(event RecordedEvent) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if len(r.events) == r.maxEvents {
+		// Drop the oldest event so that the outcome is always recorded
+		copy(r.events, r.events[1:])
+		r.events = r.events[:len(r.events)-1]
+		r.dropped++
+	}
+	r.events = append(r.events, event)
+}
+
+// recordingHandler is a slog.Handler that records events to an ExecutionRecording, and passes them to the next handler,
+// if any.
+type recordingHandler struct {
+	recording *ExecutionRecording
+	next      slog.Handler
+}
+
+// newRecordingLogger returns a logger that records events to the recording, and logs them to the logger, if any.
+func newRecordingLogger(recording *ExecutionRecording, logger *slog.Logger) *slog.Logger {
+	handler := &recordingHandler{recording: recording}
+	if logger != nil {
+		handler.next = logger.Handler()
+	}
+	return slog.New(handler)
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := make(map[string]any, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs[attr.Key] = attr.Value.Any()
+		return true
+	})
+	h.recording.add(RecordedEvent{
+		Time:    record.Time,
+		Message: record.Message,
+		Attrs:   attrs,
+	})
+	if h.next != nil && h.next.Enabled(ctx, record.Level) {
+		return h.next.Handle(ctx, record)
+	}
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	if c.next != nil {
+		c.next = c.next.WithAttrs(attrs)
+	}
+	return &c
+}
+
+func (h *recordingHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	if c.next != nil {
+		c.next = c.next.WithGroup(name)
+	}
+	return &c
+}
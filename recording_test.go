@@ -0,0 +1,80 @@
+package failsafe_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+// Asserts that attempts, policy decisions, and the outcome of an execution are recorded.
+func TestExecutionRecording(t *testing.T) {
+	// Given
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var recording *failsafe.ExecutionRecording
+	executor := failsafe.NewExecutor[any](retrypolicy.WithDefaults[any]()).
+		WithLogger(logger).
+		WithExecutionRecording(10).
+		OnFailure(func(e failsafe.ExecutionDoneEvent[any]) {
+			recording = e.Recording()
+		})
+
+	// When
+	err := executor.Run(func() error {
+		return errors.New("failed")
+	})
+
+	// Then
+	assert.Error(t, err)
+	var messages []string
+	for _, event := range recording.Events() {
+		messages = append(messages, event.Message)
+	}
+	assert.Equal(t, []string{
+		"attempt completed",
+		"retry scheduled",
+		"attempt completed",
+		"retry scheduled",
+		"attempt completed",
+		"retries exceeded",
+		"execution done",
+	}, messages)
+	lastEvent := recording.Events()[6]
+	assert.Equal(t, false, lastEvent.Attrs["success"])
+	assert.Equal(t, int64(3), lastEvent.Attrs["attempts"])
+	assert.Equal(t, 0, recording.Dropped())
+	assert.Contains(t, buf.String(), "retries exceeded")
+}
+
+func TestExecutionRecordingWithMaxEvents(t *testing.T) {
+	// Given
+	executor := failsafe.NewExecutor[any](retrypolicy.WithDefaults[any]()).WithExecutionRecording(2)
+
+	// When
+	var recording *failsafe.ExecutionRecording
+	_ = executor.RunWithExecution(func(exec failsafe.Execution[any]) error {
+		recording = failsafe.RecordingOf(exec)
+		return errors.New("failed")
+	})
+
+	// Then
+	events := recording.Events()
+	assert.Len(t, events, 2)
+	assert.Equal(t, "retries exceeded", events[0].Message)
+	assert.Equal(t, "execution done", events[1].Message)
+	assert.Equal(t, 5, recording.Dropped())
+}
+
+func TestExecutionRecordingDisabled(t *testing.T) {
+	executor := failsafe.NewExecutor[any]()
+	_ = executor.RunWithExecution(func(exec failsafe.Execution[any]) error {
+		assert.Nil(t, failsafe.RecordingOf(exec))
+		return nil
+	})
+}
@@ -2,6 +2,8 @@ package failsafe
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 	"sync/atomic"
 
 	"github.com/failsafe-go/failsafe-go/common"
@@ -10,6 +12,30 @@ import (
 // ErrExecutionCanceled indicates that an execution was canceled by ExecutionResult.Cancel.
 var ErrExecutionCanceled = errors.New("execution canceled")
 
+// ErrContextDone indicates that an execution was not performed because its context was already done when it started.
+// This is only returned when an Executor is configured with WithFailFastOnDone.
+var ErrContextDone = errors.New("context done before execution")
+
+// PanicError indicates that an execution panicked. For async executions, the panic is recovered and provided through
+// the ExecutionResult, unless the Executor is configured with WithRepanicAsync. For any execution, the panic is recovered
+// and handled by policies as a failure when the Executor is configured with WithPanicRecovery.
+type PanicError struct {
+	// Value is the value that was passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine that panicked, as formatted by debug.Stack.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("execution panicked: %v", e.Value)
+}
+
+// Unwrap returns the Value if it's an error, else nil.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
 // ExecutionResult provides the result of an asynchronous execution.
 type ExecutionResult[R any] interface {
 	// Done is a channel that is closed when the execution is done and the result can be retrieved via Get, Result, or Error.
@@ -27,23 +53,90 @@ type ExecutionResult[R any] interface {
 	// Error returns the execution error else nil, blocking until the execution is done.
 	Error() error
 
+	// Then registers the fn to be called with the execution result and error when the execution is done. Then can be
+	// called more than once, and fns are called one at a time, in the order they were registered, by the goroutine that
+	// completes the execution. If the execution is already done, the fn is called immediately on the calling goroutine,
+	// unless previously registered fns are still being called, in which case it's called after them.
+	Then(fn func(R, error))
+
+	// Chan returns a channel that receives the execution's Outcome when it's done, which is useful for selecting on the
+	// result along with other channels. The channel is buffered so that the result is not lost if it's not received.
+	Chan() <-chan Outcome[R]
+
 	// Cancel cancels the execution if it is not already done, with ErrExecutionCanceled as the error. If a Context was
 	// configured with the execution, a child context will be created for the execution and canceled as well.
 	Cancel()
 }
 
+// Outcome is the result and error of an async execution.
+type Outcome[R any] struct {
+	Result R
+	Error  error
+}
+
 type executionResult[R any] struct {
 	*execution[R]
 	cancelFunc func()
 	doneChan   chan any
 	done       atomic.Bool
 	result     atomic.Pointer[*common.PolicyResult[R]]
+
+	thenMtx sync.Mutex
+	// Guarded by thenMtx. Fns that are waiting to be called, in the order they were registered.
+	thenFns []func(R, error)
+	// Guarded by thenMtx. Whether a goroutine is calling the thenFns.
+	dispatching bool
 }
 
+// record records the result and calls any Then fns. Only the first result that's recorded is used.
 func (e *executionResult[R]) record(result *common.PolicyResult[R]) {
+	e.thenMtx.Lock()
+	if e.done.Load() {
+		e.thenMtx.Unlock()
+		return
+	}
 	e.result.Store(&result)
 	e.done.Store(true)
 	close(e.doneChan)
+	e.thenMtx.Unlock()
+	e.dispatchThenFns()
+}
+
+// dispatchThenFns calls the thenFns in the order they were registered, including any that are registered while they're
+// being called, unless another goroutine is already calling them.
+func (e *executionResult[R]) dispatchThenFns() {
+	e.thenMtx.Lock()
+	if e.dispatching {
+		e.thenMtx.Unlock()
+		return
+	}
+	e.dispatching = true
+	e.thenMtx.Unlock()
+
+	// Allow other goroutines to dispatch if a fn panics
+	completed := false
+	defer func() {
+		if !completed {
+			e.thenMtx.Lock()
+			e.dispatching = false
+			e.thenMtx.Unlock()
+		}
+	}()
+
+	r, err := e.Get()
+	for {
+		e.thenMtx.Lock()
+		if len(e.thenFns) == 0 {
+			e.dispatching = false
+			e.thenMtx.Unlock()
+			completed = true
+			return
+		}
+		fn := e.thenFns[0]
+		e.thenFns = e.thenFns[1:]
+		e.thenMtx.Unlock()
+		fn(r, err)
+	}
 }
 
 func (e *executionResult[R]) Done() <-chan any {
@@ -73,6 +166,24 @@ func (e *executionResult[R]) Error() error {
 	return err
 }
 
+func (e *executionResult[R]) Then(fn func(R, error)) {
+	e.thenMtx.Lock()
+	e.thenFns = append(e.thenFns, fn)
+	done := e.done.Load()
+	e.thenMtx.Unlock()
+	if done {
+		e.dispatchThenFns()
+	}
+}
+
+func (e *executionResult[R]) Chan() <-chan Outcome[R] {
+	ch := make(chan Outcome[R], 1)
+	e.Then(func(result R, err error) {
+		ch <- Outcome[R]{Result: result, Error: err}
+	})
+	return ch
+}
+
 func (e *executionResult[R]) Cancel() {
 	// Propagate cancelation to contexts
 	e.execution.Cancel(&common.PolicyResult[R]{
@@ -1,11 +1,14 @@
 package retrypolicy
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
@@ -19,16 +22,20 @@ var ErrExceeded = errors.New("retries exceeded")
 // ExceededError is returned when a RetryPolicy's max attempts or max duration are exceeded. This type can be used with
 // HandleErrorTypes(retrypolicy.ExceededError{}).
 type ExceededError struct {
+	// Name is the name of the RetryPolicy, else an empty string if it's not named.
+	Name       string
 	LastResult any
 	LastError  error
+	// Indicates whether retries were skipped because the next retry could not be attempted before the context deadline
+	deadlineExceeded bool
 }
 
 func (e ExceededError) Error() string {
-	return fmt.Sprintf("retries exceeded. last result: %v, last error: %v", e.LastResult, e.LastError)
+	return fmt.Sprintf("retries exceeded. %slast result: %v, last error: %v", internal.ErrorName(e.Name), e.LastResult, e.LastError)
 }
 
 func (e ExceededError) Is(err error) bool {
-	if err == ErrExceeded {
+	if err == ErrExceeded || (e.deadlineExceeded && err == context.DeadlineExceeded) {
 		return true
 	}
 	return err == e
@@ -41,6 +48,37 @@ func (e ExceededError) Unwrap() error {
 	return fmt.Errorf("failure: %v", e.LastResult)
 }
 
+// DelayHint is implemented by errors that provide a hint for how long to delay before retrying, such as a delay provided
+// by a server. When the last execution error, or any error it wraps, is a DelayHint, its delay is used before retrying.
+type DelayHint interface {
+	error
+
+	// DelayHint returns the delay to wait before retrying.
+	DelayHint() time.Duration
+}
+
+// DeadlineBehavior describes how a RetryPolicy behaves when the next retry delay would extend past the execution
+// context's deadline.
+type DeadlineBehavior int
+
+const (
+	// SkipRetry skips a retry that would be delayed past the context deadline, failing immediately with an ExceededError
+	// that also matches context.DeadlineExceeded via errors.Is, or the last failure if ReturnLastFailure is configured.
+	SkipRetry DeadlineBehavior = iota + 1
+
+	// TruncateDelay truncates a delay that would extend past the context deadline, performing the retry immediately.
+	TruncateDelay
+)
+
+// backoffJitter is a strategy for randomizing backoff delays.
+type backoffJitter int
+
+const (
+	noBackoffJitter backoffJitter = iota
+	fullBackoffJitter
+	decorrelatedBackoffJitter
+)
+
 // RetryPolicy is a policy that defines when retries should be performed. See RetryPolicyBuilder for configuration
 // options.
 //
@@ -71,6 +109,7 @@ R is the execution result type. This type is not concurrency safe.
 type RetryPolicyBuilder[R any] interface {
 	failsafe.FailurePolicyBuilder[RetryPolicyBuilder[R], R]
 	failsafe.DelayablePolicyBuilder[RetryPolicyBuilder[R], R]
+	failsafe.NamedPolicyBuilder[RetryPolicyBuilder[R]]
 
 	// AbortOnResult specifies that retries should be aborted if the execution result matches the result using
 	// reflect.DeepEqual.
@@ -110,6 +149,43 @@ type RetryPolicyBuilder[R any] interface {
 	// consecutive delays by the delayFactor. Replaces any previously configured fixed or random delays.
 	WithBackoffFactor(delay time.Duration, maxDelay time.Duration, delayFactor float32) RetryPolicyBuilder[R]
 
+	// WithFullJitterBackoff sets the delay between retries to a random duration between 0 and an exponential backoff that
+	// starts at the baseDelay and doubles up to the maxDelay. This is the "full jitter" strategy, which spreads out
+	// retries from many clients. Since the delays are already random, WithJitter and WithJitterFactor do not apply to them.
+	// Replaces any previously configured fixed, random, or backoff delays.
+	WithFullJitterBackoff(baseDelay time.Duration, maxDelay time.Duration) RetryPolicyBuilder[R]
+
+	// WithDecorrelatedJitterBackoff sets the delay between retries to a random duration between the baseDelay and 3 times
+	// the previous delay, up to the maxDelay. This is the "decorrelated jitter" strategy, where each delay grows from the
+	// previous random delay rather than from the number of retries. Since the delays are already random, WithJitter and
+	// WithJitterFactor do not apply to them. Replaces any previously configured fixed, random, or backoff delays.
+	WithDecorrelatedJitterBackoff(baseDelay time.Duration, maxDelay time.Duration) RetryPolicyBuilder[R]
+
+	// WithDeadlineAwareness configures how the policy behaves when the next retry delay would extend past the execution
+	// context's deadline, rather than delaying and then failing with context.DeadlineExceeded.
+	WithDeadlineAwareness(behavior DeadlineBehavior) RetryPolicyBuilder[R]
+
+	// WithClock configures the clock that is used for retry delays and deadline awareness, such as a failsafe.FakeClock
+	// when testing. By default, failsafe.SystemClock is used.
+	WithClock(clock failsafe.TimerClock) RetryPolicyBuilder[R]
+
+	// WithDelayFromError configures a function that extracts a delay from the last execution error, such as a delay
+	// provided by a server, returning true if a delay was found. Delays from errors, including errors that implement
+	// DelayHint, take precedence over other configured delays, and are not adjusted for jitter. Delays from errors are
+	// capped at the maxDelay of any configured backoff, and WithMaxDuration still applies to them.
+	WithDelayFromError(delayFromError func(err error) (time.Duration, bool)) RetryPolicyBuilder[R]
+
+	// WithDelayForErrors sets a delay to use between retries when the last execution error matches any of the errs using
+	// errors.Is. Delays for errors take precedence over other configured delays, and are evaluated in the order they're
+	// configured. Jitter and WithMaxDuration still apply to delays for errors.
+	WithDelayForErrors(delay time.Duration, errs ...error) RetryPolicyBuilder[R]
+
+	// WithBackoffForErrors sets a delay to use between retries when the last execution error matches any of the errs using
+	// errors.Is, exponentially backing off to the maxDelay and multiplying consecutive delays for the errs by a factor of 2.
+	// Delays for errors take precedence over other configured delays, and are evaluated in the order they're configured.
+	// Jitter and WithMaxDuration still apply to delays for errors.
+	WithBackoffForErrors(delay time.Duration, maxDelay time.Duration, errs ...error) RetryPolicyBuilder[R]
+
 	// WithRandomDelay sets a random delay between the delayMin and delayMax (inclusive) to occur between retries.
 	// Replaces any previously configured delay or backoff delay.
 	WithRandomDelay(delayMin time.Duration, delayMax time.Duration) RetryPolicyBuilder[R]
@@ -142,6 +218,12 @@ type RetryPolicyBuilder[R any] interface {
 	// OnRetry registers the listener to be called when a retry is about to be attempted.
 	OnRetry(listener func(failsafe.ExecutionEvent[R])) RetryPolicyBuilder[R]
 
+	// OnBeforeRetry registers the hook to be called after any delay, just before a retry is attempted, which can prepare
+	// for the retry, such as by refreshing credentials, rotating endpoints, or regenerating a request payload. The hook
+	// can pass state to the retry attempt via the execution's SetValue. If the hook returns an error, retries are aborted
+	// and the error is returned as the execution's error.
+	OnBeforeRetry(hook func(exec failsafe.ExecutionAttempt[R]) error) RetryPolicyBuilder[R]
+
 	// OnRetriesExceeded registers the listener to be called when an execution fails and the max retry attempts or max
 	// duration are exceeded. The provided event will contain the last execution result and error.
 	OnRetriesExceeded(listener func(failsafe.ExecutionEvent[R])) RetryPolicyBuilder[R]
@@ -154,27 +236,54 @@ type config[R any] struct {
 	*policy.BaseFailurePolicy[R]
 	*policy.BaseDelayablePolicy[R]
 	*policy.BaseAbortablePolicy[R]
+	name string
 
 	returnLastFailure bool
 	delayMin          time.Duration
 	delayMax          time.Duration
 	delayFactor       float32
 	maxDelay          time.Duration
+	backoffJitter     backoffJitter
 	jitter            time.Duration
 	jitterFactor      float32
 	maxDuration       time.Duration
 	maxRetries        int
+	errorDelays       []errorDelay
+	delayFromError    func(error) (time.Duration, bool)
+	deadlineBehavior  DeadlineBehavior
+	clock             failsafe.TimerClock
 
 	onAbort           func(failsafe.ExecutionEvent[R])
 	onRetry           func(failsafe.ExecutionEvent[R])
+	onBeforeRetry     func(failsafe.ExecutionAttempt[R]) error
 	onRetryScheduled  func(failsafe.ExecutionScheduledEvent[R])
 	onRetriesExceeded func(failsafe.ExecutionEvent[R])
 }
 
 var _ RetryPolicyBuilder[any] = &config[any]{}
 
+// errorDelay is a fixed or backoff delay that applies to specific errors.
+type errorDelay struct {
+	errs        []error
+	delay       time.Duration
+	maxDelay    time.Duration
+	delayFactor float32
+}
+
+func (d *errorDelay) matches(err error) bool {
+	for _, e := range d.errs {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
 type retryPolicy[R any] struct {
 	*config[R]
+	retryCount    atomic.Uint64
+	exceededCount atomic.Uint64
+	abortCount    atomic.Uint64
 }
 
 // WithDefaults creates a RetryPolicy for execution result type R that allows 3 execution attempts max with no delay. To
@@ -191,9 +300,15 @@ func Builder[R any]() RetryPolicyBuilder[R] {
 		BaseDelayablePolicy: &policy.BaseDelayablePolicy[R]{},
 		BaseAbortablePolicy: &policy.BaseAbortablePolicy[R]{},
 		maxRetries:          defaultMaxRetries,
+		clock:               failsafe.SystemClock(),
 	}
 }
 
+func (c *config[R]) WithName(name string) RetryPolicyBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() RetryPolicy[R] {
 	rpCopy := *c
 	return &retryPolicy[R]{
@@ -241,6 +356,11 @@ func (c *config[R]) HandleIf(predicate func(R, error) bool) RetryPolicyBuilder[R
 	return c
 }
 
+func (c *config[R]) HandleWith(classifier failsafe.Classifier[R]) RetryPolicyBuilder[R] {
+	c.BaseFailurePolicy.HandleWith(classifier)
+	return c
+}
+
 func (c *config[R]) ReturnLastFailure() RetryPolicyBuilder[R] {
 	c.returnLastFailure = true
 	return c
@@ -283,6 +403,7 @@ func (c *config[R]) WithBackoffFactor(delay time.Duration, maxDelay time.Duratio
 	c.BaseDelayablePolicy.WithDelay(delay)
 	c.maxDelay = maxDelay
 	c.delayFactor = delayFactor
+	c.backoffJitter = noBackoffJitter
 
 	// Clear random delay
 	c.delayMin = 0
@@ -290,6 +411,51 @@ func (c *config[R]) WithBackoffFactor(delay time.Duration, maxDelay time.Duratio
 	return c
 }
 
+func (c *config[R]) WithFullJitterBackoff(baseDelay time.Duration, maxDelay time.Duration) RetryPolicyBuilder[R] {
+	c.WithBackoffFactor(baseDelay, maxDelay, 2)
+	c.backoffJitter = fullBackoffJitter
+	return c
+}
+
+func (c *config[R]) WithDecorrelatedJitterBackoff(baseDelay time.Duration, maxDelay time.Duration) RetryPolicyBuilder[R] {
+	c.WithBackoffFactor(baseDelay, maxDelay, 3)
+	c.backoffJitter = decorrelatedBackoffJitter
+	return c
+}
+
+func (c *config[R]) WithDeadlineAwareness(behavior DeadlineBehavior) RetryPolicyBuilder[R] {
+	c.deadlineBehavior = behavior
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.TimerClock) RetryPolicyBuilder[R] {
+	c.clock = clock
+	return c
+}
+
+func (c *config[R]) WithDelayFromError(delayFromError func(err error) (time.Duration, bool)) RetryPolicyBuilder[R] {
+	c.delayFromError = delayFromError
+	return c
+}
+
+func (c *config[R]) WithDelayForErrors(delay time.Duration, errs ...error) RetryPolicyBuilder[R] {
+	c.errorDelays = append(c.errorDelays, errorDelay{
+		errs:  errs,
+		delay: delay,
+	})
+	return c
+}
+
+func (c *config[R]) WithBackoffForErrors(delay time.Duration, maxDelay time.Duration, errs ...error) RetryPolicyBuilder[R] {
+	c.errorDelays = append(c.errorDelays, errorDelay{
+		errs:        errs,
+		delay:       delay,
+		maxDelay:    maxDelay,
+		delayFactor: 2,
+	})
+	return c
+}
+
 func (c *config[R]) WithRandomDelay(delayMin time.Duration, delayMax time.Duration) RetryPolicyBuilder[R] {
 	c.delayMin = delayMin
 	c.delayMax = delayMax
@@ -297,6 +463,7 @@ func (c *config[R]) WithRandomDelay(delayMin time.Duration, delayMax time.Durati
 	// Clear non-random delay
 	c.Delay = 0
 	c.maxDelay = 0
+	c.backoffJitter = noBackoffJitter
 	return c
 }
 
@@ -330,6 +497,11 @@ func (c *config[R]) OnRetry(listener func(failsafe.ExecutionEvent[R])) RetryPoli
 	return c
 }
 
+func (c *config[R]) OnBeforeRetry(hook func(exec failsafe.ExecutionAttempt[R]) error) RetryPolicyBuilder[R] {
+	c.onBeforeRetry = hook
+	return c
+}
+
 func (c *config[R]) OnRetryScheduled(listener func(failsafe.ExecutionScheduledEvent[R])) RetryPolicyBuilder[R] {
 	c.onRetryScheduled = listener
 	return c
@@ -344,6 +516,36 @@ func (c *config[R]) allowsRetries() bool {
 	return c.maxRetries == -1 || c.maxRetries > 0
 }
 
+func (rp *retryPolicy[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.RetryKind
+}
+
+func (rp *retryPolicy[R]) PolicyName() string {
+	return rp.name
+}
+
+func (rp *retryPolicy[R]) PolicyConfig() map[string]any {
+	return map[string]any{
+		"maxRetries":   rp.maxRetries,
+		"maxDuration":  rp.maxDuration,
+		"delay":        rp.Delay,
+		"maxDelay":     rp.maxDelay,
+		"delayFactor":  rp.delayFactor,
+		"delayMin":     rp.delayMin,
+		"delayMax":     rp.delayMax,
+		"jitter":       rp.jitter,
+		"jitterFactor": rp.jitterFactor,
+	}
+}
+
+func (rp *retryPolicy[R]) PolicyMetrics() []failsafe.Metric {
+	return []failsafe.Metric{
+		{Name: "retries", Kind: failsafe.CounterMetric, Value: float64(rp.retryCount.Load())},
+		{Name: "retriesExceeded", Kind: failsafe.CounterMetric, Value: float64(rp.exceededCount.Load())},
+		{Name: "aborts", Kind: failsafe.CounterMetric, Value: float64(rp.abortCount.Load())},
+	}
+}
+
 func (rp *retryPolicy[R]) ToExecutor(_ R) any {
 	rpe := &executor[R]{
 		BaseExecutor: &policy.BaseExecutor[R]{
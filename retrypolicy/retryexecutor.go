@@ -1,6 +1,7 @@
 package retrypolicy
 
 import (
+	"errors"
 	"math/rand"
 	"time"
 
@@ -15,14 +16,24 @@ import (
 type executor[R any] struct {
 	*policy.BaseExecutor[R]
 	*retryPolicy[R]
+}
+
+var _ policy.Executor[any] = &executor[any]{}
 
-	// Mutable state
+// state is mutable retry state for an execution. Since an executor may be reused across executions, this state is
+// stored with the execution, and is only created once a failure occurs.
+type state struct {
 	failedAttempts  int
 	retriesExceeded bool
 	lastDelay       time.Duration // The last backoff delay time
+	elapsedTime     time.Duration // The elapsed execution time as of the last failure, when a maxDuration is configured
+	// The last backoff delay time for each config.errorDelays entry, when errorDelays are configured
+	lastErrorDelays []time.Duration
 }
 
-var _ policy.Executor[any] = &executor[any]{}
+func newState() any {
+	return &state{}
+}
 
 func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
@@ -33,7 +44,7 @@ func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyRe
 			if canceled, cancelResult := execInternal.IsCanceledWithResult(); canceled {
 				return cancelResult
 			}
-			if e.retriesExceeded {
+			if s, _ := execInternal.ExecutorState(e, nil).(*state); s != nil && s.retriesExceeded {
 				return result
 			}
 
@@ -48,16 +59,35 @@ func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyRe
 			}
 
 			// Delay
-			delay := e.getDelay(exec)
+			s := execInternal.ExecutorState(e, newState).(*state)
+			delay := e.getDelay(s, exec, result.Error)
+			if e.deadlineBehavior != 0 && exceedsDeadline(exec, delay) {
+				if e.deadlineBehavior == SkipRetry {
+					return e.onDeadlineExceeded(s, execInternal, result)
+				}
+				delay = 0
+			}
+			if logger := internal.NamedLogger(execInternal.Logger(), e.name); logger != nil {
+				logger.Debug("retry scheduled",
+					"policy", failsafe.RetryKind,
+					"attempts", exec.Attempts(),
+					"delay", delay,
+					"error", result.Error)
+			}
+			e.retryCount.Add(1)
 			if e.onRetryScheduled != nil {
 				e.onRetryScheduled(failsafe.ExecutionScheduledEvent[R]{
 					ExecutionAttempt: execInternal.CopyWithResult(result),
 					Delay:            delay,
 				})
 			}
-			timer := time.NewTimer(delay)
+			delayed := make(chan struct{})
+			timer := e.clock.AfterFunc(delay, func() {
+				close(delayed)
+			})
 			select {
-			case <-timer.C:
+			case <-delayed:
+				execInternal.RecordDelayTime(delay)
 			case <-exec.Canceled():
 				timer.Stop()
 			}
@@ -71,6 +101,13 @@ func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyRe
 			if e.onRetry != nil {
 				e.onRetry(failsafe.ExecutionEvent[R]{ExecutionAttempt: execInternal.CopyWithResult(result)})
 			}
+
+			// Prepare for the retry
+			if e.onBeforeRetry != nil {
+				if err := e.onBeforeRetry(execInternal.CopyWithResult(result)); err != nil {
+					return e.onBeforeRetryFailed(execInternal, err)
+				}
+			}
 		}
 	}
 }
@@ -79,24 +116,47 @@ func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyRe
 func (e *executor[R]) OnFailure(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
 	e.BaseExecutor.OnFailure(exec, result)
 
-	e.failedAttempts++
-	maxRetriesExceeded := e.maxRetries != -1 && e.failedAttempts > e.maxRetries
-	maxDurationExceeded := e.maxDuration != 0 && exec.ElapsedTime() > e.maxDuration
-	e.retriesExceeded = maxRetriesExceeded || maxDurationExceeded
+	s := exec.ExecutorState(e, newState).(*state)
+	s.failedAttempts++
+	maxRetriesExceeded := e.maxRetries != -1 && s.failedAttempts > e.maxRetries
+	maxDurationExceeded := false
+	if e.maxDuration != 0 {
+		// Read the elapsed time once per failure, and reuse it when computing a delay
+		s.elapsedTime = exec.ElapsedTime()
+		maxDurationExceeded = s.elapsedTime > e.maxDuration
+	}
+	s.retriesExceeded = maxRetriesExceeded || maxDurationExceeded
 	isAbortable := e.IsAbortable(result.Result, result.Error)
-	shouldRetry := !isAbortable && !e.retriesExceeded && e.allowsRetries()
+	isIdempotent := failsafe.IsIdempotent(exec.Context())
+	shouldRetry := !isAbortable && !s.retriesExceeded && e.allowsRetries() && isIdempotent
 	done := isAbortable || !shouldRetry
 
-	// Call listeners
-	if isAbortable && e.onAbort != nil {
-		e.onAbort(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+	// Log and call listeners
+	if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+		if isAbortable {
+			logger.Debug("retries aborted", "policy", failsafe.RetryKind, "attempts", exec.Attempts(), "error", result.Error)
+		} else if s.retriesExceeded {
+			logger.Debug("retries exceeded", "policy", failsafe.RetryKind, "attempts", exec.Attempts(), "error", result.Error)
+		} else if !isIdempotent {
+			logger.Debug("retries skipped for non-idempotent execution", "policy", failsafe.RetryKind, "attempts", exec.Attempts(), "error", result.Error)
+		}
 	}
-	if e.retriesExceeded {
-		if !isAbortable && e.onRetriesExceeded != nil {
-			e.onRetriesExceeded(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+	if isAbortable {
+		e.abortCount.Add(1)
+		if e.onAbort != nil {
+			e.onAbort(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+		}
+	}
+	if s.retriesExceeded {
+		if !isAbortable {
+			e.exceededCount.Add(1)
+			if e.onRetriesExceeded != nil {
+				e.onRetriesExceeded(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+			}
 		}
 		if !e.returnLastFailure {
 			return internal.FailureResult[R](ExceededError{
+				Name:       e.name,
 				LastResult: result.Result,
 				LastError:  result.Error,
 			})
@@ -105,31 +165,136 @@ func (e *executor[R]) OnFailure(exec policy.ExecutionInternal[R], result *common
 	return result.WithDone(done, false)
 }
 
-// getDelay updates lastDelay and returns the new delay
-func (e *executor[R]) getDelay(exec failsafe.ExecutionAttempt[R]) time.Duration {
+// onBeforeRetryFailed aborts retries when an OnBeforeRetry hook fails, and returns the hook's err as the final result.
+func (e *executor[R]) onBeforeRetryFailed(exec policy.ExecutionInternal[R], err error) *common.PolicyResult[R] {
+	result := internal.FailureResult[R](err)
+	e.abortCount.Add(1)
+	if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+		logger.Debug("retries aborted", "policy", failsafe.RetryKind, "attempts", exec.Attempts(), "error", err)
+	}
+	if e.onAbort != nil {
+		e.onAbort(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+	}
+	return result
+}
+
+// onDeadlineExceeded marks retries as exceeded when a retry is skipped because of a context deadline, and returns the
+// final result.
+func (e *executor[R]) onDeadlineExceeded(s *state, exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) *common.PolicyResult[R] {
+	s.retriesExceeded = true
+	e.exceededCount.Add(1)
+	if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+		logger.Debug("retries exceeded",
+			"policy", failsafe.RetryKind,
+			"attempts", exec.Attempts(),
+			"error", result.Error,
+			"deadlineExceeded", true)
+	}
+	if e.onRetriesExceeded != nil {
+		e.onRetriesExceeded(failsafe.ExecutionEvent[R]{ExecutionAttempt: exec.CopyWithResult(result)})
+	}
+	if e.returnLastFailure {
+		return result.WithDone(true, false)
+	}
+	return internal.FailureResult[R](ExceededError{
+		Name:             e.name,
+		LastResult:       result.Result,
+		LastError:        result.Error,
+		deadlineExceeded: true,
+	})
+}
+
+// exceedsDeadline returns whether delaying for the delay would reach the execution context's deadline, if any. Context
+// deadlines are measured with the system clock, regardless of the policy's clock.
+func exceedsDeadline[R any](exec failsafe.Execution[R], delay time.Duration) bool {
+	deadline, ok := exec.Context().Deadline()
+	return ok && delay >= time.Until(deadline)
+}
+
+// getDelay updates lastDelay and returns the new delay for the err.
+func (e *executor[R]) getDelay(s *state, exec failsafe.ExecutionAttempt[R], err error) time.Duration {
+	if hintedDelay := e.getHintedDelay(err); hintedDelay != -1 {
+		return e.adjustForMaxDuration(hintedDelay, s.elapsedTime)
+	}
+
 	var delay time.Duration
-	if computedDelay := e.ComputeDelay(exec); computedDelay != -1 {
+	if errorDelay := e.getErrorDelay(s, err); errorDelay != -1 {
+		delay = errorDelay
+	} else if computedDelay := e.ComputeDelay(exec); computedDelay != -1 {
 		delay = computedDelay
+	} else if e.backoffJitter != noBackoffJitter && e.Delay != 0 {
+		// Jittered backoff delays are already random
+		return e.adjustForMaxDuration(e.getJitteredBackoffDelay(s, exec), s.elapsedTime)
 	} else {
-		delay = e.getFixedOrRandomDelay(exec)
+		delay = e.getFixedOrRandomDelay(s, exec)
 	}
 	if delay != 0 {
 		delay = e.adjustForJitter(delay)
 	}
-	delay = e.adjustForMaxDuration(delay, exec.ElapsedTime())
+	delay = e.adjustForMaxDuration(delay, s.elapsedTime)
+	return delay
+}
+
+// getHintedDelay returns a delay provided by the err, either via a delayFromError func or a DelayHint, else -1. The
+// delay is capped at the maxDelay, if any.
+func (e *executor[R]) getHintedDelay(err error) time.Duration {
+	if err == nil {
+		return -1
+	}
+	delay := time.Duration(-1)
+	if e.delayFromError != nil {
+		if d, ok := e.delayFromError(err); ok {
+			delay = d
+		}
+	}
+	var hint DelayHint
+	if delay == -1 && errors.As(err, &hint) {
+		delay = hint.DelayHint()
+	}
+	if delay != -1 && e.maxDelay > 0 {
+		delay = min(delay, e.maxDelay)
+	}
 	return delay
 }
 
-func (e *executor[R]) getFixedOrRandomDelay(exec failsafe.ExecutionAttempt[R]) time.Duration {
+// getErrorDelay returns the delay for the first errorDelay that matches the err, else -1 if none match.
+func (e *executor[R]) getErrorDelay(s *state, err error) time.Duration {
+	if len(e.errorDelays) == 0 || err == nil {
+		return -1
+	}
+	for i := range e.errorDelays {
+		ed := &e.errorDelays[i]
+		if !ed.matches(err) {
+			continue
+		}
+		if ed.maxDelay == 0 {
+			return ed.delay
+		}
+
+		// Adjust for backoffs
+		if s.lastErrorDelays == nil {
+			s.lastErrorDelays = make([]time.Duration, len(e.errorDelays))
+		}
+		if lastDelay := s.lastErrorDelays[i]; lastDelay != 0 {
+			s.lastErrorDelays[i] = min(time.Duration(float32(lastDelay)*ed.delayFactor), ed.maxDelay)
+		} else {
+			s.lastErrorDelays[i] = ed.delay
+		}
+		return s.lastErrorDelays[i]
+	}
+	return -1
+}
+
+func (e *executor[R]) getFixedOrRandomDelay(s *state, exec failsafe.ExecutionAttempt[R]) time.Duration {
 	if e.Delay != 0 {
 		// Adjust for backoffs
-		if e.lastDelay != 0 && exec.Retries() >= 1 && e.maxDelay != 0 {
-			backoffDelay := time.Duration(float32(e.lastDelay) * e.delayFactor)
-			e.lastDelay = min(backoffDelay, e.maxDelay)
+		if s.lastDelay != 0 && exec.Retries() >= 1 && e.maxDelay != 0 {
+			backoffDelay := time.Duration(float32(s.lastDelay) * e.delayFactor)
+			s.lastDelay = min(backoffDelay, e.maxDelay)
 		} else {
-			e.lastDelay = e.Delay
+			s.lastDelay = e.Delay
 		}
-		return e.lastDelay
+		return s.lastDelay
 	}
 	if e.delayMin != 0 && e.delayMax != 0 {
 		return time.Duration(util.RandomDelayInRange(e.delayMin.Nanoseconds(), e.delayMax.Nanoseconds(), rand.Float64()))
@@ -137,6 +302,27 @@ func (e *executor[R]) getFixedOrRandomDelay(exec failsafe.ExecutionAttempt[R]) t
 	return 0
 }
 
+// getJitteredBackoffDelay returns a random delay using the backoffJitter strategy. For full jitter, lastDelay tracks the
+// exponential backoff that bounds the random delay. For decorrelated jitter, lastDelay tracks the previous random delay.
+func (e *executor[R]) getJitteredBackoffDelay(s *state, exec failsafe.ExecutionAttempt[R]) time.Duration {
+	if e.backoffJitter == fullBackoffJitter {
+		if s.lastDelay != 0 && exec.Retries() >= 1 {
+			s.lastDelay = min(time.Duration(float32(s.lastDelay)*e.delayFactor), e.maxDelay)
+		} else {
+			s.lastDelay = e.Delay
+		}
+		return time.Duration(util.RandomDelayInRange(0, s.lastDelay.Nanoseconds(), rand.Float64()))
+	}
+
+	prevDelay := s.lastDelay
+	if prevDelay == 0 {
+		prevDelay = e.Delay
+	}
+	upperDelay := time.Duration(float32(prevDelay) * e.delayFactor)
+	s.lastDelay = min(time.Duration(util.RandomDelayInRange(e.Delay.Nanoseconds(), upperDelay.Nanoseconds(), rand.Float64())), e.maxDelay)
+	return s.lastDelay
+}
+
 func (e *executor[R]) adjustForJitter(delay time.Duration) time.Duration {
 	if e.jitter != 0 {
 		delay = util.RandomDelay(delay, e.jitter, rand.Float64())
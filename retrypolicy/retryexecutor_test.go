@@ -1,6 +1,8 @@
 package retrypolicy
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -18,9 +20,10 @@ func TestGetFixedOrRandomDelay(t *testing.T) {
 		},
 	}
 	exec := &testutil.TestExecution[any]{}
+	s := &state{}
 	delay := rpc.Delay
 	f := func() time.Duration {
-		delay = rpe.getFixedOrRandomDelay(exec)
+		delay = rpe.getFixedOrRandomDelay(s, exec)
 		exec.TheRetries++
 		return delay
 	}
@@ -32,3 +35,119 @@ func TestGetFixedOrRandomDelay(t *testing.T) {
 	assert.Equal(t, 16*time.Second, f())
 	assert.Equal(t, 30*time.Second, f())
 }
+
+func TestGetFullJitterBackoffDelay(t *testing.T) {
+	// Given
+	rpc := Builder[any]().WithFullJitterBackoff(2*time.Second, 30*time.Second).(*config[any])
+	rpe := &executor[any]{
+		retryPolicy: &retryPolicy[any]{
+			config: rpc,
+		},
+	}
+	exec := &testutil.TestExecution[any]{}
+	s := &state{}
+
+	// When / Then
+	for _, maxDelay := range []time.Duration{2, 4, 8, 16, 30, 30} {
+		delay := rpe.getDelay(s, exec, nil)
+		exec.TheRetries++
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, maxDelay*time.Second)
+	}
+}
+
+func TestGetDecorrelatedJitterBackoffDelay(t *testing.T) {
+	// Given
+	rpc := Builder[any]().WithDecorrelatedJitterBackoff(time.Second, 30*time.Second).WithJitter(time.Hour).(*config[any])
+	rpe := &executor[any]{
+		retryPolicy: &retryPolicy[any]{
+			config: rpc,
+		},
+	}
+	exec := &testutil.TestExecution[any]{}
+	s := &state{}
+
+	// When / Then
+	prevDelay := time.Second
+	for i := 0; i < 10; i++ {
+		delay := rpe.getDelay(s, exec, nil)
+		exec.TheRetries++
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, min(3*prevDelay, 30*time.Second))
+		prevDelay = delay
+	}
+}
+
+func TestGetDelayForErrors(t *testing.T) {
+	// Given
+	rpc := Builder[any]().
+		WithDelay(time.Second).
+		WithDelayForErrors(0, testutil.ErrConnecting).
+		WithBackoffForErrors(10*time.Second, 30*time.Second, testutil.ErrInvalidState).(*config[any])
+	rpe := &executor[any]{
+		retryPolicy: &retryPolicy[any]{
+			config: rpc,
+		},
+	}
+	exec := &testutil.TestExecution[any]{}
+	s := &state{}
+	f := func(err error) time.Duration {
+		delay := rpe.getDelay(s, exec, err)
+		exec.TheRetries++
+		return delay
+	}
+
+	// When / Then
+	assert.Equal(t, time.Duration(0), f(testutil.ErrConnecting))
+	assert.Equal(t, 10*time.Second, f(testutil.ErrInvalidState))
+	assert.Equal(t, time.Second, f(testutil.ErrInvalidArgument))
+	assert.Equal(t, 20*time.Second, f(testutil.ErrInvalidState))
+	assert.Equal(t, 30*time.Second, f(testutil.ErrInvalidState))
+}
+
+type delayHintError struct {
+	delay time.Duration
+}
+
+func (e *delayHintError) Error() string {
+	return "delay hint"
+}
+
+func (e *delayHintError) DelayHint() time.Duration {
+	return e.delay
+}
+
+func TestGetDelayFromError(t *testing.T) {
+	// Given
+	rpc := Builder[any]().
+		WithDelay(time.Second).
+		WithJitter(100 * time.Millisecond).
+		WithDelayFromError(func(err error) (time.Duration, bool) {
+			if errors.Is(err, testutil.ErrConnecting) {
+				return 5 * time.Second, true
+			}
+			return 0, false
+		}).(*config[any])
+	rpe := &executor[any]{
+		retryPolicy: &retryPolicy[any]{
+			config: rpc,
+		},
+	}
+	exec := &testutil.TestExecution[any]{}
+	f := func(err error) time.Duration {
+		return rpe.getDelay(&state{}, exec, err)
+	}
+
+	// When / Then
+	assert.Equal(t, 5*time.Second, f(testutil.ErrConnecting))
+	assert.Equal(t, 3*time.Second, f(fmt.Errorf("wrapped: %w", &delayHintError{3 * time.Second})))
+	delay := f(testutil.ErrInvalidState)
+	assert.True(t, delay >= 900*time.Millisecond && delay <= 1100*time.Millisecond)
+
+	// When max delay is configured
+	rpc.WithBackoff(time.Second, 4*time.Second)
+
+	// Then
+	assert.Equal(t, 4*time.Second, f(testutil.ErrConnecting))
+	assert.Equal(t, 3*time.Second, f(&delayHintError{3 * time.Second}))
+}
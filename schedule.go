@@ -0,0 +1,166 @@
+package failsafe
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Trigger determines when a scheduled fn is run. Trigger is compatible with the schedules of common cron libraries,
+// which allows cron expressions to be used with Schedule:
+//
+//	cronSchedule, err := cron.ParseStandard("*/5 * * * *")
+//	job := failsafe.Schedule(executor, cronSchedule, syncData)
+type Trigger interface {
+	// Next returns the next time a fn should be run after the time, or the zero time if it should not be run again.
+	Next(after time.Time) time.Time
+}
+
+type intervalTrigger time.Duration
+
+func (t intervalTrigger) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(t))
+}
+
+// Every returns a Trigger that runs a fn each time the interval elapses after the previous run completes.
+func Every(interval time.Duration) Trigger {
+	return intervalTrigger(interval)
+}
+
+// ScheduleOption configures how Schedule runs a fn.
+type ScheduleOption func(*scheduleConfig)
+
+type scheduleConfig struct {
+	jitter time.Duration
+	clock  TimerClock
+	onRun  func(error)
+}
+
+// WithScheduleJitter configures a random delay, up to the jitter, that is added to each run's scheduled time. This can
+// be used to spread out runs for jobs that are scheduled in many processes at once.
+func WithScheduleJitter(jitter time.Duration) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.jitter = jitter
+	}
+}
+
+// WithScheduleClock configures the clock that is used to wait for runs. Defaults to SystemClock.
+func WithScheduleClock(clock TimerClock) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.clock = clock
+	}
+}
+
+// OnScheduledRun registers the listener to be called with the error, if any, after each scheduled run completes.
+func OnScheduledRun(listener func(err error)) ScheduleOption {
+	return func(c *scheduleConfig) {
+		c.onRun = listener
+	}
+}
+
+// ScheduledJob is a fn that is run repeatedly by Schedule.
+//
+// This type is concurrency safe.
+type ScheduledJob struct {
+	config *scheduleConfig
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	runs int
+	// Guarded by mtx
+	lastErr error
+}
+
+// Schedule runs the fn via the executor each time the trigger fires, until the returned ScheduledJob is stopped or the
+// trigger returns the zero time. The next run is scheduled after the previous run completes, so runs never overlap, and
+// runs that would have happened while a run was in progress are skipped. Failures are handled by the executor's
+// policies, and a failed run does not stop the job. Example usage:
+//
+//	job := failsafe.Schedule(executor, failsafe.Every(time.Minute), func(exec failsafe.Execution[any]) error {
+//	  return syncData(exec.Context())
+//	})
+//	defer job.Stop()
+//
+// R is the execution result type.
+func Schedule[R any](executor Executor[R], trigger Trigger, fn func(exec Execution[R]) error, options ...ScheduleOption) *ScheduledJob {
+	config := &scheduleConfig{clock: SystemClock()}
+	for _, option := range options {
+		option(config)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &ScheduledJob{
+		config: config,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go runSchedule(ctx, job, executor.WithContext(ctx), trigger, fn)
+	return job
+}
+
+// Stop stops the job from being run again, cancels the context of any run that is in progress, and waits for it to
+// complete.
+func (j *ScheduledJob) Stop() {
+	j.cancel()
+	<-j.done
+}
+
+// Done returns a channel that is closed once the job has stopped, either via Stop or because its trigger returned the
+// zero time.
+func (j *ScheduledJob) Done() <-chan struct{} {
+	return j.done
+}
+
+// Runs returns the number of runs that have completed.
+func (j *ScheduledJob) Runs() int {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	return j.runs
+}
+
+// LastError returns the error from the most recent run, if any.
+func (j *ScheduledJob) LastError() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	return j.lastErr
+}
+
+func runSchedule[R any](ctx context.Context, job *ScheduledJob, executor Executor[R], trigger Trigger, fn func(exec Execution[R]) error) {
+	defer close(job.done)
+	fire := make(chan struct{}, 1)
+	for {
+		now := job.config.clock.Now()
+		next := trigger.Next(now)
+		if next.IsZero() {
+			return
+		}
+		delay := next.Sub(now)
+		if job.config.jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(job.config.jitter)))
+		}
+
+		timer := job.config.clock.AfterFunc(delay, func() {
+			fire <- struct{}{}
+		})
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-fire:
+		}
+
+		err := executor.RunWithExecution(fn)
+		job.mtx.Lock()
+		job.runs++
+		job.lastErr = err
+		job.mtx.Unlock()
+		if job.config.onRun != nil {
+			job.config.onRun(err)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
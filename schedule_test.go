@@ -0,0 +1,89 @@
+package failsafe_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+// Asserts that Schedule runs a fn each time its trigger fires, applying the executor's policies to each run.
+func TestSchedule(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	executor := failsafe.NewExecutor[any](retrypolicy.WithDefaults[any]())
+	var attempts atomic.Int32
+	var runErrs []error
+	fn := func(exec failsafe.Execution[any]) error {
+		// Fail the first attempt of each run
+		attempts.Add(1)
+		if exec.Attempts() == 1 {
+			return errors.New("failed")
+		}
+		return nil
+	}
+
+	// When
+	job := failsafe.Schedule(executor, failsafe.Every(time.Minute), fn, failsafe.WithScheduleClock(clock),
+		failsafe.OnScheduledRun(func(err error) {
+			runErrs = append(runErrs, err)
+		}))
+	for i := 0; i < 3; i++ {
+		waitForPendingTimer(t, clock)
+		clock.Advance(time.Minute)
+	}
+	waitForPendingTimer(t, clock)
+	job.Stop()
+
+	// Then
+	assert.Equal(t, 3, job.Runs())
+	assert.Equal(t, int32(6), attempts.Load())
+	assert.Equal(t, []error{nil, nil, nil}, runErrs)
+	assert.NoError(t, job.LastError())
+	assert.Equal(t, 0, clock.PendingTimers())
+}
+
+// Asserts that a ScheduledJob stops when its trigger returns the zero time.
+func TestScheduleWithFiniteTrigger(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	trigger := &countingTrigger{remaining: 2}
+	executor := failsafe.NewExecutor[any]()
+
+	// When
+	job := failsafe.Schedule(executor, trigger, func(exec failsafe.Execution[any]) error {
+		return errors.New("failed")
+	}, failsafe.WithScheduleClock(clock))
+	for i := 0; i < 2; i++ {
+		waitForPendingTimer(t, clock)
+		clock.Advance(time.Second)
+	}
+
+	// Then
+	<-job.Done()
+	assert.Equal(t, 2, job.Runs())
+	assert.Error(t, job.LastError())
+}
+
+type countingTrigger struct {
+	remaining int
+}
+
+func (t *countingTrigger) Next(after time.Time) time.Time {
+	if t.remaining == 0 {
+		return time.Time{}
+	}
+	t.remaining--
+	return after.Add(time.Second)
+}
+
+func waitForPendingTimer(t *testing.T, clock *failsafe.FakeClock) {
+	assert.Eventually(t, func() bool {
+		return clock.PendingTimers() == 1
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,113 @@
+package failsafe
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrSchedulerRejected indicates that an async execution was not performed because the Executor's Scheduler rejected it,
+// such as when a WorkerPool is saturated.
+var ErrSchedulerRejected = errors.New("execution rejected by scheduler")
+
+// Scheduler runs funcs asynchronously, such as on a pool of reused goroutines. A Scheduler can be configured on an
+// Executor via WithScheduler to run async executions and policy work, such as hedge attempts.
+//
+// Implementations must be concurrency safe.
+type Scheduler interface {
+	// Schedule runs the fn asynchronously, returning false if the fn was rejected. Schedule should not block or queue
+	// the fn, since policies may wait on the fn to run.
+	Schedule(fn func()) bool
+}
+
+// WorkerPool is a Scheduler that runs funcs on a bounded pool of reused goroutines. Workers are started as needed, up to
+// the maxWorkers, and are kept until the WorkerPool is closed. When all workers are busy, funcs are rejected rather than
+// queued.
+//
+// This type is concurrency safe.
+type WorkerPool struct {
+	maxWorkers int
+	tasks      chan func()
+	active     atomic.Int32
+	rejected   atomic.Uint64
+
+	mtx sync.Mutex
+	// Guarded by mtx
+	workers int
+	// Guarded by mtx
+	closed bool
+}
+
+var _ Scheduler = &WorkerPool{}
+
+// NewWorkerPool returns a new WorkerPool that runs up to maxWorkers funcs at a time.
+func NewWorkerPool(maxWorkers int) *WorkerPool {
+	return &WorkerPool{
+		maxWorkers: maxWorkers,
+		tasks:      make(chan func()),
+	}
+}
+
+func (p *WorkerPool) Schedule(fn func()) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.closed {
+		p.rejected.Add(1)
+		return false
+	}
+	p.active.Add(1)
+	if p.workers < p.maxWorkers {
+		p.workers++
+		go p.work(fn)
+		return true
+	}
+
+	// Hand the fn to an idle worker, if any
+	select {
+	case p.tasks <- fn:
+		return true
+	default:
+		p.active.Add(-1)
+		p.rejected.Add(1)
+		return false
+	}
+}
+
+func (p *WorkerPool) work(fn func()) {
+	for {
+		fn()
+		p.active.Add(-1)
+		var ok bool
+		if fn, ok = <-p.tasks; !ok {
+			return
+		}
+	}
+}
+
+// Workers returns the number of workers that have been started.
+func (p *WorkerPool) Workers() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.workers
+}
+
+// Active returns the number of funcs that are currently running.
+func (p *WorkerPool) Active() int {
+	return int(p.active.Load())
+}
+
+// Rejected returns the number of funcs that have been rejected.
+func (p *WorkerPool) Rejected() uint64 {
+	return p.rejected.Load()
+}
+
+// Close stops the WorkerPool's workers once they finish their current funcs. Funcs that are scheduled after Close are
+// rejected.
+func (p *WorkerPool) Close() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+}
@@ -0,0 +1,99 @@
+package failsafe_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+)
+
+// Asserts that a WorkerPool reuses workers, and rejects funcs when all workers are busy.
+func TestWorkerPool(t *testing.T) {
+	// Given
+	pool := failsafe.NewWorkerPool(2)
+	defer pool.Close()
+	release := make(chan struct{})
+	block := func() { <-release }
+
+	// When / Then
+	assert.True(t, pool.Schedule(block))
+	assert.True(t, pool.Schedule(block))
+	assert.False(t, pool.Schedule(block))
+	assert.Equal(t, 2, pool.Active())
+	assert.Equal(t, uint64(1), pool.Rejected())
+
+	// When workers are idle
+	close(release)
+	assert.Eventually(t, func() bool {
+		return pool.Active() == 0
+	}, time.Second, time.Millisecond)
+	done := make(chan struct{})
+	assert.Eventually(t, func() bool {
+		return pool.Schedule(func() { close(done) })
+	}, time.Second, time.Millisecond)
+
+	// Then
+	<-done
+	assert.Equal(t, 2, pool.Workers())
+
+	// When closed
+	pool.Close()
+
+	// Then
+	assert.False(t, pool.Schedule(func() {}))
+}
+
+// Asserts that async executions are rejected when the scheduler is saturated.
+func TestWithScheduler(t *testing.T) {
+	// Given
+	pool := failsafe.NewWorkerPool(1)
+	defer pool.Close()
+	executor := failsafe.NewExecutor[any]().WithScheduler(pool)
+	release := make(chan struct{})
+
+	// When
+	result1 := executor.RunAsync(func() error {
+		<-release
+		return nil
+	})
+	result2 := executor.RunAsync(func() error {
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, result2.Error(), failsafe.ErrSchedulerRejected)
+	close(release)
+	assert.NoError(t, result1.Error())
+}
+
+// Asserts that hedge attempts run on the scheduler when it has capacity, and are skipped otherwise.
+func TestWithSchedulerAndHedges(t *testing.T) {
+	// Given
+	pool := failsafe.NewWorkerPool(2)
+	defer pool.Close()
+	hp := hedgepolicy.BuilderWithDelay[any](10 * time.Millisecond).WithMaxHedges(2).Build()
+	executor := failsafe.NewExecutor[any](hp).WithScheduler(pool)
+	release := make(chan struct{})
+
+	// When
+	result := executor.RunWithExecutionAsync(func(exec failsafe.Execution[any]) error {
+		if exec.Attempts() > 1 {
+			return errors.New("unexpected hedge")
+		}
+		<-release
+		return nil
+	})
+	assert.Eventually(t, func() bool {
+		return pool.Rejected() == 1
+	}, time.Second, time.Millisecond)
+	close(release)
+
+	// Then
+	assert.NoError(t, result.Error())
+	assert.Equal(t, 2, pool.Workers())
+	assert.Equal(t, uint64(1), pool.Rejected())
+}
@@ -0,0 +1,49 @@
+package failsafe
+
+import "context"
+
+type tagsKey struct{}
+
+// WithTags returns a child of the ctx that carries the tags, merged with any tags that the ctx already carries. Tags are
+// provided to event listeners via ExecutionInfo.Tags for executions performed with the ctx, along with any tags that are
+// configured via Executor.WithTag, which allows a single shared listener to attribute events to an operation:
+//
+//	ctx = failsafe.WithTags(ctx, map[string]string{"operation": "createOrder"})
+//	err := executor.RunWithContext(ctx, createOrder)
+func WithTags(ctx context.Context, tags map[string]string) context.Context {
+	merged := make(map[string]string, len(tags))
+	for k, v := range contextTags(ctx) {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, tagsKey{}, merged)
+}
+
+// contextTags returns the tags that the ctx carries, else nil.
+func contextTags(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return tags
+}
+
+// mergeTags returns the tags with the overrides applied, avoiding an allocation when either is empty.
+func mergeTags(tags map[string]string, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return tags
+	}
+	if len(tags) == 0 {
+		return overrides
+	}
+	merged := make(map[string]string, len(tags)+len(overrides))
+	for k, v := range tags {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
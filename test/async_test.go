@@ -1,12 +1,14 @@
 package test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
 	"github.com/failsafe-go/failsafe-go/retrypolicy"
 )
 
@@ -23,3 +25,92 @@ func TestGetAsync(t *testing.T) {
 	assert.True(t, result.Result())
 	assert.Nil(t, result.Error())
 }
+
+func TestAsyncThenAndChan(t *testing.T) {
+	// Given
+	release := make(chan struct{})
+	result := failsafe.GetAsync(func() (string, error) {
+		<-release
+		return "foo", testutil.ErrInvalidState
+	})
+	var thenResults []string
+	result.Then(func(r string, err error) {
+		assert.ErrorIs(t, err, testutil.ErrInvalidState)
+		thenResults = append(thenResults, r+"1")
+	})
+	ch := result.Chan()
+
+	// When
+	close(release)
+
+	// Then
+	select {
+	case outcome := <-ch:
+		assert.Equal(t, "foo", outcome.Result)
+		assert.ErrorIs(t, outcome.Error, testutil.ErrInvalidState)
+	case <-time.After(time.Second):
+		assert.Fail(t, "expected a result")
+	}
+
+	// When already done
+	result.Then(func(r string, err error) {
+		thenResults = append(thenResults, r+"2")
+	})
+
+	// Then
+	assert.Equal(t, []string{"foo1", "foo2"}, thenResults)
+	outcome := <-result.Chan()
+	assert.Equal(t, "foo", outcome.Result)
+}
+
+// Asserts that Then fns are called in the order they were registered, even when they're registered while earlier fns
+// are being called.
+func TestAsyncThenOrder(t *testing.T) {
+	// Given
+	result := failsafe.GetAsync(func() (string, error) {
+		return "foo", nil
+	})
+	calling := make(chan struct{})
+	release := make(chan struct{})
+	results := make(chan string, 2)
+	result.Then(func(r string, err error) {
+		close(calling)
+		<-release
+		results <- r + "1"
+	})
+	<-calling
+
+	// When
+	result.Then(func(r string, err error) {
+		results <- r + "2"
+	})
+	close(release)
+
+	// Then
+	assert.Equal(t, "foo1", <-results)
+	assert.Equal(t, "foo2", <-results)
+}
+
+// Asserts that Then fns are still called after an earlier fn panics.
+func TestAsyncThenAfterPanic(t *testing.T) {
+	// Given an execution that's completed on the calling goroutine
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := failsafe.NewExecutor[string]().WithFailFastOnDone(true).GetWithContextAsync(ctx, func() (string, error) {
+		return "foo", nil
+	})
+
+	// When
+	assert.Panics(t, func() {
+		result.Then(func(r string, err error) {
+			panic("test")
+		})
+	})
+	var thenErr error
+	result.Then(func(r string, err error) {
+		thenErr = err
+	})
+
+	// Then
+	assert.ErrorIs(t, thenErr, failsafe.ErrContextDone)
+}
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/batcher"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+type batchItem struct{}
+
+func batchItemFunc(ctx context.Context) int {
+	item, _ := ctx.Value(batchItem{}).(int)
+	return item
+}
+
+func withBatchItem(item int) context.Context {
+	return context.WithValue(context.Background(), batchItem{}, item)
+}
+
+func batchFn(batches *atomic.Int32) batcher.BatchFunc[int, string] {
+	return func(ctx context.Context, items []int) ([]string, error) {
+		batches.Add(1)
+		results := make([]string, len(items))
+		for i, item := range items {
+			results[i] = strconv.Itoa(item)
+		}
+		return results, nil
+	}
+}
+
+// getBatched concurrently performs executions for the items, returning their results and errors.
+func getBatched(executor failsafe.Executor[string], items ...int) ([]string, []error) {
+	var wg sync.WaitGroup
+	results := make([]string, len(items))
+	errs := make([]error, len(items))
+	for i, item := range items {
+		wg.Add(1)
+		go func(i int, item int) {
+			defer wg.Done()
+			results[i], errs[i] = executor.GetWithContext(withBatchItem(item), nil)
+		}(i, item)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+// Asserts that executions are batched when the max batch size is reached.
+func TestBatcherWithMaxBatchSize(t *testing.T) {
+	// Given
+	var batches atomic.Int32
+	var sizes []int
+	b := batcher.Builder[int, string](batchItemFunc, batchFn(&batches)).
+		WithMaxBatchSize(3).
+		WithMaxDelay(time.Minute).
+		OnBatchDone(func(e batcher.BatchDoneEvent) {
+			sizes = append(sizes, e.Size)
+		}).
+		Build()
+	executor := failsafe.NewExecutor[string](b)
+
+	// When
+	results, errs := getBatched(executor, 1, 2, 3)
+
+	// Then
+	assert.Equal(t, []string{"1", "2", "3"}, results)
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, int32(1), batches.Load())
+	assert.Equal(t, []int{3}, sizes)
+	assert.Equal(t, 0, b.Pending())
+}
+
+// Asserts that executions are batched when the max delay elapses.
+func TestBatcherWithMaxDelay(t *testing.T) {
+	// Given
+	var batches atomic.Int32
+	b := batcher.Builder[int, string](batchItemFunc, batchFn(&batches)).
+		WithMaxDelay(50 * time.Millisecond).
+		Build()
+	executor := failsafe.NewExecutor[string](b)
+
+	// When
+	results, errs := getBatched(executor, 1, 2)
+
+	// Then
+	assert.Equal(t, []string{"1", "2"}, results)
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, int32(1), batches.Load())
+}
+
+func TestBatcherWithKeyFunc(t *testing.T) {
+	// Given
+	var batches atomic.Int32
+	b := batcher.Builder[int, string](batchItemFunc, batchFn(&batches)).
+		WithKeyFunc(func(ctx context.Context) string {
+			return strconv.Itoa(batchItemFunc(ctx) % 2)
+		}).
+		WithMaxBatchSize(2).
+		WithMaxDelay(time.Minute).
+		Build()
+	executor := failsafe.NewExecutor[string](b)
+
+	// When
+	results, _ := getBatched(executor, 1, 2, 3, 4)
+
+	// Then
+	assert.Equal(t, []string{"1", "2", "3", "4"}, results)
+	assert.Equal(t, int32(2), batches.Load())
+}
+
+// Asserts that per item errors are provided to their executions.
+func TestBatcherWithItemErrors(t *testing.T) {
+	// Given
+	b := batcher.Builder[int, string](batchItemFunc, func(ctx context.Context, items []int) ([]string, error) {
+		results := make([]string, len(items))
+		errs := make(batcher.ItemErrors, len(items))
+		for i, item := range items {
+			if item%2 == 0 {
+				errs[i] = testutil.ErrInvalidArgument
+			} else {
+				results[i] = strconv.Itoa(item)
+			}
+		}
+		return results, errs
+	}).WithMaxBatchSize(2).WithMaxDelay(time.Minute).Build()
+	executor := failsafe.NewExecutor[string](b)
+
+	// When
+	results, errs := getBatched(executor, 1, 2)
+
+	// Then
+	assert.Equal(t, []string{"1", ""}, results)
+	assert.Equal(t, []error{nil, testutil.ErrInvalidArgument}, errs)
+}
+
+func TestBatcherWithResultCountMismatch(t *testing.T) {
+	b := batcher.Builder[int, string](batchItemFunc, func(ctx context.Context, items []int) ([]string, error) {
+		return []string{"1"}, nil
+	}).WithMaxBatchSize(2).WithMaxDelay(time.Minute).Build()
+	executor := failsafe.NewExecutor[string](b)
+
+	_, errs := getBatched(executor, 1, 2)
+
+	assert.Equal(t, []error{batcher.ErrResultCount, batcher.ErrResultCount}, errs)
+}
+
+// Asserts that a panic in the batchFn fails all of the batch's items.
+func TestBatcherWithPanic(t *testing.T) {
+	// Given
+	var batchErr error
+	b := batcher.Builder[int, string](batchItemFunc, func(ctx context.Context, items []int) ([]string, error) {
+		panic("batch panicked")
+	}).WithMaxBatchSize(2).WithMaxDelay(time.Minute).OnBatchDone(func(e batcher.BatchDoneEvent) {
+		batchErr = e.Error
+	}).Build()
+	executor := failsafe.NewExecutor[string](b)
+
+	// When
+	_, errs := getBatched(executor, 1, 2)
+
+	// Then
+	for _, err := range append(errs, batchErr) {
+		var panicErr *failsafe.PanicError
+		assert.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, "batch panicked", panicErr.Value)
+	}
+}
+
+// Asserts that batches are retried via a batch executor.
+func TestBatcherWithBatchExecutor(t *testing.T) {
+	// Given
+	var attempts atomic.Int32
+	var batches atomic.Int32
+	b := batcher.Builder[int, string](batchItemFunc, func(ctx context.Context, items []int) ([]string, error) {
+		if attempts.Add(1) < 3 {
+			return nil, testutil.ErrConnecting
+		}
+		return batchFn(&batches)(ctx, items)
+	}).
+		WithMaxBatchSize(2).
+		WithMaxDelay(time.Minute).
+		WithBatchExecutor(failsafe.NewExecutor[[]string](retrypolicy.Builder[[]string]().WithMaxRetries(2).Build())).
+		Build()
+	executor := failsafe.NewExecutor[string](b)
+
+	// When
+	results, errs := getBatched(executor, 1, 2)
+
+	// Then
+	assert.Equal(t, []string{"1", "2"}, results)
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+// Asserts that a canceled execution stops waiting on its batch without affecting it.
+func TestBatcherWithCanceledExecution(t *testing.T) {
+	// Given
+	var batches atomic.Int32
+	b := batcher.Builder[int, string](batchItemFunc, batchFn(&batches)).
+		WithMaxDelay(100 * time.Millisecond).
+		Build()
+	executor := failsafe.NewExecutor[string](b)
+	ctx, cancel := context.WithCancel(withBatchItem(1))
+
+	// When
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := executor.GetWithContext(ctx, nil)
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, b.Pending())
+	assert.Eventually(t, func() bool {
+		return batches.Load() == 1 && b.Pending() == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/budget"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+// Tests that a Budget does not affect executions without a deadline.
+func TestBudgetWithoutDeadline(t *testing.T) {
+	// Given
+	executor := failsafe.NewExecutor[any](budget.With[any](time.Second))
+
+	// When
+	var hasDeadline bool
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		_, hasDeadline = exec.Context().Deadline()
+		return "success", nil
+	})
+
+	// Then
+	assert.Equal(t, "success", result)
+	assert.NoError(t, err)
+	assert.False(t, hasDeadline)
+}
+
+// Tests that each Budget trims the deadline seen by the execution.
+func TestBudgetTrimsDeadline(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	parentDeadline, _ := ctx.Deadline()
+	executor := failsafe.NewExecutor[any](budget.With[any](100*time.Millisecond), budget.With[any](50*time.Millisecond)).
+		WithContext(ctx)
+
+	// When
+	var deadline time.Time
+	_, err := executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		deadline, _ = exec.Context().Deadline()
+		return nil, nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, parentDeadline.Add(-150*time.Millisecond), deadline)
+}
+
+// Tests that an execution fails fast when not enough budget remains.
+func TestBudgetFailFast(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var exceeded bool
+	b := budget.Builder[any](50 * time.Millisecond).
+		WithMinRemaining(100 * time.Millisecond).
+		OnBudgetExceeded(func(e failsafe.ExecutionDoneEvent[any]) {
+			exceeded = true
+		}).
+		Build()
+	executor := failsafe.NewExecutor[any](b).WithContext(ctx)
+
+	// When
+	var called bool
+	_, err := executor.Get(func() (any, error) {
+		called = true
+		return nil, nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, budget.ErrExceeded)
+	assert.False(t, called)
+	assert.True(t, exceeded)
+}
+
+// Tests that an execution is canceled at its trimmed deadline, leaving time for an outer fallback.
+func TestFallbackBudgetWithBlockedFunc(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fb := fallback.WithResult[any]("fallback")
+	executor := failsafe.NewExecutor[any](fb, budget.With[any](950*time.Millisecond)).WithContext(ctx)
+
+	// When
+	start := time.Now()
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		<-exec.Canceled()
+		return nil, testutil.ErrInvalidState
+	})
+
+	// Then
+	assert.Equal(t, "fallback", result)
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.NoError(t, ctx.Err())
+}
+
+// Tests that a Budget configured with a FakeClock times the execution with the clock and cancels the execution when the
+// clock is advanced past the remaining budget.
+func TestBudgetWithFakeClock(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	ctx, cancel := context.WithDeadline(context.Background(), clock.Now().Add(2*time.Hour))
+	defer cancel()
+	b := budget.Builder[any](time.Hour).WithClock(clock).Build()
+
+	// When
+	result := failsafe.NewExecutor[any](b).WithContext(ctx).GetWithExecutionAsync(func(exec failsafe.Execution[any]) (any, error) {
+		<-exec.Canceled()
+		return nil, nil
+	})
+	waitForTimers(clock, 1)
+	clock.Advance(time.Hour)
+
+	// Then
+	_, err := result.Get()
+	assert.ErrorIs(t, err, budget.ErrExceeded)
+	assert.NoError(t, ctx.Err())
+}
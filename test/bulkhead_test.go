@@ -1,11 +1,13 @@
 package test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/bulkhead"
 	"github.com/failsafe-go/failsafe-go/internal/policytesting"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
@@ -34,7 +36,7 @@ func TestBulkheadPermitAcquiredAfterWait(t *testing.T) {
 func TestBulkheadNotFull(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	bh := policytesting.WithBulkheadStatsAndLogs(bulkhead.Builder[any](2), stats, true).Build()
+	bh := policytesting.WithBulkheadStats(bulkhead.Builder[any](2), stats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -47,7 +49,7 @@ func TestBulkheadNotFull(t *testing.T) {
 func TestBulkheadFull(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	bh := policytesting.WithBulkheadStatsAndLogs(bulkhead.Builder[any](2), stats, true).Build()
+	bh := policytesting.WithBulkheadStats(bulkhead.Builder[any](2), stats).Build()
 	assert.True(t, bh.TryAcquirePermit())
 	assert.True(t, bh.TryAcquirePermit()) // bulkhead should be full
 
@@ -86,3 +88,42 @@ func TestBulkheadWithShortMaxWaitTime(t *testing.T) {
 		Run(testutil.RunFn(nil)).
 		AssertSuccess(1, 1, nil)
 }
+
+func TestKeyedBulkhead(t *testing.T) {
+	// Given
+	type tenantKey struct{}
+	bh := bulkhead.Keyed[any](func(ctx context.Context) string {
+		return ctx.Value(tenantKey{}).(string)
+	}, bulkhead.Builder[any](1), 0)
+	executor := failsafe.NewExecutor[any](bh)
+	ctxA := context.WithValue(context.Background(), tenantKey{}, "a")
+	ctxB := context.WithValue(context.Background(), tenantKey{}, "b")
+	assert.True(t, bh.Get("a").TryAcquirePermit()) // a should be full
+
+	// When / Then
+	assert.ErrorIs(t, executor.RunWithContext(ctxA, func() error { return nil }), bulkhead.ErrFull)
+	assert.NoError(t, executor.RunWithContext(ctxB, func() error { return nil }))
+	bh.Get("a").ReleasePermit()
+	assert.NoError(t, executor.RunWithContext(ctxA, func() error { return nil }))
+	assert.Equal(t, 2, bh.Keys())
+}
+
+// Asserts that an execution with a canceled context does not acquire a permit.
+func TestBulkheadWithCanceledContext(t *testing.T) {
+	// Given
+	bh := bulkhead.Builder[any](1).Build()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	executed := false
+
+	// When
+	err := failsafe.NewExecutor[any](bh).RunWithContext(ctx, func() error {
+		executed = true
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, executed)
+	assert.True(t, bh.TryAcquirePermit())
+}
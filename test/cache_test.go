@@ -2,7 +2,11 @@ package test
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -168,3 +172,166 @@ func TestDoNotCacheOnError(t *testing.T) {
 			assert.Equal(t, 1, stats.CacheMisses())
 		})
 }
+
+// Tests caching values in a byte cache via a SerializingCache, including skipping values that exceed the max size.
+func TestSerializingCache(t *testing.T) {
+	// Given
+	cache, byteCache := policytesting.NewCache[[]byte]()
+	var events []cachepolicy.SerializedEvent
+	serializingCache := cachepolicy.SerializingCache(byteCache, cachepolicy.GzipCodec(cachepolicy.JSONCodec[string]())).
+		WithMaxSize(100).
+		OnSerialized(func(e cachepolicy.SerializedEvent) {
+			events = append(events, e)
+		}).
+		Build()
+	stats := &policytesting.Stats{}
+	cp := policytesting.WithCacheStats(cachepolicy.Builder[string](serializingCache), stats).
+		WithKey("foo").
+		Build()
+
+	setup := func() {
+		stats.Reset()
+		clear(cache)
+		events = nil
+	}
+
+	// When / Then
+	testutil.Test[string](t).
+		With(cp).
+		Setup(setup).
+		Get(testutil.GetFn("bar", nil)).
+		AssertSuccess(1, 1, "bar", func() {
+			assert.Equal(t, 1, stats.Caches())
+			assert.Len(t, cache, 1)
+			assert.Len(t, events, 1)
+			assert.False(t, events[0].Skipped)
+			assert.Equal(t, len(cache["foo"]), events[0].Size)
+		})
+	testutil.Test[string](t).
+		With(cp).
+		Reset(stats).
+		Get(testutil.GetFn("missing", nil)).
+		AssertSuccess(1, 0, "bar", func() {
+			assert.Equal(t, 1, stats.CacheHits())
+		})
+
+	// Values that exceed the max size should not be cached
+	clear(cache)
+	events = nil
+	serializingCache = cachepolicy.SerializingCache(byteCache, cachepolicy.JSONCodec[string]()).
+		WithMaxSize(100).
+		OnSerialized(func(e cachepolicy.SerializedEvent) {
+			events = append(events, e)
+		}).
+		Build()
+	serializingCache.Set("foo", strings.Repeat("large value ", 10))
+	assert.Empty(t, cache)
+	assert.True(t, events[0].Skipped)
+	assert.Greater(t, events[0].Size, 100)
+}
+
+// Tests that concurrent executions that miss the cache for the same key share a single load.
+func TestCacheWithSingleFlight(t *testing.T) {
+	// Given
+	cp := cachepolicy.Builder[string](cachepolicy.NewMemoryCache[string](0, 0)).
+		WithKey("foo").
+		WithSingleFlight().
+		Build()
+	executor := failsafe.NewExecutor[string](cp)
+	var loads atomic.Int32
+	release := make(chan struct{})
+
+	// When
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = executor.Get(func() (string, error) {
+				loads.Add(1)
+				<-release
+				return "bar", nil
+			})
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Equal(t, []string{"bar", "bar", "bar", "bar", "bar"}, results)
+}
+
+// Tests that an execution waiting on a single flight load takes over the load when the loading execution is canceled.
+func TestCacheWithSingleFlightCanceledLoad(t *testing.T) {
+	// Given
+	cp := cachepolicy.Builder[string](cachepolicy.NewMemoryCache[string](0, 0)).
+		WithKey("foo").
+		WithSingleFlight().
+		Build()
+	executor := failsafe.NewExecutor[string](cp)
+	ctx, cancel := context.WithCancel(context.Background())
+	loading := make(chan struct{})
+	canceledResult := executor.WithContext(ctx).GetWithExecutionAsync(func(exec failsafe.Execution[string]) (string, error) {
+		close(loading)
+		<-exec.Canceled()
+		return "", exec.Context().Err()
+	})
+	<-loading
+
+	// When
+	waiterResult := executor.GetAsync(func() (string, error) {
+		return "bar", nil
+	})
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	// Then
+	_, err := canceledResult.Get()
+	assert.ErrorIs(t, err, context.Canceled)
+	result, err := waiterResult.Get()
+	assert.Equal(t, "bar", result)
+	assert.NoError(t, err)
+}
+
+// Tests that expired values are refreshed by one execution while other executions use the expired value.
+func TestCacheWithEarlyExpiration(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	cp := cachepolicy.Builder[string](cachepolicy.NewMemoryCache[string](0, 0)).
+		WithKey("foo").
+		WithEarlyExpiration(50*time.Millisecond, 0).
+		WithClock(clock).
+		Build()
+	executor := failsafe.NewExecutor[string](cp)
+	result, _ := executor.GetWithExecution(testutil.GetFn("bar", nil))
+	assert.Equal(t, "bar", result)
+
+	// When not expired
+	result, _ = executor.GetWithExecution(testutil.GetFn("baz", nil))
+
+	// Then
+	assert.Equal(t, "bar", result)
+
+	// When expired and refreshing
+	clock.Advance(60 * time.Millisecond)
+	refreshing := make(chan struct{})
+	release := make(chan struct{})
+	refreshResult := executor.GetAsync(func() (string, error) {
+		close(refreshing)
+		<-release
+		return "baz", nil
+	})
+	<-refreshing
+	result, _ = executor.GetWithExecution(testutil.GetFn("qux", nil))
+
+	// Then
+	assert.Equal(t, "bar", result)
+	close(release)
+	result, _ = refreshResult.Get()
+	assert.Equal(t, "baz", result)
+	result, _ = executor.GetWithExecution(testutil.GetFn("qux", nil))
+	assert.Equal(t, "baz", result)
+}
@@ -98,7 +98,7 @@ func TestCancelWithExecutionResult(t *testing.T) {
 // Asserts that when a RetryPolicy is blocked on a delay, canceling the context results in a Canceled error being returned.
 func TestCancelWithContextDuringPendingRetry(t *testing.T) {
 	// Given
-	rp := policytesting.WithRetryLogs[any](retrypolicy.Builder[any]().WithDelay(time.Second)).Build()
+	rp := retrypolicy.Builder[any]().WithDelay(time.Second).Build()
 	setup := testutil.SetupWithContextSleep(50 * time.Millisecond)
 
 	// When / Then
@@ -205,7 +205,7 @@ func TestCancelWithTimeoutDuringBulkheadDelay(t *testing.T) {
 func TestCancelWithContextBeforeHedge(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[any](time.Second).WithMaxHedges(2), stats).Build()
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[any](time.Second).WithMaxHedges(2), stats).Build()
 	setup := testutil.SetupWithContextSleep(100 * time.Millisecond)
 
 	// When / Then
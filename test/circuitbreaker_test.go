@@ -28,6 +28,24 @@ func TestShouldRejectInitialExecutionWhenCircuitOpen(t *testing.T) {
 		})
 }
 
+// Asserts that an OpenError identifies which of several composed circuit breakers rejected an execution.
+func TestOpenError(t *testing.T) {
+	// Given
+	cb1 := circuitbreaker.WithDefaults[any]()
+	cb2 := circuitbreaker.WithDefaults[any]()
+	cb2.Open()
+
+	// When
+	err := failsafe.NewExecutor[any](cb1, cb2).Run(testutil.NoopFn)
+
+	// Then
+	var openErr circuitbreaker.OpenError
+	assert.ErrorAs(t, err, &openErr)
+	assert.True(t, openErr.CircuitBreaker == cb2)
+	assert.Equal(t, circuitbreaker.OpenState, openErr.State)
+	assert.True(t, openErr.RemainingDelay > 0)
+}
+
 // Should return ErrOpen when max half-open executions are occurring.
 func TestShouldRejectExcessiveAttemptsWhenBreakerHalfOpen(t *testing.T) {
 	// Given
@@ -48,7 +66,7 @@ func TestShouldRejectExcessiveAttemptsWhenBreakerHalfOpen(t *testing.T) {
 	// Assert that the breaker does not allow any more executions at the moment
 	waiter.AwaitWithTimeout(3, 10*time.Second)
 	for i := 0; i < 5; i++ {
-		assert.ErrorIs(t, circuitbreaker.ErrOpen, failsafe.NewExecutor[any](cb).Run(testutil.NoopFn))
+		assert.ErrorIs(t, failsafe.NewExecutor[any](cb).Run(testutil.NoopFn), circuitbreaker.ErrOpen)
 	}
 }
 
@@ -245,6 +263,38 @@ func TestStateChangeListener(t *testing.T) {
 	})
 }
 
+func TestStateChangeListenerReasons(t *testing.T) {
+	// Given
+	var events []circuitbreaker.StateChangedEvent
+	cb := circuitbreaker.Builder[any]().
+		WithFailureThreshold(1).
+		WithDelay(10 * time.Millisecond).
+		OnStateChanged(func(e circuitbreaker.StateChangedEvent) {
+			events = append(events, e)
+		}).
+		Build()
+	executor := failsafe.NewExecutor[any](cb)
+
+	// When
+	_, _ = executor.GetWithExecution(testutil.GetFn[any](nil, testutil.ErrInvalidArgument))
+	time.Sleep(20 * time.Millisecond)
+	_ = cb.IsHalfOpen()
+	_, _ = executor.Get(func() (any, error) {
+		return "success", nil
+	})
+	cb.Open()
+
+	// Then
+	assert.Len(t, events, 4)
+	assert.Equal(t, circuitbreaker.FailureThresholdReason, events[0].Reason)
+	assert.ErrorIs(t, events[0].LastError, testutil.ErrInvalidArgument)
+	assert.Equal(t, circuitbreaker.DelayElapsedReason, events[1].Reason)
+	assert.Equal(t, circuitbreaker.SuccessThresholdReason, events[2].Reason)
+	assert.Equal(t, circuitbreaker.ManualReason, events[3].Reason)
+	assert.Nil(t, events[3].LastResult)
+	assert.Nil(t, events[3].LastError)
+}
+
 func TestStateChangeListenerOnClose(t *testing.T) {
 	// Given
 	var called bool
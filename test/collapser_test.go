@@ -0,0 +1,225 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/collapser"
+	"github.com/failsafe-go/failsafe-go/internal/testutil"
+)
+
+type collapseKey struct{}
+
+func collapseKeyFunc(ctx context.Context) string {
+	key, _ := ctx.Value(collapseKey{}).(string)
+	return key
+}
+
+func withCollapseKey(key string) context.Context {
+	return context.WithValue(context.Background(), collapseKey{}, key)
+}
+
+// Asserts that concurrent executions for the same key are collapsed into a single execution.
+func TestCollapser(t *testing.T) {
+	// Given
+	var collapsed atomic.Int32
+	c := collapser.Builder[string](collapseKeyFunc).
+		OnCollapsed(func(e collapser.CollapsedEvent[string]) {
+			assert.Equal(t, "foo", e.Key)
+			collapsed.Add(int32(e.Collapsed))
+		}).
+		Build()
+	executor := failsafe.NewExecutor[string](c)
+	var executions atomic.Int32
+	release := make(chan struct{})
+	fn := func() (string, error) {
+		executions.Add(1)
+		<-release
+		return "bar", nil
+	}
+
+	// When
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = executor.GetWithContext(withCollapseKey("foo"), fn)
+		}(i)
+	}
+	assert.Eventually(t, func() bool {
+		return executions.Load() == 1 && c.InFlight() == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(1), executions.Load())
+	assert.Equal(t, []string{"bar", "bar", "bar", "bar", "bar"}, results)
+	assert.Equal(t, int32(4), collapsed.Load())
+	assert.Equal(t, 0, c.InFlight())
+
+	// Executions are not deduped after completion without a dedupe window
+	result, _ := executor.GetWithContext(withCollapseKey("foo"), fn)
+	assert.Equal(t, "bar", result)
+	assert.Equal(t, int32(2), executions.Load())
+}
+
+func TestCollapserWithDedupeWindow(t *testing.T) {
+	// Given
+	c := collapser.Builder[string](collapseKeyFunc).WithDedupeWindow(50 * time.Millisecond).Build()
+	executor := failsafe.NewExecutor[string](c)
+	var executions atomic.Int32
+	fn := func() (string, error) {
+		return "bar", testutil.ErrInvalidState
+	}
+	countingFn := func() (string, error) {
+		executions.Add(1)
+		return fn()
+	}
+
+	// When / Then
+	for i := 0; i < 3; i++ {
+		result, err := executor.GetWithContext(withCollapseKey("foo"), countingFn)
+		assert.Equal(t, "bar", result)
+		assert.ErrorIs(t, err, testutil.ErrInvalidState)
+	}
+	assert.Equal(t, int32(1), executions.Load())
+
+	// When / Then
+	assert.Eventually(t, func() bool {
+		_, _ = executor.GetWithContext(withCollapseKey("foo"), countingFn)
+		return executions.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCollapserWithoutKey(t *testing.T) {
+	executor := failsafe.NewExecutor[string](collapser.With[string](collapseKeyFunc))
+	var executions atomic.Int32
+	for i := 0; i < 2; i++ {
+		_, _ = executor.Get(func() (string, error) {
+			executions.Add(1)
+			return "bar", nil
+		})
+	}
+	assert.Equal(t, int32(2), executions.Load())
+}
+
+// Asserts that waiting executions perform the work themselves when the in flight execution is canceled.
+func TestCollapserWithCanceledExecution(t *testing.T) {
+	// Given
+	executor := failsafe.NewExecutor[string](collapser.With[string](collapseKeyFunc))
+	started := make(chan struct{})
+	ctx, cancel := context.WithCancel(withCollapseKey("foo"))
+	defer cancel()
+
+	// When
+	first := executor.GetWithContextAsync(ctx, func() (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	<-started
+	second := executor.GetWithContextAsync(withCollapseKey("foo"), func() (string, error) {
+		return "bar", nil
+	})
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	// Then
+	assert.ErrorIs(t, first.Error(), context.Canceled)
+	result, err := second.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", result)
+}
+
+// Asserts that waiting executions perform the work themselves when the in flight execution panics, and that later
+// executions for the key are not blocked.
+func TestCollapserWithPanickedExecution(t *testing.T) {
+	// Given
+	c := collapser.With[string](collapseKeyFunc)
+	executor := failsafe.NewExecutor[string](c)
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	// When
+	panicked := make(chan any)
+	go func() {
+		defer func() {
+			panicked <- recover()
+		}()
+		_, _ = executor.GetWithContext(withCollapseKey("foo"), func() (string, error) {
+			close(started)
+			<-release
+			panic("test")
+		})
+	}()
+	<-started
+	second := executor.GetWithContextAsync(withCollapseKey("foo"), func() (string, error) {
+		return "bar", nil
+	})
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	// Then
+	assert.Equal(t, "test", <-panicked)
+	result, err := second.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", result)
+	result, err = executor.GetWithContext(withCollapseKey("foo"), func() (string, error) {
+		return "baz", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "baz", result)
+	assert.Equal(t, 0, c.InFlight())
+}
+
+// Asserts that waiting executions that are canceled are not counted as collapsed.
+func TestCollapserWithCanceledWaiter(t *testing.T) {
+	// Given
+	collapsed := make(chan int, 1)
+	c := collapser.Builder[string](collapseKeyFunc).
+		OnCollapsed(func(e collapser.CollapsedEvent[string]) {
+			collapsed <- e.Collapsed
+		}).
+		Build()
+	executor := failsafe.NewExecutor[string](c)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(withCollapseKey("foo"))
+
+	// When
+	first := executor.GetWithContextAsync(withCollapseKey("foo"), func() (string, error) {
+		close(started)
+		<-release
+		return "bar", nil
+	})
+	<-started
+	second := executor.GetWithContextAsync(ctx, func() (string, error) {
+		return "baz", nil
+	})
+	third := executor.GetWithContextAsync(withCollapseKey("foo"), func() (string, error) {
+		return "baz", nil
+	})
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, second.Error(), context.Canceled)
+	close(release)
+
+	// Then
+	result, err := first.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", result)
+	result, err = third.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", result)
+	assert.Equal(t, 1, <-collapsed)
+}
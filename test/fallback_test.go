@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -102,3 +103,94 @@ func TestShouldNotCallFallbackWhenCanceled(t *testing.T) {
 		Get(testutil.GetFn[any](nil, testutil.ErrInvalidArgument)).
 		AssertFailure(1, 1, context.Canceled)
 }
+
+// Tests that a Fallback with a background delay returns the fallback result for slow executions, which continue in the
+// background.
+func TestFallbackWithBackgroundDelay(t *testing.T) {
+	t.Run("should fallback and continue in background when delay is exceeded", func(t *testing.T) {
+		// Given
+		completed := make(chan failsafe.ExecutionDoneEvent[string], 1)
+		fb := fallback.BuilderWithResult[string]("fallback").
+			WithBackgroundDelay(10 * time.Millisecond).
+			OnBackgroundCompleted(func(e failsafe.ExecutionDoneEvent[string]) {
+				completed <- e
+			}).
+			Build()
+
+		// When
+		result, err := failsafe.NewExecutor[string](fb).Get(func() (string, error) {
+			time.Sleep(50 * time.Millisecond)
+			return "primary", nil
+		})
+
+		// Then
+		assert.Equal(t, "fallback", result)
+		assert.NoError(t, err)
+		select {
+		case e := <-completed:
+			assert.Equal(t, "primary", e.Result)
+			assert.NoError(t, e.Error)
+		case <-time.After(time.Second):
+			assert.Fail(t, "expected background completion")
+		}
+	})
+
+	t.Run("should not cancel background execution when the caller is done", func(t *testing.T) {
+		// Given
+		clock := failsafe.NewFakeClock(time.Now())
+		completed := make(chan failsafe.ExecutionDoneEvent[string], 1)
+		fb := fallback.BuilderWithResult[string]("fallback").
+			WithBackgroundDelay(time.Minute).
+			WithClock(clock).
+			OnBackgroundCompleted(func(e failsafe.ExecutionDoneEvent[string]) {
+				completed <- e
+			}).
+			Build()
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+
+		// When
+		result := failsafe.NewExecutor[string](fb).WithContext(ctx).GetWithExecutionAsync(func(exec failsafe.Execution[string]) (string, error) {
+			<-release
+			return "primary", exec.Context().Err()
+		})
+		waitForTimers(clock, 1)
+		clock.Advance(time.Minute)
+		fallbackResult, err := result.Get()
+		cancel()
+		close(release)
+
+		// Then
+		assert.Equal(t, "fallback", fallbackResult)
+		assert.NoError(t, err)
+		e := <-completed
+		assert.Equal(t, "primary", e.Result)
+		assert.NoError(t, e.Error)
+	})
+
+	t.Run("should not fallback when execution completes within delay", func(t *testing.T) {
+		// Given
+		fb := fallback.BuilderWithResult[string]("fallback").
+			WithBackgroundDelay(time.Second).
+			Build()
+
+		// When / Then
+		testutil.Test[string](t).
+			With(fb).
+			Get(testutil.GetFn("primary", nil)).
+			AssertSuccess(1, 1, "primary")
+	})
+
+	t.Run("should fallback when execution fails within delay", func(t *testing.T) {
+		// Given
+		fb := fallback.BuilderWithResult[string]("fallback").
+			WithBackgroundDelay(time.Second).
+			Build()
+
+		// When / Then
+		testutil.Test[string](t).
+			With(fb).
+			Get(testutil.GetFn("", testutil.ErrInvalidArgument)).
+			AssertSuccess(1, 1, "fallback")
+	})
+}
@@ -1,6 +1,8 @@
 package test
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,12 +12,13 @@ import (
 	"github.com/failsafe-go/failsafe-go/hedgepolicy"
 	"github.com/failsafe-go/failsafe-go/internal/policytesting"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
+	"github.com/failsafe-go/failsafe-go/timeout"
 )
 
 func TestShouldNotHedgeWhenDelayNotExceeded(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[any](time.Second), stats).Build()
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[any](time.Second), stats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -31,7 +34,7 @@ func TestShouldNotHedgeWhenDelayNotExceeded(t *testing.T) {
 func TestShouldHedgeWhenDelayExceeded(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[bool](10*time.Millisecond).WithMaxHedges(2), stats).Build()
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[bool](10*time.Millisecond).WithMaxHedges(2), stats).Build()
 
 	// When / Then
 	testutil.Test[bool](t).
@@ -50,11 +53,76 @@ func TestShouldHedgeWhenDelayExceeded(t *testing.T) {
 		})
 }
 
+// Tests that executions marked as not idempotent are not hedged.
+func TestShouldNotHedgeNonIdempotentExecution(t *testing.T) {
+	// Given
+	stats := &policytesting.Stats{}
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[bool](10*time.Millisecond), stats).Build()
+
+	// When / Then
+	testutil.Test[bool](t).
+		With(hp).
+		Reset(stats).
+		Context(func() context.Context {
+			return failsafe.MarkIdempotent(context.Background(), false)
+		}).
+		Get(func(exec failsafe.Execution[bool]) (bool, error) {
+			time.Sleep(50 * time.Millisecond)
+			return true, nil
+		}).
+		AssertSuccess(1, 1, true, func() {
+			assert.Equal(t, 0, stats.Hedges())
+		})
+}
+
+// Tests that the results of attempts that are not used are discarded after they complete.
+func TestShouldDiscardUnusedHedgeResults(t *testing.T) {
+	// Given
+	var mtx sync.Mutex
+	var cleanedUp []int
+	var discardedResults []int
+	hp := hedgepolicy.BuilderWithDelay[int](10 * time.Millisecond).
+		WithCleanup(func(result int) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			cleanedUp = append(cleanedUp, result)
+		}).
+		OnHedgeDiscarded(func(e failsafe.ExecutionEvent[int]) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			discardedResults = append(discardedResults, e.LastResult())
+		}).
+		Build()
+
+	// When
+	result, err := failsafe.NewExecutor[int](hp).GetWithExecution(func(exec failsafe.Execution[int]) (int, error) {
+		attempt := exec.Attempts()
+		if attempt == 1 {
+			// Ignore cancellation
+			time.Sleep(50 * time.Millisecond)
+		}
+		return attempt, nil
+	})
+
+	// Then
+	assert.Equal(t, 2, result)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(cleanedUp) == 1
+	}, time.Second, 10*time.Millisecond)
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, []int{1}, cleanedUp)
+	assert.Equal(t, []int{1}, discardedResults)
+}
+
 // Asserts that the expected number of hedges are executed.
 func TestAllHedgesUsed(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[int](20*time.Millisecond).WithMaxHedges(2), stats).Build()
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[int](20*time.Millisecond).WithMaxHedges(2), stats).Build()
 
 	// When / Then
 	testutil.Test[int](t).
@@ -75,7 +143,7 @@ func TestAllHedgesUsed(t *testing.T) {
 func TestBackupExecutions(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[int](0).
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[int](0).
 		WithMaxHedges(2).
 		CancelOnResult(3), stats).Build()
 
@@ -95,7 +163,7 @@ func TestBackupExecutions(t *testing.T) {
 func TestCancelOnResult(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond).
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond).
 		WithMaxHedges(4).
 		CancelOnResult(true).
 		CancelOnResult(3), stats).
@@ -140,3 +208,154 @@ func TestCancelOnResult(t *testing.T) {
 			})
 	})
 }
+
+// Asserts that a result selector can select a successful result that completes after an earlier failure.
+func TestHedgeWithFirstSuccessSelector(t *testing.T) {
+	// Given
+	stats := &policytesting.Stats{}
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[int](10*time.Millisecond).
+		WithMaxHedges(2).
+		WithResultSelector(hedgepolicy.FirstSuccess[int]()), stats).
+		Build()
+
+	// When / Then
+	testutil.Test[int](t).
+		With(hp).
+		Reset(stats).
+		Get(func(exec failsafe.Execution[int]) (int, error) {
+			switch exec.Attempts() {
+			case 1:
+				time.Sleep(20 * time.Millisecond)
+				return 0, testutil.ErrInvalidState
+			case 2:
+				time.Sleep(50 * time.Millisecond)
+				return 2, nil
+			default:
+				testutil.WaitAndAssertCanceled(t, time.Second, exec)
+				return 0, testutil.ErrInvalidState
+			}
+		}).
+		AssertSuccess(3, -1, 2, func() {
+			assert.Equal(t, 2, stats.Hedges())
+		})
+}
+
+// Asserts that a quorum result selector waits for matching results.
+func TestHedgeWithQuorumSelector(t *testing.T) {
+	// Given
+	stats := &policytesting.Stats{}
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[int](10*time.Millisecond).
+		WithMaxHedges(2).
+		WithResultSelector(hedgepolicy.Quorum[int](2)), stats).
+		Build()
+
+	// When / Then
+	testutil.Test[int](t).
+		With(hp).
+		Reset(stats).
+		Get(func(exec failsafe.Execution[int]) (int, error) {
+			if exec.Attempts() == 1 {
+				time.Sleep(15 * time.Millisecond)
+				return 1, nil
+			}
+			time.Sleep(20 * time.Millisecond)
+			return 5, nil
+		}).
+		AssertSuccess(3, -1, 5, func() {
+			assert.Equal(t, 2, stats.Hedges())
+		})
+}
+
+// Asserts that a Budget shared across policies limits their hedges.
+func TestShouldLimitHedgesWithBudget(t *testing.T) {
+	// Given
+	budget := hedgepolicy.NewBudget(0, 1)
+	hp1 := hedgepolicy.BuilderWithDelay[int](10 * time.Millisecond).WithBudget(budget).Build()
+	hp2 := hedgepolicy.BuilderWithDelay[int](10 * time.Millisecond).WithBudget(budget).CancelOnResult(3).Build()
+	fn := func(exec failsafe.Execution[int]) (int, error) {
+		attempt := exec.Attempts()
+		time.Sleep(50 * time.Millisecond)
+		return attempt, nil
+	}
+
+	// When / Then
+	result, err := failsafe.GetWithExecution(fn, hp1)
+	assert.Equal(t, 1, result)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, budget.AvailableHedges())
+
+	// Budget is exhausted, so a non-cancellable result from the initial attempt is returned without hedging
+	testutil.Test[int](t).
+		With(hp2).
+		Get(fn).
+		AssertSuccess(1, 1, 1)
+}
+
+func TestBudget(t *testing.T) {
+	budget := hedgepolicy.NewBudget(.5, 2)
+	assert.True(t, budget.TryAcquireHedge())
+	assert.True(t, budget.TryAcquireHedge())
+	assert.False(t, budget.TryAcquireHedge())
+
+	budget.RecordExecution()
+	assert.False(t, budget.TryAcquireHedge())
+	budget.RecordExecution()
+	assert.True(t, budget.TryAcquireHedge())
+
+	for i := 0; i < 10; i++ {
+		budget.RecordExecution()
+	}
+	assert.Equal(t, 2, budget.AvailableHedges())
+}
+
+// Asserts that each attempt is limited by the attempt timeout, and that an attempt that times out does not cancel other
+// outstanding attempts.
+func TestHedgeWithAttemptTimeout(t *testing.T) {
+	// Given
+	hp := hedgepolicy.BuilderWithDelay[string](20 * time.Millisecond).
+		WithAttemptTimeout(50 * time.Millisecond).
+		Build()
+	var mtx sync.Mutex
+	var deadlines []time.Time
+
+	// When
+	start := time.Now()
+	result, err := failsafe.NewExecutor[string](hp).GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		deadline, _ := exec.Context().Deadline()
+		mtx.Lock()
+		deadlines = append(deadlines, deadline)
+		mtx.Unlock()
+		<-exec.Canceled()
+		return "", nil
+	})
+
+	// Then
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+	assert.Len(t, deadlines, 2)
+	for _, deadline := range deadlines {
+		assert.False(t, deadline.IsZero())
+	}
+}
+
+// Tests that a panic in a hedge attempt is recovered as the attempt's result rather than crashing the process.
+func TestHedgeWithPanickedAttempt(t *testing.T) {
+	// Given
+	hp := hedgepolicy.BuilderWithDelay[string](10 * time.Millisecond).Build()
+
+	// When
+	result, err := failsafe.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		if exec.IsHedge() {
+			panic("hedge panicked")
+		}
+		time.Sleep(100 * time.Millisecond)
+		return "success", nil
+	}, hp)
+
+	// Then
+	assert.Empty(t, result)
+	var panicErr *failsafe.PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "hedge panicked", panicErr.Value)
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/bulkhead"
+	"github.com/failsafe-go/failsafe-go/hedgepolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// leakRecorder records the kinds of resources that are reported as leaked.
+type leakRecorder struct {
+	mtx   sync.Mutex
+	kinds []failsafe.ResourceKind
+}
+
+func (r *leakRecorder) record(e failsafe.LeakEvent) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, leak := range e.Leaks {
+		r.kinds = append(r.kinds, leak.Kind)
+	}
+}
+
+func (r *leakRecorder) get() []failsafe.ResourceKind {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.kinds
+}
+
+func TestNoLeaks(t *testing.T) {
+	// Given
+	recorder := &leakRecorder{}
+	executor := failsafe.NewExecutor[any](timeout.With[any](10*time.Millisecond), bulkhead.With[any](1)).
+		WithLeakDetection(0, recorder.record)
+
+	// When
+	err1 := executor.Run(func() error {
+		return nil
+	})
+	err2 := executor.RunWithExecution(func(exec failsafe.Execution[any]) error {
+		<-exec.Canceled()
+		return nil
+	})
+
+	// Then
+	assert.NoError(t, err1)
+	assert.ErrorIs(t, err2, timeout.ErrExceeded)
+	assert.Empty(t, recorder.get())
+}
+
+// Asserts that hedge attempts that are still running after an execution is done, and their Bulkhead permits, are
+// reported as leaked, unless they return within the grace period.
+func TestLeaks(t *testing.T) {
+	test := func(gracePeriod time.Duration, expected []failsafe.ResourceKind) {
+		// Given
+		recorder := &leakRecorder{}
+		hp := hedgepolicy.BuilderWithDelay[any](10 * time.Millisecond).
+			CancelIf(func(_ any, err error) bool {
+				return err == nil
+			}).
+			Build()
+		executor := failsafe.NewExecutor[any](hp, bulkhead.With[any](2)).WithLeakDetection(gracePeriod, recorder.record)
+		release := make(chan struct{})
+
+		// When
+		err := executor.RunWithExecution(func(exec failsafe.Execution[any]) error {
+			if exec.IsHedge() {
+				return nil
+			}
+			<-release
+			return nil
+		})
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		time.Sleep(2 * gracePeriod)
+
+		// Then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, recorder.get())
+	}
+
+	test(0, []failsafe.ResourceKind{failsafe.GoroutineResource, failsafe.PermitResource})
+	test(50*time.Millisecond, nil)
+}
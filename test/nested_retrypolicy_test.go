@@ -21,8 +21,8 @@ func TestNestedRetryPoliciesWhereInnerIsExceeded(t *testing.T) {
 	// Given
 	outerRetryStats := &policytesting.Stats{}
 	innerRetryStats := &policytesting.Stats{}
-	outerRetryPolicy := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[bool]().WithMaxRetries(10), outerRetryStats).Build()
-	innerRetryPolicy := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[bool]().WithMaxRetries(1), innerRetryStats).Build()
+	outerRetryPolicy := policytesting.WithRetryStats(retrypolicy.Builder[bool]().WithMaxRetries(10), outerRetryStats).Build()
+	innerRetryPolicy := policytesting.WithRetryStats(retrypolicy.Builder[bool]().WithMaxRetries(1), innerRetryStats).Build()
 	fn, reset := testutil.ErrorNTimesThenReturn[bool](testutil.ErrConnecting, 5, true)
 	setup := func() {
 		reset()
@@ -51,8 +51,8 @@ RetryPolicy -> RetryPolicy
 Asserts that attempt counts are as expected when using nested retry policies.
 */
 func TestRetryPolicyRetryPolicyAttempts(t *testing.T) {
-	rp1 := policytesting.WithRetryLogs(retrypolicy.Builder[any]()).Build()
-	rp2 := policytesting.WithRetryLogs(retrypolicy.Builder[any]()).Build()
+	rp1 := retrypolicy.Builder[any]().Build()
+	rp2 := retrypolicy.Builder[any]().Build()
 	testutil.Test[any](t).
 		With(rp2, rp1).
 		Get(testutil.GetFn[any](nil, testutil.ErrInvalidArgument)).
@@ -18,8 +18,8 @@ import (
 func TestTimeoutTimeout(t *testing.T) {
 	innerTimeoutStats := &policytesting.Stats{}
 	outerTimeoutStats := &policytesting.Stats{}
-	innerTimeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](100*time.Millisecond), innerTimeoutStats).Build()
-	outerTimeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](500*time.Millisecond), outerTimeoutStats).Build()
+	innerTimeout := policytesting.WithTimeoutStats(timeout.Builder[any](100*time.Millisecond), innerTimeoutStats).Build()
+	outerTimeout := policytesting.WithTimeoutStats(timeout.Builder[any](500*time.Millisecond), outerTimeoutStats).Build()
 
 	testutil.Test[any](t).
 		With(outerTimeout, innerTimeout).
@@ -40,9 +40,9 @@ func TestTimeoutRetryPolicyTimeout(t *testing.T) {
 	innerTimeoutStats := &policytesting.Stats{}
 	retryStats := &policytesting.Stats{}
 	outerTimeoutStats := &policytesting.Stats{}
-	innerTimeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](100*time.Millisecond), innerTimeoutStats).Build()
-	retryPolicy := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[any]().WithMaxRetries(10), retryStats).Build()
-	outerTimeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](500*time.Millisecond), outerTimeoutStats).Build()
+	innerTimeout := policytesting.WithTimeoutStats(timeout.Builder[any](100*time.Millisecond), innerTimeoutStats).Build()
+	retryPolicy := policytesting.WithRetryStats(retrypolicy.Builder[any]().WithMaxRetries(10), retryStats).Build()
+	outerTimeout := policytesting.WithTimeoutStats(timeout.Builder[any](500*time.Millisecond), outerTimeoutStats).Build()
 
 	testutil.Test[any](t).
 		With(outerTimeout, retryPolicy, innerTimeout).
@@ -63,8 +63,8 @@ func TestTimeoutRetryPolicyTimeout(t *testing.T) {
 func TestFallbackRetryPolicyTimeoutTimeout(t *testing.T) {
 	innerTimeoutStats := &policytesting.Stats{}
 	outerTimeoutStats := &policytesting.Stats{}
-	innerTimeout := policytesting.WithTimeoutStatsAndLogs[bool](timeout.Builder[bool](100*time.Millisecond), innerTimeoutStats).Build()
-	outerTimeout := policytesting.WithTimeoutStatsAndLogs[bool](timeout.Builder[bool](50*time.Millisecond), outerTimeoutStats).Build()
+	innerTimeout := policytesting.WithTimeoutStats[bool](timeout.Builder[bool](100*time.Millisecond), innerTimeoutStats).Build()
+	outerTimeout := policytesting.WithTimeoutStats[bool](timeout.Builder[bool](50*time.Millisecond), outerTimeoutStats).Build()
 	rp := retrypolicy.WithDefaults[bool]()
 	fb := fallback.WithResult(true)
 
@@ -88,9 +88,9 @@ func TestCancelNestedTimeouts(t *testing.T) {
 	retryStats := &policytesting.Stats{}
 	innerTimeoutStats := &policytesting.Stats{}
 	outerTimeoutStats := &policytesting.Stats{}
-	rp := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[any](), retryStats).Build()
-	innerTimeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](time.Second), innerTimeoutStats).Build()
-	outerTimeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](200*time.Millisecond), outerTimeoutStats).Build()
+	rp := policytesting.WithRetryStats(retrypolicy.Builder[any](), retryStats).Build()
+	innerTimeout := policytesting.WithTimeoutStats(timeout.Builder[any](time.Second), innerTimeoutStats).Build()
+	outerTimeout := policytesting.WithTimeoutStats(timeout.Builder[any](200*time.Millisecond), outerTimeoutStats).Build()
 
 	testutil.Test[any](t).
 		With(rp, outerTimeout, innerTimeout).
@@ -50,8 +50,8 @@ func TestRetryPolicyCircuitBreaker(t *testing.T) {
 // Tests RetryPolicy with a CircuitBreaker that is open.
 func TestRetryPolicyCircuitBreakerOpen(t *testing.T) {
 	// Given
-	rp := policytesting.WithRetryLogs(retrypolicy.Builder[any]()).Build()
-	cb := policytesting.WithBreakerLogs(circuitbreaker.Builder[any]()).Build()
+	rp := retrypolicy.Builder[any]().Build()
+	cb := circuitbreaker.Builder[any]().Build()
 	setup := func() {
 		policytesting.ResetCircuitBreaker(cb)
 	}
@@ -170,7 +170,7 @@ func TestFallbackCircuitBreakerOpen(t *testing.T) {
 	// Given
 	fb := fallback.WithFunc(func(exec failsafe.Execution[bool]) (bool, error) {
 		assert.False(t, exec.LastResult())
-		assert.ErrorIs(t, circuitbreaker.ErrOpen, exec.LastError())
+		assert.ErrorIs(t, exec.LastError(), circuitbreaker.ErrOpen)
 		return false, nil
 	})
 	cb := circuitbreaker.Builder[bool]().WithSuccessThreshold(3).Build()
@@ -236,7 +236,7 @@ func TestRetryPolicyTimeout(t *testing.T) {
 		assert.ErrorIs(t, e.LastError(), timeout.ErrExceeded)
 	}).Build()
 	toStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](50*time.Millisecond), toStats).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](50*time.Millisecond), toStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -260,10 +260,10 @@ func TestRetryPolicyTimeout(t *testing.T) {
 func TestRetryPolicyHedgePolicy(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	rp := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[any](), stats).Build()
+	rp := policytesting.WithRetryStats(retrypolicy.Builder[any](), stats).Build()
 
 	t.Run("when hedge runs multiple times", func(t *testing.T) {
-		hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond), stats).Build()
+		hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond), stats).Build()
 
 		testutil.Test[any](t).
 			With(rp, hp).
@@ -279,7 +279,7 @@ func TestRetryPolicyHedgePolicy(t *testing.T) {
 	})
 
 	t.Run("when hedge returns error", func(t *testing.T) {
-		hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[any](time.Second), stats).Build()
+		hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[any](time.Second), stats).Build()
 
 		testutil.Test[any](t).
 			With(rp, hp).
@@ -356,14 +356,14 @@ func TestRetryPolicyBulkhead(t *testing.T) {
 func TestHedgePolicyTimeout(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond).
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond).
 		CancelIf(func(a any, err error) bool {
 			return err == nil
 		}).
 		WithMaxHedges(2), stats).
 		Build()
 	toStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](100*time.Millisecond), toStats).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](100*time.Millisecond), toStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
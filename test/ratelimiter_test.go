@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/circuitbreaker"
 	"github.com/failsafe-go/failsafe-go/internal/testutil"
 	"github.com/failsafe-go/failsafe-go/ratelimiter"
 )
@@ -68,3 +70,70 @@ func TestCancelRateLimiting(t *testing.T) {
 	assert.NoError(t, limiter.AcquirePermit(nil))
 	assert.Error(t, limiter.AcquirePermit(ctx))
 }
+
+// Asserts that executions that would wait beyond the wait threshold are rejected with WaitThresholdExceededError, without
+// being performed, which an outer CircuitBreaker can handle.
+func TestRateLimiterWaitThresholdExceeded(t *testing.T) {
+	// Given
+	limiter := ratelimiter.SmoothBuilderWithMaxRate[string](50 * time.Millisecond).
+		WithMaxWaitTime(time.Second).
+		WithWaitThreshold(10 * time.Millisecond).
+		Build()
+	cb := circuitbreaker.Builder[string]().HandleErrors(ratelimiter.ErrWaitThresholdExceeded).Build()
+
+	limiter.TryAcquirePermit() // limiter should now be out of permits
+
+	// When
+	var called bool
+	result, err := failsafe.Get(func() (string, error) {
+		called = true
+		return "test", nil
+	}, cb, limiter)
+
+	// Then
+	assert.Empty(t, result)
+	assert.False(t, called)
+	assert.ErrorIs(t, err, ratelimiter.ErrWaitThresholdExceeded)
+	assert.NotErrorIs(t, err, ratelimiter.ErrExceeded)
+	assert.True(t, cb.IsOpen())
+}
+
+func TestKeyedRateLimiter(t *testing.T) {
+	// Given
+	type tenantKey struct{}
+	keyFunc := func(ctx context.Context) string {
+		return ctx.Value(tenantKey{}).(string)
+	}
+	limiter := ratelimiter.Keyed[any](keyFunc, ratelimiter.BurstyBuilder[any](1, time.Hour), 2)
+	executor := failsafe.NewExecutor[any](limiter)
+	run := func(tenant string) error {
+		return executor.RunWithContext(context.WithValue(context.Background(), tenantKey{}, tenant), func() error { return nil })
+	}
+
+	// When / Then
+	assert.NoError(t, run("a"))
+	assert.ErrorIs(t, run("a"), ratelimiter.ErrExceeded)
+	assert.NoError(t, run("b"))
+	assert.ErrorIs(t, run("b"), ratelimiter.ErrExceeded)
+	assert.Equal(t, 2, limiter.Keys())
+	assert.False(t, limiter.Get("a").TryAcquirePermit())
+
+	// Evicts b, which is least recently used
+	assert.NoError(t, run("c"))
+	assert.Equal(t, 2, limiter.Keys())
+	assert.NoError(t, run("b"))
+}
+
+// Asserts that a RateLimiter configured with a FakeClock only permits executions as the clock is advanced.
+func TestRateLimiterWithFakeClock(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	limiter := ratelimiter.SmoothBuilderWithMaxRate[any](time.Second).WithClock(clock).Build()
+
+	// When / Then
+	assert.True(t, limiter.TryAcquirePermit())
+	assert.False(t, limiter.TryAcquirePermit())
+	clock.Advance(time.Second)
+	assert.True(t, limiter.TryAcquirePermit())
+	assert.False(t, limiter.TryAcquirePermit())
+}
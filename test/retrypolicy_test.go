@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -25,6 +26,21 @@ func TestShouldRetryOnFailure(t *testing.T) {
 		AssertFailure(3, 3, testutil.ErrConnecting)
 }
 
+// Tests that executions marked as not idempotent are not retried.
+func TestShouldNotRetryNonIdempotentExecution(t *testing.T) {
+	// Given
+	rp := retrypolicy.WithDefaults[bool]()
+
+	// When / Then
+	testutil.Test[bool](t).
+		With(rp).
+		Context(func() context.Context {
+			return failsafe.MarkIdempotent(context.Background(), false)
+		}).
+		Get(testutil.GetFn(false, testutil.ErrConnecting)).
+		AssertFailure(1, 1, testutil.ErrConnecting)
+}
+
 func TestShouldReturnRetriesExceededError(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
@@ -167,3 +183,118 @@ func TestBackoffDelay(t *testing.T) {
 	expected := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 8 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}
 	assert.ElementsMatch(t, expected, delays)
 }
+
+// Asserts that a retry that would be delayed past the context deadline is skipped.
+func TestShouldSkipRetryWhenDelayExceedsDeadline(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[bool]().
+		WithDelay(time.Second).
+		WithDeadlineAwareness(retrypolicy.SkipRetry).
+		Build()
+
+	// When / Then
+	elapsed := testutil.Timed(func() {
+		testutil.Test[bool](t).
+			With(rp).
+			Context(testutil.SetupWithContextTimeout(100*time.Millisecond)).
+			Get(testutil.GetFn(false, testutil.ErrConnecting)).
+			AssertFailure(1, 1, context.DeadlineExceeded)
+	})
+	assert.Less(t, elapsed, 100*time.Millisecond)
+}
+
+// Asserts that a retry delay that would extend past the context deadline is truncated.
+func TestShouldTruncateDelayWhenDelayExceedsDeadline(t *testing.T) {
+	// Given
+	rp := retrypolicy.Builder[bool]().
+		WithDelay(time.Second).
+		WithDeadlineAwareness(retrypolicy.TruncateDelay).
+		Build()
+
+	// When / Then
+	elapsed := testutil.Timed(func() {
+		testutil.Test[bool](t).
+			With(rp).
+			Context(testutil.SetupWithContextTimeout(100*time.Millisecond)).
+			Get(testutil.GetFn(false, testutil.ErrConnecting)).
+			AssertFailureAs(3, 3, &retrypolicy.ExceededError{})
+	})
+	assert.Less(t, elapsed, 100*time.Millisecond)
+}
+
+// Asserts that a RetryPolicy configured with a FakeClock delays retries until the clock is advanced.
+func TestRetryPolicyWithFakeClock(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	rp := retrypolicy.Builder[bool]().WithDelay(time.Hour).WithClock(clock).Build()
+	stub, _ := testutil.ErrorNTimesThenReturn[bool](testutil.ErrConnecting, 2, true)
+
+	// When
+	result := failsafe.NewExecutor[bool](rp).GetWithExecutionAsync(stub)
+	for i := 0; i < 2; i++ {
+		waitForTimers(clock, 1)
+		clock.Advance(time.Hour)
+	}
+
+	// Then
+	r, err := result.Get()
+	assert.True(t, r)
+	assert.NoError(t, err)
+}
+
+// Asserts that an OnBeforeRetry hook can pass state to retry attempts.
+func TestOnBeforeRetry(t *testing.T) {
+	// Given
+	type endpointKey struct{}
+	var hookErrs []error
+	rp := retrypolicy.Builder[string]().
+		OnBeforeRetry(func(exec failsafe.ExecutionAttempt[string]) error {
+			hookErrs = append(hookErrs, exec.LastError())
+			exec.SetValue(endpointKey{}, exec.Attempts())
+			return nil
+		}).
+		Build()
+
+	// When
+	var endpoints []any
+	result, err := failsafe.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		endpoints = append(endpoints, exec.Value(endpointKey{}))
+		if exec.Attempts() < 3 {
+			return "", testutil.ErrConnecting
+		}
+		return "success", nil
+	}, rp)
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "success", result)
+	assert.Equal(t, []any{nil, 2, 3}, endpoints)
+	assert.Equal(t, []error{testutil.ErrConnecting, testutil.ErrConnecting}, hookErrs)
+}
+
+// Asserts that retries are aborted when an OnBeforeRetry hook fails.
+func TestOnBeforeRetryAborts(t *testing.T) {
+	// Given
+	hookErr := errors.New("credentials unavailable")
+	var abortErr error
+	rp := retrypolicy.Builder[string]().
+		OnBeforeRetry(func(exec failsafe.ExecutionAttempt[string]) error {
+			return hookErr
+		}).
+		OnAbort(func(e failsafe.ExecutionEvent[string]) {
+			abortErr = e.LastError()
+		}).
+		Build()
+
+	// When
+	attempts := 0
+	_, err := failsafe.Get(func() (string, error) {
+		attempts++
+		return "", testutil.ErrConnecting
+	}, rp)
+
+	// Then
+	assert.ErrorIs(t, err, hookErr)
+	assert.ErrorIs(t, abortErr, hookErr)
+	assert.Equal(t, 1, attempts)
+}
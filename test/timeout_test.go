@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -33,8 +34,8 @@ func TestRetryTimeoutWithBlockedFunc(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
 	rpStats := &policytesting.Stats{}
-	timeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](50*time.Millisecond), timeoutStats).Build()
-	rp := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[any](), rpStats).Build()
+	timeout := policytesting.WithTimeoutStats(timeout.Builder[any](50*time.Millisecond), timeoutStats).Build()
+	rp := policytesting.WithRetryStats(retrypolicy.Builder[any](), rpStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -60,8 +61,8 @@ func TestRetryTimeoutWithPendingRetry(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
 	rpStats := &policytesting.Stats{}
-	timeout := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](50*time.Millisecond), timeoutStats).Build()
-	rp := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[any]().WithDelay(100*time.Millisecond), rpStats).Build()
+	timeout := policytesting.WithTimeoutStats(timeout.Builder[any](50*time.Millisecond), timeoutStats).Build()
+	rp := policytesting.WithRetryStats(retrypolicy.Builder[any]().WithDelay(100*time.Millisecond), rpStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -81,7 +82,7 @@ func TestRetryTimeoutWithPendingRetry(t *testing.T) {
 func TestTimeoutRetryWithBlockedFunc(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](150*time.Millisecond), timeoutStats).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](150*time.Millisecond), timeoutStats).Build()
 	rp := retrypolicy.WithDefaults[any]()
 
 	// When / Then
@@ -105,8 +106,8 @@ func TestTimeoutRetryWithPendingRetry(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
 	rpStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](100*time.Millisecond), timeoutStats).Build()
-	rp := policytesting.WithRetryStatsAndLogs(retrypolicy.Builder[any]().WithDelay(time.Second), rpStats).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](100*time.Millisecond), timeoutStats).Build()
+	rp := policytesting.WithRetryStats(retrypolicy.Builder[any]().WithDelay(time.Second), rpStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -125,8 +126,8 @@ func TestTimeoutRetryWithPendingRetry(t *testing.T) {
 func TestTimeoutHedgeWithBlockedFunc(t *testing.T) {
 	// Given
 	stats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](100*time.Millisecond), stats).Build()
-	hp := policytesting.WithHedgeStatsAndLogs(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond), stats).WithMaxHedges(2).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](100*time.Millisecond), stats).Build()
+	hp := policytesting.WithHedgeStats(hedgepolicy.BuilderWithDelay[any](10*time.Millisecond), stats).WithMaxHedges(2).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -147,8 +148,8 @@ func TestFallbackTimeoutWithBlockedFunc(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
 	fbStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](10*time.Millisecond), timeoutStats).Build()
-	fb := policytesting.WithFallbackStatsAndLogs(fallback.BuilderWithError[any](testutil.ErrInvalidArgument), fbStats).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](10*time.Millisecond), timeoutStats).Build()
+	fb := policytesting.WithFallbackStats(fallback.BuilderWithError[any](testutil.ErrInvalidArgument), fbStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -170,8 +171,8 @@ func TestFallbackWithInnerTimeout(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
 	fbStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](10*time.Millisecond), timeoutStats).Build()
-	fb := policytesting.WithFallbackStatsAndLogs(fallback.BuilderWithError[any](testutil.ErrInvalidArgument), fbStats).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](10*time.Millisecond), timeoutStats).Build()
+	fb := policytesting.WithFallbackStats(fallback.BuilderWithError[any](testutil.ErrInvalidArgument), fbStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -189,8 +190,8 @@ func TestTimeoutFallbackWithBlockedFunc(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
 	fbStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](10*time.Millisecond), timeoutStats).Build()
-	fb := policytesting.WithFallbackStatsAndLogs(fallback.BuilderWithError[any](testutil.ErrInvalidArgument), fbStats).Build()
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](10*time.Millisecond), timeoutStats).Build()
+	fb := policytesting.WithFallbackStats(fallback.BuilderWithError[any](testutil.ErrInvalidArgument), fbStats).Build()
 
 	// When / Then
 	testutil.Test[any](t).
@@ -211,8 +212,8 @@ func TestTimeoutFallbackWithBlockedFallback(t *testing.T) {
 	// Given
 	timeoutStats := &policytesting.Stats{}
 	fbStats := &policytesting.Stats{}
-	to := policytesting.WithTimeoutStatsAndLogs(timeout.Builder[any](100*time.Millisecond), timeoutStats).Build()
-	fb := policytesting.WithFallbackStatsAndLogs(fallback.BuilderWithFunc[any](func(_ failsafe.Execution[any]) (any, error) {
+	to := policytesting.WithTimeoutStats(timeout.Builder[any](100*time.Millisecond), timeoutStats).Build()
+	fb := policytesting.WithFallbackStats(fallback.BuilderWithFunc[any](func(_ failsafe.Execution[any]) (any, error) {
 		time.Sleep(200 * time.Millisecond)
 		return nil, testutil.ErrInvalidState
 	}), fbStats).Build()
@@ -227,3 +228,236 @@ func TestTimeoutFallbackWithBlockedFallback(t *testing.T) {
 			assert.Equal(t, 0, fbStats.Executions())
 		})
 }
+
+// Tests that a partial result returned during a grace period is returned along with ErrExceeded.
+func TestTimeoutWithGracePeriod(t *testing.T) {
+	// Given
+	to := timeout.Builder[string](10 * time.Millisecond).WithGracePeriod(time.Second).Build()
+	executor := failsafe.NewExecutor[string](to)
+
+	// When
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		<-exec.Canceled()
+		return "partial", exec.Context().Err()
+	})
+
+	// Then
+	assert.Equal(t, "partial", result)
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+}
+
+// Tests that a result returned after a grace period is not used.
+func TestTimeoutWithExceededGracePeriod(t *testing.T) {
+	// Given
+	to := timeout.Builder[string](10 * time.Millisecond).WithGracePeriod(10 * time.Millisecond).Build()
+	executor := failsafe.NewExecutor[string](to)
+
+	// When
+	result, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		<-exec.Canceled()
+		time.Sleep(50 * time.Millisecond)
+		return "partial", nil
+	})
+
+	// Then
+	assert.Empty(t, result)
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+}
+
+// Tests that a Timeout's deadline is visible via the execution's context.
+func TestTimeoutSetsContextDeadline(t *testing.T) {
+	t.Run("with timeout deadline", func(t *testing.T) {
+		// Given
+		executor := failsafe.NewExecutor[any](timeout.With[any](time.Second))
+
+		// When
+		var deadline time.Time
+		var hasDeadline bool
+		_, err := executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+			deadline, hasDeadline = exec.Context().Deadline()
+			return nil, nil
+		})
+
+		// Then
+		assert.NoError(t, err)
+		assert.True(t, hasDeadline)
+		assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+	})
+
+	t.Run("with earlier parent deadline", func(t *testing.T) {
+		// Given
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		parentDeadline, _ := ctx.Deadline()
+		executor := failsafe.NewExecutor[any](timeout.With[any](time.Minute)).WithContext(ctx)
+
+		// When
+		var deadline time.Time
+		_, _ = executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+			deadline, _ = exec.Context().Deadline()
+			return nil, nil
+		})
+
+		// Then
+		assert.Equal(t, parentDeadline, deadline)
+	})
+
+	t.Run("should fail with ErrExceeded at the deadline", func(t *testing.T) {
+		// Given
+		executor := failsafe.NewExecutor[any](timeout.With[any](10 * time.Millisecond))
+
+		// When
+		_, err := executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+			<-exec.Context().Done()
+			return nil, exec.Context().Err()
+		})
+
+		// Then
+		assert.ErrorIs(t, err, timeout.ErrExceeded)
+	})
+}
+
+// Asserts that a Timeout configured with a FakeClock is exceeded when the clock is advanced past the time limit.
+func TestTimeoutWithFakeClock(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	to := timeout.Builder[any](time.Hour).WithClock(clock).Build()
+	var deadline time.Time
+
+	// When
+	result := failsafe.NewExecutor[any](to).GetWithExecutionAsync(func(exec failsafe.Execution[any]) (any, error) {
+		deadline, _ = exec.Context().Deadline()
+		<-exec.Canceled()
+		return nil, nil
+	})
+	waitForTimers(clock, 1)
+	clock.Advance(time.Hour)
+
+	// Then
+	_, err := result.Get()
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	assert.Equal(t, clock.Now(), deadline)
+}
+
+// Asserts that an ExceededError describes the time limit, elapsed time, and attempt of the execution that exceeded it.
+func TestTimeoutExceededError(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	var listenerErr error
+	to := timeout.Builder[any](time.Second).
+		WithClock(clock).
+		WithGracePeriod(time.Second).
+		OnTimeoutExceeded(func(e failsafe.ExecutionDoneEvent[any]) {
+			listenerErr = e.Error
+		}).
+		Build()
+
+	// When
+	_, err := failsafe.NewExecutor[any](to).GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+		clock.Advance(time.Second)
+		clock.Advance(500 * time.Millisecond)
+		return nil, nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	var exceededErr timeout.ExceededError
+	assert.ErrorAs(t, err, &exceededErr)
+	assert.Equal(t, timeout.ExceededError{Timeout: to, TimeLimit: time.Second, Elapsed: 1500 * time.Millisecond, Attempt: 1}, exceededErr)
+	assert.Equal(t, timeout.ExceededError{Timeout: to, TimeLimit: time.Second, Elapsed: time.Second, Attempt: 1}, listenerErr)
+}
+
+// Asserts that an adaptive Timeout computes its time limit from the latencies of recent successful executions.
+func TestAdaptiveTimeout(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	to := timeout.NewAdaptiveBuilder[any](0.5, 2, 50*time.Millisecond, time.Second).WithClock(clock).Build()
+	executor := failsafe.NewExecutor[any](to)
+	runFor := func(latency time.Duration, err error) (time.Duration, error) {
+		var timeLimit time.Duration
+		_, execErr := executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+			deadline, _ := exec.Context().Deadline()
+			timeLimit = deadline.Sub(clock.Now())
+			clock.Advance(latency)
+			return nil, err
+		})
+		return timeLimit, execErr
+	}
+
+	// When / Then the maxTimeout is used before any latencies are recorded
+	timeLimit, err := runFor(100*time.Millisecond, nil)
+	assert.Equal(t, time.Second, timeLimit)
+	assert.NoError(t, err)
+
+	// When / Then the time limit is computed from recorded latencies
+	timeLimit, err = runFor(100*time.Millisecond, nil)
+	assert.Equal(t, 200*time.Millisecond, timeLimit)
+	assert.NoError(t, err)
+
+	// When / Then failures are not recorded, and timeouts are recorded as the time limit
+	_, err = runFor(400*time.Millisecond, testutil.ErrInvalidState)
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	_, err = runFor(150*time.Millisecond, testutil.ErrInvalidState)
+	assert.ErrorIs(t, err, testutil.ErrInvalidState)
+	timeLimit, err = runFor(10*time.Millisecond, nil)
+	assert.Equal(t, 200*time.Millisecond, timeLimit)
+	assert.NoError(t, err)
+
+	// When / Then the time limit is bounded by the minTimeout
+	for i := 0; i < 3; i++ {
+		runFor(time.Millisecond, nil)
+	}
+	timeLimit, _ = runFor(time.Millisecond, nil)
+	assert.Equal(t, 50*time.Millisecond, timeLimit)
+}
+
+// Asserts that an adaptive Timeout raises its time limit towards the maxTimeout when latencies increase beyond it.
+func TestAdaptiveTimeoutWithIncreasedLatency(t *testing.T) {
+	// Given
+	clock := failsafe.NewFakeClock(time.Now())
+	to := timeout.NewAdaptiveBuilder[any](0.5, 2, 50*time.Millisecond, time.Second).WithClock(clock).Build()
+	executor := failsafe.NewExecutor[any](to)
+	runFor := func(latency time.Duration) (time.Duration, error) {
+		var timeLimit time.Duration
+		_, err := executor.GetWithExecution(func(exec failsafe.Execution[any]) (any, error) {
+			deadline, _ := exec.Context().Deadline()
+			timeLimit = deadline.Sub(clock.Now())
+			clock.Advance(latency)
+			return nil, nil
+		})
+		return timeLimit, err
+	}
+	runFor(100 * time.Millisecond)
+	runFor(100 * time.Millisecond)
+
+	// When
+	var timeLimit time.Duration
+	var err error
+	for i := 0; i < 50; i++ {
+		timeLimit, err = runFor(10 * time.Second)
+	}
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	assert.Equal(t, time.Second, timeLimit)
+}
+
+// Asserts that invalid adaptive Timeout configuration panics.
+func TestAdaptiveTimeoutShouldPanicWithInvalidConfig(t *testing.T) {
+	assert.Panics(t, func() {
+		timeout.NewAdaptiveBuilder[any](0, 2, time.Millisecond, time.Second)
+	})
+	assert.Panics(t, func() {
+		timeout.NewAdaptiveBuilder[any](1.5, 2, time.Millisecond, time.Second)
+	})
+	assert.Panics(t, func() {
+		timeout.NewAdaptiveBuilder[any](0.5, 0, time.Millisecond, time.Second)
+	})
+}
+
+// waitForTimers waits for the clock to have the expected number of pending timers, such as for a policy to begin a delay.
+func waitForTimers(clock *failsafe.FakeClock, expected int) {
+	for clock.PendingTimers() != expected {
+		time.Sleep(time.Millisecond)
+	}
+}
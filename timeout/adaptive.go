@@ -0,0 +1,100 @@
+package timeout
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// adaptiveWindowSize is the number of recent successful and timed out execution latencies that an adaptive Timeout
+// computes its time limit from.
+const adaptiveWindowSize = 100
+
+// NewAdaptiveBuilder returns a TimeoutBuilder for execution result type R which builds adaptive Timeouts. Rather than
+// using a fixed time limit, an adaptive Timeout computes its time limit for each execution from the latencies of recent
+// successful executions, as the latency at the quantile multiplied by the multiplier. For example, a quantile of 0.99
+// and a multiplier of 2 times out executions that take more than twice the recent p99 latency. The time limit is kept
+// between the minTimeout and maxTimeout, and is the maxTimeout until a successful execution has been recorded.
+//
+// Latencies are recorded for the most recent 100 successful and timed out executions. Executions that exceed the timeout
+// are recorded with the time limit as their latency, so that the time limit rises towards the maxTimeout when latencies
+// increase beyond it. Executions that otherwise fail are not recorded, so that slow failures do not raise the time
+// limit.
+//
+// Panics if quantile is not > 0 and <= 1, or if multiplier is not > 0.
+func NewAdaptiveBuilder[R any](quantile float64, multiplier float64, minTimeout time.Duration, maxTimeout time.Duration) TimeoutBuilder[R] {
+	if quantile <= 0 || quantile > 1 {
+		panic("quantile must be > 0 and <= 1")
+	}
+	if multiplier <= 0 {
+		panic("multiplier must be > 0")
+	}
+	return &config[R]{
+		timeLimit: maxTimeout,
+		clock:     failsafe.SystemClock(),
+		adaptive: &adaptiveConfig{
+			quantile:   quantile,
+			multiplier: multiplier,
+			minTimeout: minTimeout,
+			maxTimeout: maxTimeout,
+		},
+	}
+}
+
+type adaptiveConfig struct {
+	quantile   float64
+	multiplier float64
+	minTimeout time.Duration
+	maxTimeout time.Duration
+}
+
+// latencyWindow holds the most recent successful and timed out execution latencies for an adaptive Timeout.
+//
+// This type is concurrency safe.
+type latencyWindow struct {
+	*adaptiveConfig
+
+	mtx sync.Mutex
+	// Guarded by mtx. A ring buffer of latencies.
+	latencies []time.Duration
+	// Guarded by mtx. The index of the next latency to overwrite once the latencies are full.
+	next int
+}
+
+func newLatencyWindow(config *adaptiveConfig) *latencyWindow {
+	return &latencyWindow{
+		adaptiveConfig: config,
+		latencies:      make([]time.Duration, 0, adaptiveWindowSize),
+	}
+}
+
+func (w *latencyWindow) record(latency time.Duration) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if len(w.latencies) < cap(w.latencies) {
+		w.latencies = append(w.latencies, latency)
+		return
+	}
+	w.latencies[w.next] = latency
+	w.next = (w.next + 1) % len(w.latencies)
+}
+
+// timeLimit returns the latency at the quantile multiplied by the multiplier, bounded by the minTimeout and maxTimeout.
+func (w *latencyWindow) timeLimit() time.Duration {
+	w.mtx.Lock()
+	if len(w.latencies) == 0 {
+		w.mtx.Unlock()
+		return w.maxTimeout
+	}
+	sorted := slices.Clone(w.latencies)
+	w.mtx.Unlock()
+
+	slices.Sort(sorted)
+	index := int(math.Ceil(w.quantile*float64(len(sorted)))) - 1
+	index = min(max(index, 0), len(sorted)-1)
+	limit := time.Duration(float64(sorted[index]) * w.multiplier)
+	return min(max(limit, w.minTimeout), w.maxTimeout)
+}
@@ -2,18 +2,50 @@ package timeout
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/internal"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
-// ErrExceeded is returned when an execution exceeds a configured timeout.
+// ErrExceeded is a convenience error sentinel that can be used to build policies that handle ExceededError, such as via
+// HandleErrors(timeout.ErrExceeded). It can also be used with Errors.Is to determine whether an error is a
+// timeout.ExceededError.
 var ErrExceeded = errors.New("timeout exceeded")
 
+// ExceededError is returned when an execution exceeds a configured timeout. This type can be used with
+// HandleErrorTypes(timeout.ExceededError{}), or with errors.As to distinguish an execution that barely exceeded its
+// timeout from one that did not return for much longer, or to determine which of several Timeouts was exceeded.
+type ExceededError struct {
+	// Timeout is the Timeout that was exceeded, which can be compared with a Timeout instance.
+	Timeout any
+	// Name is the name of the Timeout, else an empty string if it's not named.
+	Name string
+	// TimeLimit is the time limit that was exceeded.
+	TimeLimit time.Duration
+	// Elapsed is the time from when the timeout started until it failed the execution, which includes any grace period.
+	Elapsed time.Duration
+	// Attempt is the number of execution attempts, including the attempt that exceeded the timeout.
+	Attempt int
+}
+
+func (e ExceededError) Error() string {
+	return fmt.Sprintf("timeout exceeded. %stime limit: %v, elapsed: %v, attempt: %d", internal.ErrorName(e.Name), e.TimeLimit, e.Elapsed, e.Attempt)
+}
+
+func (e ExceededError) Is(err error) bool {
+	if err == ErrExceeded {
+		return true
+	}
+	return err == e
+}
+
 // Timeout is a Policy that cancels executions if they exceed a time limit. Any policies composed inside the timeout,
 // such as retries, will also be canceled. If the execution is configured with a Context, a child context will be created
-// for the execution and canceled when the Timeout is exceeded.
+// for the execution and canceled when the Timeout is exceeded. The child context carries a deadline for when the Timeout
+// will be exceeded, so that clients such as HTTP, gRPC, and SQL clients can see the remaining time.
 //
 // R is the execution result type. This type is concurrency safe.
 type Timeout[R any] interface {
@@ -24,7 +56,20 @@ type Timeout[R any] interface {
 //
 // R is the execution result type. This type is not concurrency safe.
 type TimeoutBuilder[R any] interface {
-	// OnTimeoutExceeded registers the listener to be called when the timeout is exceeded.
+	failsafe.NamedPolicyBuilder[TimeoutBuilder[R]]
+
+	// WithGracePeriod configures a grace period that an execution has to return after the timeout is exceeded. When the
+	// timeout is exceeded, the execution's Context is canceled, but ErrExceeded is not returned until the grace period
+	// elapses. If the execution returns during the grace period, its result is returned along with ErrExceeded, allowing
+	// an execution to return a partial result when it's canceled.
+	WithGracePeriod(gracePeriod time.Duration) TimeoutBuilder[R]
+
+	// WithClock configures the clock that is used to time executions and set context deadlines, such as a
+	// failsafe.FakeClock when testing. By default, failsafe.SystemClock is used.
+	WithClock(clock failsafe.TimerClock) TimeoutBuilder[R]
+
+	// OnTimeoutExceeded registers the listener to be called when the timeout is exceeded. The event's Error is an
+	// ExceededError, whose Elapsed time is the time limit, since the listener is called when the timeout is exceeded.
 	OnTimeoutExceeded(listener func(event failsafe.ExecutionDoneEvent[R])) TimeoutBuilder[R]
 
 	// Build returns a new Timeout using the builder's configuration.
@@ -32,14 +77,22 @@ type TimeoutBuilder[R any] interface {
 }
 
 type config[R any] struct {
+	name              string
 	timeLimit         time.Duration
+	gracePeriod       time.Duration
+	clock             failsafe.TimerClock
 	onTimeoutExceeded func(failsafe.ExecutionDoneEvent[R])
+
+	// Adaptive config, which is nil unless the timeout is adaptive
+	adaptive *adaptiveConfig
 }
 
 var _ TimeoutBuilder[any] = &config[any]{}
 
 type timeout[R any] struct {
 	*config[R]
+	// Recent latencies, which are nil unless the timeout is adaptive
+	latencies *latencyWindow
 }
 
 // With returns a new Timeout for execution result type R and the timeLimit. The Timeout will cancel executions if they
@@ -57,19 +110,84 @@ func With[R any](timeLimit time.Duration) Timeout[R] {
 func Builder[R any](timeLimit time.Duration) TimeoutBuilder[R] {
 	return &config[R]{
 		timeLimit: timeLimit,
+		clock:     failsafe.SystemClock(),
 	}
 }
 
+func (c *config[R]) WithGracePeriod(gracePeriod time.Duration) TimeoutBuilder[R] {
+	c.gracePeriod = gracePeriod
+	return c
+}
+
+func (c *config[R]) WithClock(clock failsafe.TimerClock) TimeoutBuilder[R] {
+	c.clock = clock
+	return c
+}
+
 func (c *config[R]) OnTimeoutExceeded(listener func(event failsafe.ExecutionDoneEvent[R])) TimeoutBuilder[R] {
 	c.onTimeoutExceeded = listener
 	return c
 }
 
+func (c *config[R]) WithName(name string) TimeoutBuilder[R] {
+	c.name = name
+	return c
+}
+
 func (c *config[R]) Build() Timeout[R] {
 	fbCopy := *c
-	return &timeout[R]{
+	t := &timeout[R]{
 		config: &fbCopy, // TODO copy base fields
 	}
+	if c.adaptive != nil {
+		t.latencies = newLatencyWindow(c.adaptive)
+	}
+	return t
+}
+
+func (t *timeout[R]) PolicyKind() failsafe.PolicyKind {
+	return failsafe.TimeoutKind
+}
+
+func (t *timeout[R]) PolicyName() string {
+	return t.name
+}
+
+func (t *timeout[R]) PolicyConfig() map[string]any {
+	config := map[string]any{
+		"timeLimit":   t.timeLimit,
+		"gracePeriod": t.gracePeriod,
+	}
+	if t.adaptive != nil {
+		config["quantile"] = t.adaptive.quantile
+		config["multiplier"] = t.adaptive.multiplier
+		config["minTimeout"] = t.adaptive.minTimeout
+		config["maxTimeout"] = t.adaptive.maxTimeout
+	}
+	return config
+}
+
+// currentTimeLimit returns the time limit for an execution, which is computed from recent latencies for adaptive
+// timeouts.
+func (t *timeout[R]) currentTimeLimit() time.Duration {
+	if t.latencies == nil {
+		return t.timeLimit
+	}
+	return t.latencies.timeLimit()
+}
+
+// recordLatency records the latency of a successful execution for adaptive timeouts.
+func (t *timeout[R]) recordLatency(start time.Time, err error) {
+	if t.latencies != nil && err == nil {
+		t.latencies.record(t.clock.Now().Sub(start))
+	}
+}
+
+// recordExceeded records the timeLimit as the latency of an execution that exceeded it for adaptive timeouts.
+func (t *timeout[R]) recordExceeded(timeLimit time.Duration) {
+	if t.latencies != nil {
+		t.latencies.record(timeLimit)
+	}
 }
 
 func (t *timeout[R]) ToExecutor(_ R) any {
@@ -8,6 +8,7 @@ import (
 	"github.com/failsafe-go/failsafe-go"
 	"github.com/failsafe-go/failsafe-go/common"
 	"github.com/failsafe-go/failsafe-go/internal"
+	"github.com/failsafe-go/failsafe-go/internal/timelimit"
 	"github.com/failsafe-go/failsafe-go/policy"
 )
 
@@ -19,36 +20,115 @@ type executor[R any] struct {
 
 var _ policy.Executor[any] = &executor[any]{}
 
+// States for executions with a grace period.
+const (
+	stateRunning int32 = iota
+	stateGracePeriod
+	stateDone
+)
+
 func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	if e.gracePeriod > 0 {
+		return e.applyWithGracePeriod(innerFn)
+	}
+
 	// This func sets up a race between a timeout and the innerFn returning
 	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
-		execInternal := exec.(policy.ExecutionInternal[R])
-
-		// Create child context
-		execInternal = execInternal.CopyForCancellable().(policy.ExecutionInternal[R])
-		var result atomic.Pointer[common.PolicyResult[R]]
-		timer := time.AfterFunc(e.timeLimit, func() {
-			timeoutResult := internal.FailureResult[R](ErrExceeded)
-			if result.CompareAndSwap(nil, timeoutResult) {
+		timeLimit := e.currentTimeLimit()
+		start := e.clock.Now()
+		execInternal, result, completed := timelimit.Apply(exec.(policy.ExecutionInternal[R]), e.clock, start.Add(timeLimit), timeLimit, innerFn,
+			func(exec policy.ExecutionInternal[R]) *common.PolicyResult[R] {
+				return internal.FailureResult[R](e.exceededError(exec, start, timeLimit))
+			},
+			func(exec policy.ExecutionInternal[R], result *common.PolicyResult[R]) {
+				e.recordExceeded(timeLimit)
+				if logger := internal.NamedLogger(exec.Logger(), e.name); logger != nil {
+					logger.Debug("timeout exceeded", "policy", failsafe.TimeoutKind, "attempts", exec.Attempts(), "timeLimit", timeLimit)
+				}
 				if e.onTimeoutExceeded != nil {
 					e.onTimeoutExceeded(failsafe.ExecutionDoneEvent[R]{
-						ExecutionInfo: execInternal,
-						Error:         ErrExceeded,
+						ExecutionInfo: exec,
+						Error:         result.Error,
 					})
 				}
+			})
+		if completed {
+			e.recordLatency(start, result.Error)
+		}
+		return e.PostExecute(execInternal, result)
+	}
+}
 
-				// Sets the timeoutResult, overwriting any previously set result for the execution. This is correct, because while an
-				// execution may have completed, inner policies such as fallbacks may still be processing that result, in which case
-				// it's still important to interrupt them with a timeout.
-				execInternal.Cancel(timeoutResult)
+// applyWithGracePeriod returns a func that cancels the execution's context when the timeout is exceeded, then waits up
+// to the gracePeriod for the innerFn to return a result before using a timeout result.
+func (e *executor[R]) applyWithGracePeriod(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		execInternal := exec.(policy.ExecutionInternal[R])
+
+		// Create child context with a deadline, so that clients can see the remaining time
+		timeLimit := e.currentTimeLimit()
+		start := e.clock.Now()
+		execInternal = execInternal.CopyForDeadline(start.Add(timeLimit)).(policy.ExecutionInternal[R])
+		releaseContext := execInternal.TrackResource(failsafe.ContextResource)
+		var state atomic.Int32
+		var graceTimer atomic.Value // Stores a failsafe.Timer
+		releaseTimer := execInternal.TrackResource(failsafe.TimerResource)
+		timer := e.clock.AfterFunc(timeLimit, func() {
+			defer releaseTimer()
+			if !state.CompareAndSwap(stateRunning, stateGracePeriod) {
+				return
+			}
+			e.recordExceeded(timeLimit)
+			if logger := internal.NamedLogger(execInternal.Logger(), e.name); logger != nil {
+				logger.Debug("timeout exceeded",
+					"policy", failsafe.TimeoutKind,
+					"attempts", execInternal.Attempts(),
+					"timeLimit", timeLimit,
+					"gracePeriod", e.gracePeriod)
 			}
+			if e.onTimeoutExceeded != nil {
+				e.onTimeoutExceeded(failsafe.ExecutionDoneEvent[R]{
+					ExecutionInfo: execInternal,
+					Error:         e.exceededError(execInternal, start, timeLimit),
+				})
+			}
+
+			// Cancel the execution's context without a result, so that the innerFn can return a partial result
+			execInternal.Cancel(nil)
+			graceTimer.Store(e.clock.AfterFunc(e.gracePeriod, func() {
+				state.CompareAndSwap(stateGracePeriod, stateDone)
+			}))
 		})
 
-		// Store result and ctxCancel timeout context if needed
-		if result.CompareAndSwap(nil, innerFn(execInternal)) {
-			timer.Stop()
+		result := innerFn(execInternal)
+		releaseContext()
+		if state.CompareAndSwap(stateRunning, stateDone) {
+			e.recordLatency(start, result.Error)
+			if timer.Stop() {
+				releaseTimer()
+			}
+		} else if state.CompareAndSwap(stateGracePeriod, stateDone) {
+			// The innerFn returned during the grace period
+			if t, ok := graceTimer.Load().(failsafe.Timer); ok {
+				t.Stop()
+			}
+			result = &common.PolicyResult[R]{Result: result.Result, Error: e.exceededError(execInternal, start, timeLimit), Done: true}
+		} else {
+			result = internal.FailureResult[R](e.exceededError(execInternal, start, timeLimit))
 		}
-		return e.PostExecute(execInternal, result.Load())
+		return e.PostExecute(execInternal, result)
+	}
+}
+
+// exceededError returns an ExceededError for the execution's current attempt, which started at the start and exceeded the
+// timeLimit.
+func (e *executor[R]) exceededError(exec failsafe.ExecutionAttempt[R], start time.Time, timeLimit time.Duration) ExceededError {
+	return ExceededError{
+		Timeout:   e.timeout,
+		Name:      e.name,
+		TimeLimit: timeLimit,
+		Elapsed:   e.clock.Now().Sub(start),
+		Attempt:   exec.Attempts(),
 	}
 }
 
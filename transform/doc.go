@@ -0,0 +1,3 @@
+// Package transform provides Transformers, which transform and validate execution results, and a Policy that applies
+// them within a composition of policies.
+package transform
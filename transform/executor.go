@@ -0,0 +1,76 @@
+package transform
+
+import (
+	"context"
+
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// StagedExecutor performs executions in two stages, with different result types: an inner Executor handles the results
+// of type R that a func returns, which are then transformed into results of type T that an outer Executor handles. This
+// allows policies to operate on the result type that suits them, such as retries and circuit breakers that handle raw
+// responses, and caches and fallbacks that handle decoded domain objects:
+//
+//	inner := failsafe.NewExecutor[*http.Response](retryPolicy, circuitBreaker)
+//	outer := failsafe.NewExecutor[*User](fallback, cache)
+//	executor := transform.NewStagedExecutor(outer, transform.New(decodeUser), inner)
+//	user, err := executor.Get(fetchUser)
+//
+// Transformation errors are handled by the outer Executor. Cancellations from the outer Executor, such as timeouts, are
+// propagated to the inner Executor via the execution context.
+//
+// R is the inner result type and T is the outer result type. This type is concurrency safe.
+type StagedExecutor[R any, T any] interface {
+	// Get executes the fn via the inner Executor, transforms its result, and handles the transformed result via the outer
+	// Executor, until successful or until the policies are exceeded.
+	Get(fn func() (R, error)) (T, error)
+
+	// GetWithContext executes the fn like Get, using the ctx for the outer execution.
+	GetWithContext(ctx context.Context, fn func() (R, error)) (T, error)
+
+	// GetWithExecution executes the fn like Get, while providing the inner execution to the fn.
+	GetWithExecution(fn func(exec failsafe.Execution[R]) (R, error)) (T, error)
+}
+
+type stagedExecutor[R any, T any] struct {
+	outer       failsafe.Executor[T]
+	transformer Transformer[R, T]
+	inner       failsafe.Executor[R]
+}
+
+var _ StagedExecutor[any, any] = &stagedExecutor[any, any]{}
+
+// NewStagedExecutor returns a new StagedExecutor that executes funcs via the inner Executor, transforms their results via
+// the transformer, and handles the transformed results via the outer Executor.
+//
+// R is the inner result type and T is the outer result type.
+func NewStagedExecutor[R any, T any](outer failsafe.Executor[T], transformer Transformer[R, T], inner failsafe.Executor[R]) StagedExecutor[R, T] {
+	return &stagedExecutor[R, T]{
+		outer:       outer,
+		transformer: transformer,
+		inner:       inner,
+	}
+}
+
+func (e *stagedExecutor[R, T]) Get(fn func() (R, error)) (T, error) {
+	return e.outer.GetWithExecution(e.stage(func(exec failsafe.Execution[R]) (R, error) {
+		return fn()
+	}))
+}
+
+func (e *stagedExecutor[R, T]) GetWithContext(ctx context.Context, fn func() (R, error)) (T, error) {
+	return e.outer.WithContext(ctx).GetWithExecution(e.stage(func(exec failsafe.Execution[R]) (R, error) {
+		return fn()
+	}))
+}
+
+func (e *stagedExecutor[R, T]) GetWithExecution(fn func(exec failsafe.Execution[R]) (R, error)) (T, error) {
+	return e.outer.GetWithExecution(e.stage(fn))
+}
+
+// stage returns a func for the outer Executor that performs the fn via the inner Executor and transforms its result.
+func (e *stagedExecutor[R, T]) stage(fn func(exec failsafe.Execution[R]) (R, error)) func(exec failsafe.Execution[T]) (T, error) {
+	return e.transformer.FuncWithExecution(func(exec failsafe.Execution[T]) (R, error) {
+		return e.inner.WithContext(exec.Context()).GetWithExecution(fn)
+	})
+}
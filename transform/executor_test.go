@@ -0,0 +1,64 @@
+package transform
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/fallback"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+	"github.com/failsafe-go/failsafe-go/timeout"
+)
+
+// Asserts that inner policies handle raw results while outer policies handle transformed results and errors.
+func TestStagedExecutor(t *testing.T) {
+	// Given
+	inner := failsafe.NewExecutor[string](retrypolicy.Builder[string]().HandleResult("").Build())
+	outer := failsafe.NewExecutor[int](fallback.WithResult(-1))
+	executor := NewStagedExecutor(outer, New(strconv.Atoi), inner)
+
+	// When
+	responses := []string{"", "", "42"}
+	attempts := 0
+	result, err := executor.Get(func() (string, error) {
+		attempts++
+		return responses[attempts-1], nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+	assert.Equal(t, 3, attempts)
+
+	// When
+	result, err = executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		return "invalid", nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, -1, result)
+}
+
+// Asserts that cancellations from the outer Executor are propagated to inner executions.
+func TestStagedExecutorWithOuterTimeout(t *testing.T) {
+	// Given
+	inner := failsafe.NewExecutor[string]()
+	outer := failsafe.NewExecutor[int](timeout.With[int](10 * time.Millisecond))
+	executor := NewStagedExecutor(outer, New(strconv.Atoi), inner)
+
+	// When
+	var innerErr error
+	_, err := executor.GetWithExecution(func(exec failsafe.Execution[string]) (string, error) {
+		<-exec.Canceled()
+		innerErr = exec.Context().Err()
+		return "", innerErr
+	})
+
+	// Then
+	assert.ErrorIs(t, err, timeout.ErrExceeded)
+	assert.Error(t, innerErr)
+}
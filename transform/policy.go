@@ -0,0 +1,57 @@
+package transform
+
+import (
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/common"
+	"github.com/failsafe-go/failsafe-go/internal"
+	"github.com/failsafe-go/failsafe-go/policy"
+)
+
+// AsPolicy returns a Policy that transforms successful execution results via the transformer. Results that fail to be
+// transformed are replaced with the transformer's error, which outer policies, such as retries, handle as a failure.
+// The Policy is typically composed innermost so that all other policies handle transformed results:
+//
+//	validate := transform.Validate(func(user *User) error { ... })
+//	executor := failsafe.NewExecutor[*User](retryPolicy, transform.AsPolicy(validate))
+//
+// R is the execution result type.
+func AsPolicy[R any](transformer Transformer[R, R]) failsafe.Policy[R] {
+	return &transformPolicy[R]{transformer: transformer}
+}
+
+type transformPolicy[R any] struct {
+	transformer Transformer[R, R]
+}
+
+func (p *transformPolicy[R]) ToExecutor(_ R) any {
+	te := &executor[R]{
+		BaseExecutor:    &policy.BaseExecutor[R]{},
+		transformPolicy: p,
+	}
+	te.Executor = te
+	return te
+}
+
+// executor is a policy.Executor that transforms results according to a Transformer.
+type executor[R any] struct {
+	*policy.BaseExecutor[R]
+	*transformPolicy[R]
+}
+
+var _ policy.Executor[any] = &executor[any]{}
+
+func (e *executor[R]) Apply(innerFn func(failsafe.Execution[R]) *common.PolicyResult[R]) func(failsafe.Execution[R]) *common.PolicyResult[R] {
+	return func(exec failsafe.Execution[R]) *common.PolicyResult[R] {
+		result := innerFn(exec)
+		if result.Error != nil {
+			return result
+		}
+		transformed, err := e.transformer.Transform(result.Result)
+		if err != nil {
+			return internal.FailureResult[R](err)
+		}
+		rCopy := *result
+		rCopy.Result = transformed
+		return &rCopy
+	}
+}
@@ -0,0 +1,86 @@
+package transform
+
+import (
+	"github.com/failsafe-go/failsafe-go"
+)
+
+// Transformer transforms execution results of type R into results of type T, returning an error if a result cannot be
+// transformed, such as when it's invalid. Since an Executor's policies all handle the same result type, a Transformer
+// that changes the result type is applied to the func that an Executor for T performs, so that the Executor's policies,
+// such as retries, handle transformation errors along with execution errors:
+//
+//	decode := transform.New(decodeUser)
+//	user, err := failsafe.Get(decode.Func(fetchUser), retryPolicy)
+//
+// Transformers for the same result type can also be composed with other policies via AsPolicy.
+//
+// R is the input result type and T is the transformed result type. This type is concurrency safe.
+type Transformer[R any, T any] interface {
+	// Transform transforms the result, returning an error if the result cannot be transformed.
+	Transform(result R) (T, error)
+
+	// Func returns a func that calls the fn and transforms its result, if the fn does not return an error.
+	Func(fn func() (R, error)) func() (T, error)
+
+	// FuncWithExecution returns a func that calls the fn with the execution and transforms its result, if the fn does not
+	// return an error.
+	FuncWithExecution(fn func(exec failsafe.Execution[T]) (R, error)) func(exec failsafe.Execution[T]) (T, error)
+}
+
+type transformer[R any, T any] struct {
+	fn func(R) (T, error)
+}
+
+var _ Transformer[any, any] = &transformer[any, any]{}
+
+// New returns a new Transformer that transforms results via the fn.
+func New[R any, T any](fn func(result R) (T, error)) Transformer[R, T] {
+	return &transformer[R, T]{fn: fn}
+}
+
+// Validate returns a new Transformer that validates results via the fn, returning results unchanged if the fn returns
+// nil, else returning the fn's error.
+func Validate[R any](fn func(result R) error) Transformer[R, R] {
+	return New(func(result R) (R, error) {
+		if err := fn(result); err != nil {
+			return *new(R), err
+		}
+		return result, nil
+	})
+}
+
+// Then returns a new Transformer that transforms results with the first Transformer and then with the second, stopping
+// at the first error. This can be used to compose typed stages, such as decoding a response and then validating it.
+func Then[R any, T any, U any](first Transformer[R, T], second Transformer[T, U]) Transformer[R, U] {
+	return New(func(result R) (U, error) {
+		t, err := first.Transform(result)
+		if err != nil {
+			return *new(U), err
+		}
+		return second.Transform(t)
+	})
+}
+
+func (t *transformer[R, T]) Transform(result R) (T, error) {
+	return t.fn(result)
+}
+
+func (t *transformer[R, T]) Func(fn func() (R, error)) func() (T, error) {
+	return func() (T, error) {
+		result, err := fn()
+		if err != nil {
+			return *new(T), err
+		}
+		return t.fn(result)
+	}
+}
+
+func (t *transformer[R, T]) FuncWithExecution(fn func(exec failsafe.Execution[T]) (R, error)) func(exec failsafe.Execution[T]) (T, error) {
+	return func(exec failsafe.Execution[T]) (T, error) {
+		result, err := fn(exec)
+		if err != nil {
+			return *new(T), err
+		}
+		return t.fn(result)
+	}
+}
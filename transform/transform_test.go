@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/failsafe-go/failsafe-go"
+	"github.com/failsafe-go/failsafe-go/retrypolicy"
+)
+
+var errInvalid = errors.New("invalid")
+
+// Asserts that a transformation error is handled by an outer RetryPolicy.
+func TestFuncWithRetries(t *testing.T) {
+	// Given
+	parse := New(strconv.Atoi)
+	responses := []string{"invalid", "invalid", "42"}
+	attempts := 0
+	fetch := func() (string, error) {
+		attempts++
+		return responses[attempts-1], nil
+	}
+
+	// When
+	result, err := failsafe.Get(parse.Func(fetch), retrypolicy.WithDefaults[int]())
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestFuncWithError(t *testing.T) {
+	// Given
+	calls := 0
+	parse := New(func(result string) (int, error) {
+		calls++
+		return strconv.Atoi(result)
+	})
+
+	// When
+	_, err := parse.Func(func() (string, error) {
+		return "", errInvalid
+	})()
+
+	// Then
+	assert.ErrorIs(t, err, errInvalid)
+	assert.Equal(t, 0, calls)
+}
+
+func TestThen(t *testing.T) {
+	// Given
+	parse := Then(New(strconv.Atoi), Validate(func(result int) error {
+		if result < 0 {
+			return errInvalid
+		}
+		return nil
+	}))
+
+	// When / Then
+	result, err := parse.Transform("42")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result)
+	_, err = parse.Transform("-1")
+	assert.ErrorIs(t, err, errInvalid)
+	_, err = parse.Transform("invalid")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+// Asserts that a validation failure from a transform policy is retried by an outer RetryPolicy.
+func TestAsPolicy(t *testing.T) {
+	// Given
+	validate := Validate(func(result string) error {
+		if result == "" {
+			return errInvalid
+		}
+		return nil
+	})
+	rp := retrypolicy.Builder[string]().ReturnLastFailure().Build()
+	executor := failsafe.NewExecutor[string](rp, AsPolicy(validate))
+
+	// When
+	attempts := 0
+	result, err := executor.Get(func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", nil
+		}
+		return "valid", nil
+	})
+
+	// Then
+	assert.NoError(t, err)
+	assert.Equal(t, "valid", result)
+	assert.Equal(t, 3, attempts)
+
+	// When
+	_, err = executor.Get(func() (string, error) {
+		return "", nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, errInvalid)
+}